@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Batch output formats
+const (
+	batchFormatCSV   = "csv"
+	batchFormatJSONL = "jsonl"
+	batchFormatJSON  = "json"
+)
+
+// batchColumns are the input columns each row may set: a label, the income,
+// and the same fields as the config file defaults and compare options
+var batchColumns = []string{
+	"label", "income", "rate", "per", "fte", "pro-rata-days",
+	"year", "region", "age", "pension", "pension-scheme", "employer-pension",
+	"student-loan", "tax-code", "extra", "married", "blind", "no-ni", "partner-income",
+	"dividends", "savings-interest", "rental-profit",
+}
+
+// batchBoolColumns are the input columns that take true or false
+var batchBoolColumns = map[string]bool{"married": true, "blind": true, "no-ni": true}
+
+var (
+	flagBatchInput       string
+	flagBatchOutput      string
+	flagBatchFormat      string
+	flagBatchPeriod      string
+	flagBatchConcurrency int
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Calculate tax for many people from a CSV or JSONL file",
+	Long: `Calculate tax for every row of a CSV or JSONL file, such as a salary band
+review, and write one result per row.
+
+Each row has an income and an optional label, plus any of the config file
+defaults: year, region, age, pension, pension-scheme, employer-pension,
+student-loan, tax-code, extra, married, blind, no-ni and partner-income (and
+rate, per, fte, pro-rata-days, dividends, savings-interest and rental-profit).
+Empty or missing values fall back to the config file. CSV input needs a
+header row; JSONL input has one JSON object per line (.jsonl or .ndjson).
+
+Rows are calculated concurrently (--concurrency), and identical rows are only
+calculated once. A row that cannot be calculated gets an error in its result
+rather than stopping the batch.
+
+The output format is csv, jsonl or json, from --format or the --output file's
+extension (default: csv).`,
+	Example: `  listentotaxman batch --input people.csv --output results.csv
+  listentotaxman batch --input people.jsonl --output results.jsonl --period monthly
+  listentotaxman batch --input people.csv --format json --concurrency 8`,
+	RunE: runBatch,
+}
+
+func init() {
+	rootCmd.AddCommand(batchCmd)
+
+	batchCmd.Flags().StringVar(&flagBatchInput, "input", "", "CSV or JSONL file of people to calculate (required)")
+	batchCmd.Flags().StringVar(&flagBatchOutput, "output", "", "File to write the results to (default: standard output)")
+	batchCmd.Flags().StringVar(&flagBatchFormat, "format", "", "Output format (csv, jsonl, json) (default: from --output, or csv)")
+	batchCmd.Flags().StringVar(&flagBatchPeriod, "period", "", "Display period (yearly, quarterly, monthly, four-weekly, fortnightly, weekly, daily, hourly) (default: yearly)")
+	batchCmd.Flags().IntVar(&flagBatchConcurrency, "concurrency", 4, "Number of rows to calculate at once")
+}
+
+// batchRow is one row of batch input: its row number, label and fields
+type batchRow struct {
+	Row    int
+	Label  string
+	Fields map[string]string
+	Err    error
+}
+
+func runBatch(cmd *cobra.Command, _ []string) error {
+	// Load config file
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if flagBatchInput == "" {
+		return fmt.Errorf("--input is required")
+	}
+	if flagBatchConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got: %d", flagBatchConcurrency)
+	}
+	format, err := batchOutputFormat(flagBatchFormat, flagBatchOutput)
+	if err != nil {
+		return err
+	}
+
+	// Period: flag > config > default yearly
+	period := firstNonEmpty(flagBatchPeriod, cfg.Defaults.Period, payperiod.Yearly)
+	if err := payperiod.Validate(period); err != nil {
+		return err
+	}
+
+	rows, err := readBatchFile(flagBatchInput)
+	if err != nil {
+		return err
+	}
+
+	results, calculations := calculateBatch(rows, cfg, period, flagBatchConcurrency)
+
+	out := cmd.OutOrStdout()
+	if flagBatchOutput != "" {
+		file, err := os.Create(flagBatchOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+	if err := writeBatchResults(out, format, results); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Calculated %d of %d rows (%d failed, %d unique calculations)\n",
+		len(results)-failed, len(results), failed, calculations)
+	return nil
+}
+
+// batchOutputFormat returns the output format from --format, or the output
+// file's extension
+func batchOutputFormat(format, output string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(output)) {
+		case ".jsonl", ".ndjson":
+			return batchFormatJSONL, nil
+		case ".json":
+			return batchFormatJSON, nil
+		default:
+			return batchFormatCSV, nil
+		}
+	}
+
+	switch format {
+	case batchFormatCSV, batchFormatJSONL, batchFormatJSON:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid format: %s (must be one of: csv, jsonl, json)", format)
+}
+
+// readBatchFile reads batch rows from a CSV file, or a JSONL file by its extension
+func readBatchFile(path string) ([]batchRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var rows []batchRow
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		rows, err = readBatchJSONL(file)
+	default:
+		rows, err = readBatchCSV(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	return rows, nil
+}
+
+// readBatchCSV reads batch rows from CSV with a header row of column names
+func readBatchCSV(r io.Reader) ([]batchRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header row: %w", err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(name))
+		if err := validateBatchColumn(columns[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	rows := []batchRow{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string]string, len(record))
+		for i, value := range record {
+			values[columns[i]] = value
+		}
+		rows = append(rows, newBatchRow(len(rows)+1, values))
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no rows found")
+	}
+	return rows, nil
+}
+
+// readBatchJSONL reads batch rows from one JSON object per line. Blank lines
+// are skipped.
+func readBatchJSONL(r io.Reader) ([]batchRow, error) {
+	scanner := bufio.NewScanner(r)
+	rows := []batchRow{}
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &object); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		values, err := batchJSONValues(object)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rows = append(rows, newBatchRow(len(rows)+1, values))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no rows found")
+	}
+	return rows, nil
+}
+
+// batchJSONValues converts a JSON object's values into column values
+func batchJSONValues(object map[string]interface{}) (map[string]string, error) {
+	values := make(map[string]string, len(object))
+	for key, value := range object {
+		column := strings.ToLower(key)
+		if err := validateBatchColumn(column); err != nil {
+			return nil, err
+		}
+		switch v := value.(type) {
+		case nil:
+		case string:
+			values[column] = v
+		case float64:
+			values[column] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			values[column] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("%s must be a string, number or boolean", key)
+		}
+	}
+	return values, nil
+}
+
+// validateBatchColumn checks that an input column is one a row may set
+func validateBatchColumn(column string) error {
+	for _, valid := range batchColumns {
+		if column == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown column: %s (must be one of: %s)", column, strings.Join(batchColumns, ", "))
+}
+
+// newBatchRow builds a row from its column values, leaving out empty values so
+// they fall back to the config file. A value that can't be read is kept as the
+// row's error, so the row is reported without stopping the others.
+func newBatchRow(row int, values map[string]string) batchRow {
+	fields, err := batchFields(values)
+
+	label := strings.TrimSpace(values["label"])
+	if label == "" {
+		label = fmt.Sprintf("Row %d", row)
+	}
+	return batchRow{Row: row, Label: label, Fields: fields, Err: err}
+}
+
+// batchFields returns the field values of a row without the label or empty
+// values. Booleans accept true/false, yes/no, y/n and 1/0.
+func batchFields(values map[string]string) (map[string]string, error) {
+	fields := make(map[string]string, len(values))
+	for _, column := range slices.Sorted(maps.Keys(values)) {
+		value := strings.TrimSpace(values[column])
+		if value == "" || column == "label" {
+			continue
+		}
+		if batchBoolColumns[column] {
+			switch strings.ToLower(value) {
+			case flagValueTrue, "yes", "y", "1":
+				value = flagValueTrue
+			case "false", "no", "n", "0":
+				value = "false"
+			default:
+				return nil, fmt.Errorf("invalid %s: %s (must be one of: true, false, yes, no, y, n, 1, 0)", column, value)
+			}
+		}
+		fields[column] = value
+	}
+	return fields, nil
+}
+
+// calculateBatch calculates every row with up to concurrency rows at once,
+// returning the results in row order and the number of unique calculations.
+// Money figures are divided into the display period.
+func calculateBatch(rows []batchRow, cfg *config.Config, period string, concurrency int) ([]types.BatchResult, int) {
+	cache := newCalculationCache(0)
+	results := make([]types.BatchResult, len(rows))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = calculateBatchRow(rows[i], cfg, period, cache)
+			}
+		}()
+	}
+	for i := range rows {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, cache.size()
+}
+
+// calculateBatchRow builds, validates and calculates a single row
+func calculateBatchRow(row batchRow, cfg *config.Config, period string, cache *calculationCache) types.BatchResult {
+	result := types.BatchResult{Row: row.Row, Label: row.Label}
+	if row.Err != nil {
+		result.Error = row.Err.Error()
+		return result
+	}
+
+	req, err := buildCheckRequestFromFields(row.Fields, cfg)
+	if err == nil {
+		err = applyProRata(req)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	resp, err := calculateCached(req, period, cache)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Result = resp
+	return result
+}
+
+// buildCheckRequestFromFields builds and validates a check request from
+// column or field values, converting any rate of pay into the annual salary
+func buildCheckRequestFromFields(fields map[string]string, cfg *config.Config) (*types.TaxRequest, error) {
+	req, err := buildTaxRequest(fields, cfg)
+	if err == nil {
+		err = applyRate(req)
+	}
+	if err == nil {
+		err = validateCheckRequest(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// calculateCached calculates a request through the cache and divides the
+// result into the period
+func calculateCached(req *types.TaxRequest, period string, cache *calculationCache) (*types.TaxResponse, error) {
+	resp, err := cache.calculate(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate tax: %w", err)
+	}
+
+	// Requests share cached responses, so copy before adding this request's rate
+	reqResp := *resp
+	reqResp.Rate = req.Rate
+	return adjustResponseForPeriod(&reqResp, period, req.WorkPattern), nil
+}
+
+// writeBatchResults writes the results in the output format
+func writeBatchResults(w io.Writer, format string, results []types.BatchResult) error {
+	switch format {
+	case batchFormatJSONL:
+		encoder := json.NewEncoder(w)
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	case batchFormatJSON:
+		jsonData, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(jsonData))
+		return err
+	default:
+		return writeBatchCSV(w, results)
+	}
+}
+
+// batchCSVFields are the result columns written to CSV after the row and label
+var batchCSVFields = []struct {
+	name      string
+	extractor func(*types.TaxResponse) float64
+}{
+	{"gross_pay", func(r *types.TaxResponse) float64 { return r.GrossPay }},
+	{"taxable_pay", func(r *types.TaxResponse) float64 { return r.TaxablePay }},
+	{"tax_paid", func(r *types.TaxResponse) float64 { return r.TaxPaid }},
+	{"national_insurance", func(r *types.TaxResponse) float64 { return r.NationalInsurance }},
+	{"student_loan", func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment }},
+	{"pension", func(r *types.TaxResponse) float64 { return r.PensionYou }},
+	{"net_pay", func(r *types.TaxResponse) float64 { return r.NetPay }},
+	{"employers_ni", func(r *types.TaxResponse) float64 { return r.EmployersNI }},
+	{"total_cost", display.TotalCost},
+}
+
+// writeBatchCSV writes one CSV line per result, with the error for rows that failed
+func writeBatchCSV(w io.Writer, results []types.BatchResult) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"row", "label", "tax_year", "tax_code"}
+	for _, field := range batchCSVFields {
+		header = append(header, field.name)
+	}
+	if err := writer.Write(append(header, "error")); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		record := []string{strconv.Itoa(result.Row), result.Label}
+		if result.Result == nil {
+			record = append(record, "", "")
+			for range batchCSVFields {
+				record = append(record, "")
+			}
+		} else {
+			record = append(record, strconv.Itoa(result.Result.TaxYear), result.Result.TaxCode)
+			for _, field := range batchCSVFields {
+				record = append(record, strconv.FormatFloat(field.extractor(result.Result), 'f', 2, 64))
+			}
+		}
+		if err := writer.Write(append(record, result.Error)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
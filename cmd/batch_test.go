@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+const batchCSV = `label,income,year,pension,pension-scheme,student-loan,married
+Alice,45000,2025,5%,sacrifice,plan2,
+Bob,45000,2025,5%,sacrifice,plan2,no
+,30000,2025,3%,relief-at-source,,yes
+Dan,abc,2025,5%,sacrifice,,
+`
+
+func TestReadBatchCSV(t *testing.T) {
+	t.Parallel()
+
+	rows, err := readBatchCSV(strings.NewReader(batchCSV))
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, batchRow{
+		Row:   1,
+		Label: "Alice",
+		Fields: map[string]string{
+			"income": "45000", "year": "2025", "pension": "5%", "pension-scheme": "sacrifice", "student-loan": "plan2",
+		},
+	}, rows[0])
+
+	// Booleans are normalised and a missing label is numbered
+	assert.Equal(t, "false", rows[1].Fields["married"])
+	assert.Equal(t, "Row 3", rows[2].Label)
+	assert.Equal(t, flagValueTrue, rows[2].Fields["married"])
+}
+
+func TestReadBatchCSV_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := readBatchCSV(strings.NewReader(""))
+	assert.ErrorContains(t, err, "missing header row")
+
+	_, err = readBatchCSV(strings.NewReader("label,salary\nAlice,45000\n"))
+	assert.ErrorContains(t, err, "unknown column: salary")
+
+	_, err = readBatchCSV(strings.NewReader("label,income\n"))
+	assert.EqualError(t, err, "no rows found")
+}
+
+func TestReadBatchJSONL(t *testing.T) {
+	t.Parallel()
+
+	input := `{"label": "Alice", "income": 45000, "pension": "5%", "blind": true}
+
+{"income": 30000.5, "region": null}
+`
+	rows, err := readBatchJSONL(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, map[string]string{"income": "45000", "pension": "5%", "blind": flagValueTrue}, rows[0].Fields)
+	assert.Equal(t, "Row 2", rows[1].Label)
+	assert.Equal(t, map[string]string{"income": "30000.5"}, rows[1].Fields)
+
+	_, err = readBatchJSONL(strings.NewReader(`{"income": [1, 2]}`))
+	assert.EqualError(t, err, "line 1: income must be a string, number or boolean")
+
+	_, err = readBatchJSONL(strings.NewReader(`{"salary": 1}`))
+	assert.ErrorContains(t, err, "line 1: unknown column: salary")
+
+	_, err = readBatchJSONL(strings.NewReader("not json\n"))
+	assert.ErrorContains(t, err, "line 1:")
+}
+
+func TestBatchOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format string
+		output string
+		want   string
+	}{
+		{"", "", batchFormatCSV},
+		{"", "results.csv", batchFormatCSV},
+		{"", "results.jsonl", batchFormatJSONL},
+		{"", "results.NDJSON", batchFormatJSONL},
+		{"", "results.json", batchFormatJSON},
+		{"json", "results.csv", batchFormatJSON},
+	}
+
+	for _, tt := range tests {
+		format, err := batchOutputFormat(tt.format, tt.output)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, format, "format %q output %q", tt.format, tt.output)
+	}
+
+	_, err := batchOutputFormat("xml", "")
+	assert.EqualError(t, err, "invalid format: xml (must be one of: csv, jsonl, json)")
+}
+
+func TestCalculateBatch(t *testing.T) {
+	t.Parallel()
+
+	rows, err := readBatchCSV(strings.NewReader(batchCSV))
+	require.NoError(t, err)
+
+	results, calculations := calculateBatch(rows, &config.Config{}, "monthly", 4)
+	require.Len(t, results, 4)
+
+	// Alice and Bob share a calculation
+	assert.Equal(t, 2, calculations)
+	for i, result := range results {
+		assert.Equal(t, i+1, result.Row)
+	}
+
+	assert.Equal(t, "Alice", results[0].Label)
+	require.NotNil(t, results[0].Result)
+	assert.InDelta(t, 3750.0, results[0].Result.GrossPay, 0.01)
+	assert.Equal(t, results[0].Result.TaxDue, results[1].Result.TaxDue)
+
+	assert.Equal(t, "relief-at-source", results[2].Result.PensionScheme.Scheme)
+
+	assert.Nil(t, results[3].Result)
+	assert.Equal(t, "income must be a valid number: abc", results[3].Error)
+}
+
+func TestCalculateBatch_ValidationError(t *testing.T) {
+	t.Parallel()
+
+	rows := []batchRow{newBatchRow(1, map[string]string{"income": "45000", "year": "2025", "student-loan": "plan9"})}
+	results, calculations := calculateBatch(rows, &config.Config{}, "yearly", 1)
+
+	assert.Zero(t, calculations)
+	assert.Contains(t, results[0].Error, "invalid student loan plan: plan9")
+}
+
+func TestCalculateBatch_InvalidBoolean(t *testing.T) {
+	t.Parallel()
+
+	// An unrecognised boolean is the row's error rather than false
+	rows := []batchRow{newBatchRow(1, map[string]string{"income": "45000", "year": "2025", "married": "maybe"})}
+	results, calculations := calculateBatch(rows, &config.Config{}, "yearly", 1)
+
+	assert.Zero(t, calculations)
+	assert.Nil(t, results[0].Result)
+	assert.Equal(t, "invalid married: maybe (must be one of: true, false, yes, no, y, n, 1, 0)", results[0].Error)
+
+	row := newBatchRow(2, map[string]string{"blind": "N", "no-ni": "0"})
+	require.NoError(t, row.Err)
+	assert.Equal(t, map[string]string{"blind": "false", "no-ni": "false"}, row.Fields)
+}
+
+func TestWriteBatchResults(t *testing.T) {
+	t.Parallel()
+
+	results := []types.BatchResult{
+		{Row: 1, Label: "Alice", Result: testutil.CreateSampleTaxResponse()},
+		{Row: 2, Label: "Dan", Error: "income must be greater than 0"},
+	}
+
+	var csvOut bytes.Buffer
+	require.NoError(t, writeBatchResults(&csvOut, batchFormatCSV, results))
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "row,label,tax_year,tax_code,gross_pay,taxable_pay,tax_paid,national_insurance,student_loan,pension,net_pay,employers_ni,total_cost,error", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "1,Alice,"))
+	assert.Contains(t, lines[1], ",50000.00,")
+	assert.Contains(t, lines[1], ",38295.84,")
+	assert.Equal(t, "2,Dan,,,,,,,,,,,,income must be greater than 0", lines[2])
+
+	var jsonlOut bytes.Buffer
+	require.NoError(t, writeBatchResults(&jsonlOut, batchFormatJSONL, results))
+	jsonLines := strings.Split(strings.TrimSpace(jsonlOut.String()), "\n")
+	require.Len(t, jsonLines, 2)
+	var first types.BatchResult
+	require.NoError(t, json.Unmarshal([]byte(jsonLines[0]), &first))
+	assert.Equal(t, "Alice", first.Label)
+	assert.InDelta(t, 38295.84, first.Result.NetPay, 0.001)
+	assert.JSONEq(t, `{"row": 2, "label": "Dan", "error": "income must be greater than 0"}`, jsonLines[1])
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, writeBatchResults(&jsonOut, batchFormatJSON, results))
+	var all []types.BatchResult
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &all))
+	assert.Len(t, all, 2)
+}
+
+func TestRunBatch(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "people.csv")
+	require.NoError(t, os.WriteFile(input, []byte(batchCSV), 0600))
+
+	flagBatchInput = input
+	flagBatchOutput = filepath.Join(dir, "results.jsonl")
+	flagBatchConcurrency = 2
+	t.Cleanup(func() {
+		flagBatchInput = ""
+		flagBatchOutput = ""
+		flagBatchFormat = ""
+		flagBatchPeriod = ""
+		flagBatchConcurrency = 4
+		batchCmd.SetErr(nil)
+	})
+
+	var stderr bytes.Buffer
+	batchCmd.SetErr(&stderr)
+	require.NoError(t, runBatch(batchCmd, []string{}))
+	assert.Equal(t, "Calculated 3 of 4 rows (1 failed, 2 unique calculations)\n", stderr.String())
+
+	data, err := os.ReadFile(flagBatchOutput)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 4)
+
+	// The config file's weekly period applies
+	var alice types.BatchResult
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &alice))
+	assert.InDelta(t, 45000.0/52, alice.Result.GrossPay, 0.01)
+}
+
+func TestRunBatch_Errors(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+	t.Cleanup(func() {
+		flagBatchInput = ""
+		flagBatchConcurrency = 4
+	})
+
+	flagBatchInput = ""
+	assert.EqualError(t, runBatch(batchCmd, []string{}), "--input is required")
+
+	flagBatchInput = "people.csv"
+	flagBatchConcurrency = 0
+	assert.EqualError(t, runBatch(batchCmd, []string{}), "--concurrency must be at least 1, got: 0")
+
+	flagBatchConcurrency = 4
+	flagBatchInput = filepath.Join(t.TempDir(), "missing.csv")
+	assert.ErrorContains(t, runBatch(batchCmd, []string{}), "failed to open input")
+}
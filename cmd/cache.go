@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// calculationCache calculates each distinct request once, however many rows
+// or HTTP requests share it
+type calculationCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*calculationCacheEntry
+}
+
+// calculationCacheEntry is one request's calculation, shared by every caller that needs it
+type calculationCacheEntry struct {
+	once sync.Once
+	resp *types.TaxResponse
+	err  error
+}
+
+// newCalculationCache creates a cache holding at most maxEntries calculations,
+// or any number when maxEntries is 0
+func newCalculationCache(maxEntries int) *calculationCache {
+	return &calculationCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*calculationCacheEntry),
+	}
+}
+
+// calculate returns the calculation for a request, calculating it on first use
+func (c *calculationCache) calculate(req *types.TaxRequest) (*types.TaxResponse, error) {
+	// The rate of pay and pro rata fraction only describe how the income was
+	// reached, so requests for the same income share a calculation
+	keyReq := *req
+	keyReq.Rate = nil
+	keyReq.ProRata = nil
+	key := fmt.Sprintf("%+v", keyReq)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		if c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+			// Start again rather than track usage; a full cache is rare
+			c.entries = make(map[string]*calculationCacheEntry)
+		}
+		entry = &calculationCacheEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.resp, entry.err = calculateCheck(req)
+	})
+
+	if entry.err != nil {
+		// Don't keep failures, so a later request tries the API again
+		c.mu.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	return entry.resp, entry.err
+}
+
+// size returns the number of calculations held
+func (c *calculationCache) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
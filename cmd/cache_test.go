@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestCalculationCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newCalculationCache(2)
+	sacrifice := func(income int) *types.TaxRequest {
+		return testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+			r.GrossWage = income
+			r.Pension = "5%"
+			r.PensionScheme = "sacrifice"
+		})
+	}
+
+	first, err := cache.calculate(sacrifice(45000))
+	require.NoError(t, err)
+
+	// The same income reached from a rate of pay shares the calculation
+	fromRate := sacrifice(45000)
+	fromRate.Rate = &types.IncomeRate{Rate: 45000, Per: "year"}
+	second, err := cache.calculate(fromRate)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, cache.size())
+
+	_, err = cache.calculate(sacrifice(50000))
+	require.NoError(t, err)
+	assert.Equal(t, 2, cache.size())
+
+	// A full cache starts again
+	_, err = cache.calculate(sacrifice(55000))
+	require.NoError(t, err)
+	assert.Equal(t, 1, cache.size())
+}
+
+func TestCalculationCache_DropsErrors(t *testing.T) {
+	t.Parallel()
+
+	cache := newCalculationCache(0)
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Year = "1999"
+		r.PensionScheme = "sacrifice"
+	})
+
+	_, err := cache.calculate(req)
+	require.Error(t, err)
+	assert.Zero(t, cache.size())
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/terminal"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// chartDefaultWidth is the chart width when the terminal's can't be found
+const chartDefaultWidth = 80
+
+// validateChartFlags checks that charts are only asked for alongside the
+// tables, and that --no-unicode has a chart to apply to
+func validateChartFlags(chart, noUnicode, jsonOutput bool) error {
+	if noUnicode && !chart {
+		return fmt.Errorf("--no-unicode requires --chart")
+	}
+	if chart && jsonOutput {
+		return fmt.Errorf("--chart cannot be used with --json")
+	}
+	return nil
+}
+
+// chartStyle returns the chart style for the terminal width, drawn in ASCII
+// with --no-unicode or an ASCII or plain output style
+func chartStyle(noUnicode bool) display.ChartStyle {
+	ascii := noUnicode || display.CurrentStyle() != display.StyleUnicode
+	return display.ChartStyle{Width: chartWidth(), ASCII: ascii}
+}
+
+// chartWidth returns the width of the terminal, then $COLUMNS, then the default
+func chartWidth() int {
+	fd := int(os.Stdout.Fd()) // #nosec G115 -- file descriptors fit in an int
+	if width, _, err := terminal.Size(fd); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return chartDefaultWidth
+}
+
+// printChart draws the results' charts after the tables
+func printChart(results []types.ComparisonResult, period string, noUnicode bool) {
+	display.Chart(results, period, chartStyle(noUnicode))
+}
+
+// chartExportFormat returns the image format for a chart file, by its extension
+func chartExportFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		return display.ChartSVG, nil
+	case ".png":
+		return display.ChartPNG, nil
+	default:
+		return "", fmt.Errorf("--export-chart must be a .svg or .png file, got: %s", path)
+	}
+}
+
+// exportChart writes the results' charts to an SVG or PNG file
+func exportChart(path string, results []types.ComparisonResult, period string) error {
+	format, err := chartExportFormat(path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path) // #nosec G304 -- the user chooses where to write
+	if err != nil {
+		return fmt.Errorf("failed to create chart file: %w", err)
+	}
+	if err := display.ExportChart(file, format, results, period); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+	return nil
+}
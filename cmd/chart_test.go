@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateChartFlags(t *testing.T) {
+	assert.NoError(t, validateChartFlags(false, false, true))
+	assert.NoError(t, validateChartFlags(true, true, false))
+	assert.EqualError(t, validateChartFlags(false, true, false), "--no-unicode requires --chart")
+	assert.EqualError(t, validateChartFlags(true, false, true), "--chart cannot be used with --json")
+}
+
+func TestChartWidth_Columns(t *testing.T) {
+	// Stdout is not a terminal under go test, so $COLUMNS is used
+	t.Setenv("COLUMNS", "100")
+	assert.Equal(t, 100, chartWidth())
+
+	t.Setenv("COLUMNS", "wide")
+	assert.Equal(t, chartDefaultWidth, chartWidth())
+}
+
+func TestChartExportFormat(t *testing.T) {
+	format, err := chartExportFormat("report/chart.SVG")
+	assert.NoError(t, err)
+	assert.Equal(t, "svg", format)
+
+	format, err = chartExportFormat("chart.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "png", format)
+
+	_, err = chartExportFormat("chart")
+	assert.EqualError(t, err, "--export-chart must be a .svg or .png file, got: chart")
+}
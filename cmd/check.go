@@ -4,7 +4,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -12,30 +14,55 @@ import (
 	"github.com/mheap/listentotaxman-cli/internal/client"
 	"github.com/mheap/listentotaxman-cli/internal/config"
 	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
 	"github.com/mheap/listentotaxman-cli/internal/types"
 )
 
 var (
-	flagYear          string
-	flagRegion        string
-	flagAge           string
-	flagPension       string
-	flagIncome        int
-	flagStudentLoan   string
-	flagExtra         int
-	flagTaxCode       string
-	flagJSON          bool
-	flagVerbose       bool
-	flagPeriod        string
-	flagMarried       bool
-	flagBlind         bool
-	flagNoNI          bool
-	flagPartnerIncome int
+	flagYear            string
+	flagRegion          string
+	flagAge             string
+	flagPension         string
+	flagPensionScheme   string
+	flagEmployerPension string
+	flagIncome          int
+	flagRate            float64
+	flagPer             string
+	flagFTE             float64
+	flagProRataDays     float64
+	flagStudentLoan     string
+	flagExtra           int
+	flagTaxCode         string
+	flagJSON            bool
+	flagVerbose         bool
+	flagPeriod          string
+	flagMarried         bool
+	flagBlind           bool
+	flagNoNI            bool
+	flagPartnerIncome   int
+	flagSelfEmployed    bool
+	flagProfit          int
+	flagDividends       int
+	flagSavings         int
+	flagRentalProfit    int
+	flagJobs            []string
+	flagHoursPerWeek    float64
+	flagDaysPerWeek     float64
+	flagHolidayDays     float64
+	flagPayday          string
+	flagVsPrevious      bool
+	flagYears           string
+	flagReal            bool
+	flagCPIFile         string
+	flagBaseYear        string
+	flagChart           bool
+	flagNoUnicode       bool
+	flagExplain         bool
 )
 
-const (
-	periodYearly = "yearly"
-)
+// maxYears is the most tax years --years shows side by side
+const maxYears = 5
 
 // timeNowFunc allows time mocking in tests
 var timeNowFunc = time.Now
@@ -48,8 +75,48 @@ var checkClientFactory = func() *client.Client {
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check tax calculation for a given salary",
-	Long:  `Calculate UK tax and national insurance for a given salary and parameters.`,
-	RunE:  runCheck,
+	Long: `Calculate UK tax and national insurance for a given salary and parameters.
+
+With --self-employed, the calculation is run locally for a sole trader's
+trading profit (--profit): income tax, Class 2 and Class 4 National Insurance
+and payments on account replace PAYE and employer National Insurance.
+
+With one or more --job flags (LABEL:INCOME:TAXCODE[:PENSION]), each job is
+taxed locally under its own tax code with separate National Insurance, and the
+PAYE deducted is reconciled against the year-end liability on combined income
+to show the expected under or overpayment.
+
+With --rate and --per, an hourly, daily, weekly or monthly rate of pay is
+converted to an annual salary using the working pattern (--hours-per-week,
+--days-per-week and --holiday-days), and the rate is shown with the results.
+
+With --pension-scheme, the pension is calculated locally as salary sacrifice
+(before tax and NI), a net pay arrangement (before tax) or relief at source
+(from net pay, with a 20% top-up and higher rate relief claimed back). --extra
+is added to taxable pay, and no employee NI is charged from State Pension age.
+--employer-pension adds the employer's contribution to the employer costs.
+
+With --fte or --pro-rata-days, --income is the full-time-equivalent salary:
+pay, tax and NI are calculated on the pro-rata salary and shown alongside the
+full-time figures.
+
+With --vs-previous or --years (e.g. 2022..2025), the same salary is calculated
+in each tax year and shown side by side with the change, along with the
+allowances, thresholds and rates that changed or were frozen. Add --real to
+show gross and net pay in constant prices, adjusted by CPI, to see whether pay
+kept up with inflation. The CPI index is built in, or given with --cpi-file as
+a CSV with year and cpi columns; --base-year chooses the prices (default: the
+latest year shown).
+
+With --chart, where each pound goes (tax, NI, student loan, pension and net
+pay) is drawn as a bar for each year shown, fitted to the terminal width.
+--no-unicode draws it in plain ASCII.
+
+With --explain, the calculation is traced step by step: how pension relief is
+given, the personal allowance and any taper, how taxable pay fills each tax
+band, the National Insurance and student loan thresholds, and the net pay
+left, with the amount each step produces. JSON output includes the steps.`,
+	RunE: runCheck,
 }
 
 func init() {
@@ -60,7 +127,13 @@ func init() {
 	checkCmd.Flags().StringVar(&flagRegion, "region", "", "Tax region (default: uk)")
 	checkCmd.Flags().StringVar(&flagAge, "age", "", "Age (default: 0)")
 	checkCmd.Flags().StringVar(&flagPension, "pension", "", "Pension contribution (e.g., 3% or 3000)")
-	checkCmd.Flags().IntVar(&flagIncome, "income", 0, "Gross annual salary (required)")
+	checkCmd.Flags().StringVar(&flagPensionScheme, "pension-scheme", "", "Pension scheme (sacrifice, net-pay, relief-at-source), calculated locally")
+	checkCmd.Flags().StringVar(&flagEmployerPension, "employer-pension", "", "Employer pension contribution (e.g., 5% or 3000)")
+	checkCmd.Flags().IntVar(&flagIncome, "income", 0, "Gross annual salary (required unless --self-employed)")
+	checkCmd.Flags().Float64Var(&flagRate, "rate", 0, "Rate of pay, converted to an annual salary with --per (instead of --income)")
+	checkCmd.Flags().StringVar(&flagPer, "per", "", "Unit for --rate (hour, day, week, month)")
+	checkCmd.Flags().Float64Var(&flagFTE, "fte", 0, "Fraction of full-time worked (e.g., 0.6), with --income as the full-time salary")
+	checkCmd.Flags().Float64Var(&flagProRataDays, "pro-rata-days", 0, "Days worked of a full-time week (e.g., 3), with --income as the full-time salary")
 	checkCmd.Flags().StringVar(&flagStudentLoan, "student-loan", "", "Student loan plan (e.g., plan1, plan2, plan4, postgraduate, scottish)")
 	checkCmd.Flags().IntVar(&flagExtra, "extra", 0, "Extra income/deductions")
 	checkCmd.Flags().StringVar(&flagTaxCode, "tax-code", "", "Tax code (e.g., 1257L, K12)")
@@ -68,12 +141,27 @@ func init() {
 	checkCmd.Flags().BoolVar(&flagBlind, "blind", false, "Blind person's allowance")
 	checkCmd.Flags().BoolVar(&flagNoNI, "no-ni", false, "Exempt from National Insurance")
 	checkCmd.Flags().IntVar(&flagPartnerIncome, "partner-income", 0, "Partner's gross wage (requires --married)")
+	checkCmd.Flags().BoolVar(&flagSelfEmployed, "self-employed", false, "Calculate as a self-employed sole trader (requires --profit)")
+	checkCmd.Flags().IntVar(&flagProfit, "profit", 0, "Annual trading profit (requires --self-employed)")
+	checkCmd.Flags().IntVar(&flagDividends, "dividends", 0, "Annual dividend income")
+	checkCmd.Flags().IntVar(&flagSavings, "savings-interest", 0, "Annual savings interest")
+	checkCmd.Flags().IntVar(&flagRentalProfit, "rental-profit", 0, "Annual rental profit")
+	checkCmd.Flags().StringArrayVar(&flagJobs, "job", nil, "Employment as LABEL:INCOME:TAXCODE[:PENSION] (repeatable, e.g., Main:60000:1257L)")
 	checkCmd.Flags().BoolVar(&flagJSON, "json", false, "Output as JSON")
 	checkCmd.Flags().BoolVar(&flagVerbose, "verbose", false, "Show detailed breakdown")
-	checkCmd.Flags().StringVar(&flagPeriod, "period", "", "Display period (yearly, monthly, weekly, daily, hourly) (default: yearly)")
-
-	// Mark required flags
-	_ = checkCmd.MarkFlagRequired("income")
+	checkCmd.Flags().StringVar(&flagPeriod, "period", "", "Display period (yearly, quarterly, monthly, four-weekly, fortnightly, weekly, daily, hourly) (default: yearly)")
+	checkCmd.Flags().StringVar(&flagPayday, "payday", "", "Any pay date (YYYY-MM-DD), to count weekly, fortnightly or four-weekly pay days in the tax year (e.g. 53 weeks)")
+	checkCmd.Flags().Float64Var(&flagHoursPerWeek, "hours-per-week", 0, "Hours worked per week, for hourly figures (default: 40)")
+	checkCmd.Flags().Float64Var(&flagDaysPerWeek, "days-per-week", 0, "Days worked per week, for daily and hourly figures (default: 5)")
+	checkCmd.Flags().Float64Var(&flagHolidayDays, "holiday-days", 0, "Days of holiday a year, taken off daily and hourly figures")
+	checkCmd.Flags().BoolVar(&flagVsPrevious, "vs-previous", false, "Show the same salary in the previous tax year alongside, with what changed")
+	checkCmd.Flags().StringVar(&flagYears, "years", "", "Range of tax years to show the same salary across (e.g., 2022..2025)")
+	checkCmd.Flags().BoolVar(&flagReal, "real", false, "Show pay in constant prices, adjusted by CPI (requires --vs-previous or --years)")
+	checkCmd.Flags().StringVar(&flagCPIFile, "cpi-file", "", "CSV of year and cpi columns to use instead of the built-in CPI index (requires --real)")
+	checkCmd.Flags().StringVar(&flagBaseYear, "base-year", "", "Year whose prices --real figures are shown in (default: the latest year shown)")
+	checkCmd.Flags().BoolVar(&flagChart, "chart", false, "Also chart where each pound goes")
+	checkCmd.Flags().BoolVar(&flagNoUnicode, "no-unicode", false, "Draw charts with plain ASCII (requires --chart)")
+	checkCmd.Flags().BoolVar(&flagExplain, "explain", false, "Show how each figure is worked out, step by step")
 }
 
 // getDefaultYear returns the default tax year based on current date
@@ -92,31 +180,13 @@ func getDefaultYear() string {
 	return strconv.Itoa(year - 1)
 }
 
-// getPeriodDivisor returns the divisor for a given period
-func getPeriodDivisor(period string) float64 {
-	switch period {
-	case periodYearly:
-		return 1.0
-	case "monthly":
-		return 12.0
-	case "weekly":
-		return 52.0
-	case "daily":
-		return 365.0
-	case "hourly":
-		return 2080.0
-	default:
-		return 1.0
-	}
-}
-
 // adjustResponseForPeriod creates a copy of the response with values adjusted for the period
-func adjustResponseForPeriod(resp *types.TaxResponse, period string) *types.TaxResponse {
-	if period == periodYearly {
+func adjustResponseForPeriod(resp *types.TaxResponse, period string, pattern types.WorkPattern) *types.TaxResponse {
+	if period == payperiod.Yearly {
 		return resp
 	}
 
-	divisor := getPeriodDivisor(period)
+	divisor := payperiod.Divisor(period, pattern)
 
 	// Create a copy
 	adjusted := *resp
@@ -139,20 +209,122 @@ func adjustResponseForPeriod(resp *types.TaxResponse, period string) *types.TaxR
 	adjusted.GrossSacrifice /= divisor
 	adjusted.ChildcareAmount /= divisor
 
-	// Adjust tax brackets
-	for key, bracket := range adjusted.TaxDue {
-		bracket.Amount /= divisor
-		adjusted.TaxDue[key] = bracket
+	// Adjust self-employment figures
+	if adjusted.SelfEmployment != nil {
+		selfEmployment := *adjusted.SelfEmployment
+		selfEmployment.Profit /= divisor
+		selfEmployment.Class2NI /= divisor
+		selfEmployment.Class4NI /= divisor
+		selfEmployment.TotalLiability /= divisor
+		selfEmployment.PaymentOnAccount /= divisor
+		adjusted.SelfEmployment = &selfEmployment
+	}
+
+	// Adjust pension contributions and savings
+	if adjusted.PensionScheme != nil {
+		scheme := *adjusted.PensionScheme
+		scheme.Contribution /= divisor
+		scheme.BasicRateTopUp /= divisor
+		scheme.HigherRateRelief /= divisor
+		scheme.EmployeeNISaving /= divisor
+		scheme.EmployerNISaving /= divisor
+		scheme.Employer /= divisor
+		scheme.Total /= divisor
+		adjusted.PensionScheme = &scheme
+	}
+
+	// Adjust other income, including each band portion
+	if adjusted.OtherIncome != nil {
+		otherIncome := *adjusted.OtherIncome
+		otherIncome.RentalProfit /= divisor
+		otherIncome.SavingsInterest /= divisor
+		otherIncome.Dividends /= divisor
+		otherIncome.AllowanceLost /= divisor
+		otherIncome.Tax /= divisor
+		otherIncome.Bands = make([]types.IncomeBand, len(adjusted.OtherIncome.Bands))
+		for i, band := range adjusted.OtherIncome.Bands {
+			band.Amount /= divisor
+			band.Tax /= divisor
+			otherIncome.Bands[i] = band
+		}
+		adjusted.OtherIncome = &otherIncome
+	}
+
+	// Adjust each employment's deductions and the reconciliation
+	if adjusted.Employments != nil {
+		adjusted.Employments = adjustEmploymentsForPeriod(adjusted.Employments, divisor)
+	}
+
+	// Adjust tax brackets, copying them so the original response is unchanged
+	if resp.TaxDue != nil {
+		adjusted.TaxDue = make(map[string]types.TaxBracket, len(resp.TaxDue))
+		for key, bracket := range resp.TaxDue {
+			bracket.Amount /= divisor
+			adjusted.TaxDue[key] = bracket
+		}
+	}
+
+	// Adjust each step of the explanation, copying them so the original
+	// response is unchanged
+	if resp.Explanation != nil {
+		adjusted.Explanation = adjustExplanationForPeriod(resp.Explanation, divisor)
+	}
+
+	// Adjust pay in constant prices
+	if adjusted.Real != nil {
+		realTerms := *adjusted.Real
+		realTerms.GrossPay /= divisor
+		realTerms.NetPay /= divisor
+		adjusted.Real = &realTerms
 	}
 
 	// Also adjust previous year if present
 	if adjusted.Previous != nil {
-		adjusted.Previous = adjustResponseForPeriod(adjusted.Previous, period)
+		adjusted.Previous = adjustResponseForPeriod(adjusted.Previous, period, pattern)
+	}
+
+	// Full-time figures divide by the full-time working pattern
+	if adjusted.FullTime != nil && adjusted.ProRata != nil {
+		adjusted.FullTime = adjustResponseForPeriod(adjusted.FullTime, period, adjusted.ProRata.FullTimePattern)
 	}
 
 	return &adjusted
 }
 
+// adjustEmploymentsForPeriod returns a copy of each employment's deductions
+// and the reconciliation divided into the period
+func adjustEmploymentsForPeriod(employments *types.Employments, divisor float64) *types.Employments {
+	adjusted := *employments
+	adjusted.PAYETax /= divisor
+	adjusted.Liability /= divisor
+	adjusted.Underpayment /= divisor
+	adjusted.Jobs = make([]types.EmploymentResult, len(employments.Jobs))
+	for i, job := range employments.Jobs {
+		job.GrossPay /= divisor
+		job.Pension /= divisor
+		job.TaxPaid /= divisor
+		job.NationalInsurance /= divisor
+		job.StudentLoanRepayment /= divisor
+		job.EmployersNI /= divisor
+		job.NetPay /= divisor
+		adjusted.Jobs[i] = job
+	}
+	return &adjusted
+}
+
+// adjustExplanationForPeriod returns a copy of the explanation's steps with
+// their amounts divided into the period
+func adjustExplanationForPeriod(steps []types.ExplanationStep, divisor float64) []types.ExplanationStep {
+	adjusted := make([]types.ExplanationStep, len(steps))
+	for i, step := range steps {
+		step.Amount /= divisor
+		step.Base /= divisor
+		step.Threshold /= divisor
+		adjusted[i] = step
+	}
+	return adjusted
+}
+
 func runCheck(cmd *cobra.Command, _ []string) error {
 	// Load config file
 	cfg, err := config.Load()
@@ -172,17 +344,332 @@ func runCheck(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	// Call API
-	apiClient := checkClientFactory()
-	resp, err := apiClient.CalculateTax(req)
+	// Show the same salary across a range of tax years
+	if err := validateYearFlags(cmd); err != nil {
+		return err
+	}
+	if err := validateChartFlags(flagChart, flagNoUnicode, flagJSON); err != nil {
+		return err
+	}
+	if flagYears != "" {
+		return runCheckYears(req, period)
+	}
+
+	// Count pay days in the tax year for week 53 and 27-fortnight years
+	if err := applyPayDays(req, period, flagPayday); err != nil {
+		return err
+	}
+
+	// Work out part-time pay from a full-time-equivalent salary
+	if err := applyProRata(req); err != nil {
+		return err
+	}
+
+	// Calculate locally or via the API
+	resp, err := calculateCheck(req)
 	if err != nil {
 		return fmt.Errorf("failed to calculate tax: %w", err)
 	}
 
+	// Calculate the full-time figures to show alongside part-time pay
+	if err := calculateFullTime(req, resp, calculateCheck); err != nil {
+		return fmt.Errorf("failed to calculate full-time tax: %w", err)
+	}
+
+	// Calculate the same salary in the previous tax year
+	if err := applyVsPrevious(req, period, resp); err != nil {
+		return err
+	}
+
+	// Echo any rate of pay the salary was converted from
+	resp.Rate = req.Rate
+
+	// Trace how each figure is worked out
+	if err := applyExplain(req, resp); err != nil {
+		return err
+	}
+
 	// Display result
 	return displayCheckResult(resp, period, req)
 }
 
+// applyExplain adds how each figure is worked out when --explain is set
+func applyExplain(req *types.TaxRequest, resp *types.TaxResponse) error {
+	if !flagExplain {
+		return nil
+	}
+	explanation, err := tax.Explain(req, resp)
+	if err != nil {
+		return fmt.Errorf("failed to explain tax: %w", err)
+	}
+	resp.Explanation = explanation
+	return nil
+}
+
+// validateYearFlags checks that --years is not combined with --year,
+// --vs-previous or --explain, and that real terms are only asked for across
+// tax years
+func validateYearFlags(cmd *cobra.Command) error {
+	switch {
+	case flagCPIFile != "" && !flagReal:
+		return fmt.Errorf("--cpi-file requires --real")
+	case flagBaseYear != "" && !flagReal:
+		return fmt.Errorf("--base-year requires --real")
+	case flagReal && !flagVsPrevious && flagYears == "":
+		return fmt.Errorf("--real requires --vs-previous or --years")
+	}
+
+	if flagYears == "" {
+		return nil
+	}
+	if flagVsPrevious {
+		return fmt.Errorf("--vs-previous cannot be used with --years")
+	}
+	if flagExplain {
+		return fmt.Errorf("--explain cannot be used with --years")
+	}
+	if cmd.Flags().Changed("year") {
+		return fmt.Errorf("--years cannot be used with --year")
+	}
+	return nil
+}
+
+// parseYears parses a range of tax years such as 2022..2025
+func parseYears(spec string) ([]string, error) {
+	fromStr, toStr, ok := strings.Cut(spec, "..")
+	from, fromErr := strconv.Atoi(fromStr)
+	to, toErr := strconv.Atoi(toStr)
+	if !ok || fromErr != nil || toErr != nil || len(fromStr) != 4 || len(toStr) != 4 {
+		return nil, fmt.Errorf("--years must be a range of tax years such as 2022..2025, got: %s", spec)
+	}
+	if to <= from {
+		return nil, fmt.Errorf("--years must run from an earlier to a later tax year, got: %s", spec)
+	}
+	if to-from+1 > maxYears {
+		return nil, fmt.Errorf("--years covers at most %d tax years, got: %d", maxYears, to-from+1)
+	}
+
+	years := make([]string, 0, to-from+1)
+	for year := from; year <= to; year++ {
+		years = append(years, strconv.Itoa(year))
+	}
+	return years, nil
+}
+
+// runCheckYears calculates the same salary in each tax year of --years and
+// shows them side by side
+func runCheckYears(req *types.TaxRequest, period string) error {
+	years, err := parseYears(flagYears)
+	if err != nil {
+		return err
+	}
+
+	// Work out part-time pay from a full-time-equivalent salary
+	if err := applyProRata(req); err != nil {
+		return err
+	}
+
+	results := make([]types.ComparisonResult, 0, len(years))
+	responses := make([]*types.TaxResponse, 0, len(years))
+	for i, year := range years {
+		yearReq := *req
+		yearReq.Year = year
+
+		// Count pay days in each tax year for week 53 and 27-fortnight years
+		if err := applyPayDays(&yearReq, period, flagPayday); err != nil {
+			return err
+		}
+
+		resp, err := calculateCheck(&yearReq)
+		if err != nil {
+			return fmt.Errorf("failed to calculate tax for %s: %w", year, err)
+		}
+		resp.Rate = req.Rate
+		if i > 0 {
+			resp.RateChanges = rateChanges(years[i-1], year, req)
+		}
+
+		results = append(results, types.ComparisonResult{
+			Label:    display.TaxYearLabel(year),
+			Request:  &yearReq,
+			Response: resp,
+		})
+		responses = append(responses, resp)
+	}
+
+	if err := applyCheckRealTerms(years, responses); err != nil {
+		return err
+	}
+
+	if flagJSON {
+		adjusted := make([]*types.TaxResponse, len(results))
+		for i, result := range results {
+			adjusted[i] = withDisplayCurrency(adjustResponseForPeriod(result.Response, period, result.Request.WorkPattern))
+		}
+		jsonData, err := json.MarshalIndent(adjusted, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	display.Years(results, period)
+	if flagChart {
+		printChart(results, period, flagNoUnicode)
+	}
+	return nil
+}
+
+// applyVsPrevious adds the previous tax year, in real terms if asked for, when
+// --vs-previous is set. Without it any previous year the API returned is
+// dropped, so it is only shown when asked for.
+func applyVsPrevious(req *types.TaxRequest, period string, resp *types.TaxResponse) error {
+	if !flagVsPrevious {
+		resp.Previous = nil
+		return nil
+	}
+
+	if err := calculatePrevious(req, period, resp); err != nil {
+		return fmt.Errorf("failed to calculate previous year's tax: %w", err)
+	}
+	return applyCheckRealTerms([]string{previousYear(req.Year), req.Year}, []*types.TaxResponse{resp.Previous, resp})
+}
+
+// calculatePrevious adds the same salary in the previous tax year and what
+// changed since. The API's previous year is used when the response has one,
+// with the same local figures added as for this year, and it is only
+// calculated when it is missing.
+func calculatePrevious(req *types.TaxRequest, period string, resp *types.TaxResponse) error {
+	year, err := strconv.Atoi(req.Year)
+	if err != nil {
+		return fmt.Errorf("year must be a valid number: %s", req.Year)
+	}
+
+	previousReq := *req
+	previousReq.Year = strconv.Itoa(year - 1)
+	if resp.Previous != nil {
+		if err := applyLocalFigures(&previousReq, resp.Previous); err != nil {
+			return err
+		}
+	} else {
+		if err := applyPayDays(&previousReq, period, flagPayday); err != nil {
+			return err
+		}
+
+		previous, err := calculateCheck(&previousReq)
+		if err != nil {
+			return err
+		}
+		resp.Previous = previous
+	}
+
+	resp.RateChanges = rateChanges(previousReq.Year, req.Year, req)
+	return nil
+}
+
+// applyCheckRealTerms adds pay in constant prices to the responses for each
+// tax year when --real is set
+func applyCheckRealTerms(years []string, responses []*types.TaxResponse) error {
+	if !flagReal {
+		return nil
+	}
+	index, err := loadCPI(flagCPIFile)
+	if err != nil {
+		return err
+	}
+	return applyRealTerms(index, flagBaseYear, years, responses)
+}
+
+// previousYear returns the tax year before the given one
+func previousYear(year string) string {
+	start, err := strconv.Atoi(year)
+	if err != nil {
+		return year
+	}
+	return strconv.Itoa(start - 1)
+}
+
+// rateChanges returns the allowances, thresholds and rates that changed from
+// one tax year to the next. Years before the local rate tables have none.
+func rateChanges(from, to string, req *types.TaxRequest) []types.RateChange {
+	changes, err := tax.RateChanges(from, to, req.TaxRegion, req.Plan)
+	if err != nil {
+		return nil
+	}
+	return changes
+}
+
+// calculateFullTime calculates the full-time-equivalent figures for a
+// pro-rata request with calculate, to show alongside part-time pay
+func calculateFullTime(req *types.TaxRequest, resp *types.TaxResponse, calculate func(*types.TaxRequest) (*types.TaxResponse, error)) error {
+	if req.ProRata == nil {
+		return nil
+	}
+
+	fullTimeReq := *req
+	fullTimeReq.GrossWage = req.ProRata.FullTimeIncome
+	fullTimeReq.WorkPattern = req.ProRata.FullTimePattern
+	fullTimeReq.ProRata = nil
+
+	fullTime, err := calculate(&fullTimeReq)
+	if err != nil {
+		return err
+	}
+
+	resp.ProRata = req.ProRata
+	resp.FullTime = fullTime
+	return nil
+}
+
+// calculateCheck runs the calculation locally for self-employment and multiple
+// jobs, otherwise via the API, then taxes any rental, savings and dividend income on top
+func calculateCheck(req *types.TaxRequest) (*types.TaxResponse, error) {
+	var resp *types.TaxResponse
+	var err error
+	switch {
+	case req.SelfEmployed:
+		resp, err = tax.SelfEmployed(req)
+	case len(req.Jobs) > 0:
+		resp, err = tax.MultipleEmployments(req)
+	case req.PensionScheme != "":
+		resp, err = tax.PensionScheme(req)
+	default:
+		resp, err = checkClientFactory().CalculateTax(apiRequest(req))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyLocalFigures(req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// applyLocalFigures adds what the calculation leaves to the CLI: the employer
+// pension contribution and tax on income other than pay
+func applyLocalFigures(req *types.TaxRequest, resp *types.TaxResponse) error {
+	if err := tax.ApplyEmployerPension(req, resp); err != nil {
+		return err
+	}
+	return tax.ApplyOtherIncome(req, resp)
+}
+
+// apiRequest returns a copy of a request with its tax code normalised for the
+// API: upper case with any region prefix, and without a W1, M1 or X basis, as
+// the API works out tax for the whole year and the basis only changes how it
+// is spread across pay periods
+func apiRequest(req *types.TaxRequest) *types.TaxRequest {
+	code, err := tax.ParseTaxCode(req.TaxCode)
+	if err != nil {
+		return req
+	}
+	out := *req
+	out.TaxCode = code.Code
+	return &out
+}
+
 // buildCheckTaxRequest builds and validates a TaxRequest from flags and config
 func buildCheckTaxRequest(cmd *cobra.Command, cfg *config.Config) (*types.TaxRequest, error) {
 	req := &types.TaxRequest{}
@@ -190,6 +677,26 @@ func buildCheckTaxRequest(cmd *cobra.Command, cfg *config.Config) (*types.TaxReq
 	// Apply flag and config values
 	applyCheckRequestDefaults(cmd, cfg, req)
 
+	// Parse each --job into a separate employment
+	jobs, err := parseJobs(flagJobs)
+	if err != nil {
+		return nil, err
+	}
+	req.Jobs = jobs
+
+	// Convert a rate of pay into the annual salary
+	if cmd.Flags().Changed("rate") || flagPer != "" {
+		req.Rate = &types.IncomeRate{Rate: flagRate, Per: flagPer}
+	}
+	if err := applyRate(req); err != nil {
+		return nil, err
+	}
+
+	// Part-time pay is worked out once the pay days are known
+	if cmd.Flags().Changed("fte") || cmd.Flags().Changed("pro-rata-days") {
+		req.ProRata = &types.ProRata{FTE: flagFTE, Days: flagProRataDays}
+	}
+
 	// Validate the request
 	if err := validateCheckRequest(req); err != nil {
 		return nil, err
@@ -209,6 +716,13 @@ func applyCheckRequestDefaults(cmd *cobra.Command, cfg *config.Config, req *type
 	// Apply boolean fields
 	applyCheckBooleanFields(cmd, cfg, req)
 
+	// Apply the working pattern for daily and hourly figures
+	req.WorkPattern = types.WorkPattern{
+		HoursPerWeek: floatFlagOrDefault(cmd, "hours-per-week", flagHoursPerWeek, cfg.Defaults.HoursPerWeek),
+		DaysPerWeek:  floatFlagOrDefault(cmd, "days-per-week", flagDaysPerWeek, cfg.Defaults.DaysPerWeek),
+		HolidayDays:  floatFlagOrDefault(cmd, "holiday-days", flagHolidayDays, cfg.Defaults.HolidayDays),
+	}
+
 	// Normalise region (england -> uk)
 	req.TaxRegion = normalizeRegion(req.TaxRegion)
 }
@@ -249,6 +763,10 @@ func applyCheckStringFields(cfg *config.Config, req *types.TaxRequest) {
 		req.Pension = cfg.Defaults.Pension
 	}
 
+	// Pension scheme and employer pension: flag > config > empty
+	req.PensionScheme = firstNonEmpty(flagPensionScheme, cfg.Defaults.PensionScheme)
+	req.EmployerPension = firstNonEmpty(flagEmployerPension, cfg.Defaults.EmployerPension)
+
 	// Student loan: flag > config > empty
 	if flagStudentLoan != "" {
 		req.Plan = flagStudentLoan
@@ -273,8 +791,12 @@ func applyCheckNumericFields(cmd *cobra.Command, cfg *config.Config, req *types.
 		req.Extra = cfg.Defaults.Extra
 	}
 
-	// Income is always from flag (required)
+	// Income and profit are always from flags
 	req.GrossWage = flagIncome
+	req.Profit = flagProfit
+	req.Dividends = flagDividends
+	req.SavingsInterest = flagSavings
+	req.RentalProfit = flagRentalProfit
 
 	// Partner income: flag > config > 0
 	if cmd.Flags().Changed("partner-income") {
@@ -284,6 +806,14 @@ func applyCheckNumericFields(cmd *cobra.Command, cfg *config.Config, req *types.
 	}
 }
 
+// floatFlagOrDefault returns the flag value if it was set, otherwise the config default
+func floatFlagOrDefault(cmd *cobra.Command, name string, value, configDefault float64) float64 {
+	if cmd.Flags().Changed(name) {
+		return value
+	}
+	return configDefault
+}
+
 // applyCheckBooleanFields applies boolean flag and config values
 func applyCheckBooleanFields(cmd *cobra.Command, cfg *config.Config, req *types.TaxRequest) {
 	// Married: flag > config > default false
@@ -312,6 +842,9 @@ func applyCheckBooleanFields(cmd *cobra.Command, cfg *config.Config, req *types.
 	} else if cfg.Defaults.NoNI {
 		req.ExNI = "y"
 	}
+
+	// Self-employed is flag only
+	req.SelfEmployed = flagSelfEmployed
 }
 
 // validateCheckRequest validates the tax request
@@ -324,9 +857,9 @@ func validateCheckRequest(req *types.TaxRequest) error {
 		return fmt.Errorf("year must be a valid number: %s", req.Year)
 	}
 
-	// Validate income is positive
-	if req.GrossWage <= 0 {
-		return fmt.Errorf("income must be greater than 0")
+	// Validate income (or profit when self-employed) is positive
+	if err := validateCheckIncome(req); err != nil {
+		return err
 	}
 
 	// Validate partner income requires married flag
@@ -339,6 +872,11 @@ func validateCheckRequest(req *types.TaxRequest) error {
 		return fmt.Errorf("--partner-income cannot be negative")
 	}
 
+	// Validate other income is not negative
+	if err := validateOtherIncome(req); err != nil {
+		return err
+	}
+
 	// Validate student loan plan
 	if req.Plan != "" {
 		if err := validateStudentLoanPlan(req.Plan); err != nil {
@@ -346,6 +884,147 @@ func validateCheckRequest(req *types.TaxRequest) error {
 		}
 	}
 
+	// Validate the working pattern used for daily and hourly figures
+	if err := validateWorkPattern(req.WorkPattern); err != nil {
+		return err
+	}
+
+	// Validate the pension scheme and employer pension
+	if err := validatePensionScheme(req); err != nil {
+		return err
+	}
+
+	// Validate tax code before it is sent to the API
+	return validateTaxCode(req.TaxCode)
+}
+
+// validateCheckIncome validates the salary, profit or jobs for the calculation mode
+func validateCheckIncome(req *types.TaxRequest) error {
+	switch {
+	case req.SelfEmployed:
+		return validateSelfEmployedRequest(req)
+	case len(req.Jobs) > 0:
+		return validateJobs(req)
+	case req.Profit != 0:
+		return fmt.Errorf("--profit requires --self-employed flag")
+	case req.GrossWage <= 0:
+		return fmt.Errorf("income must be greater than 0")
+	}
+	return nil
+}
+
+// validatePensionScheme validates the pension scheme and employer contribution
+func validatePensionScheme(req *types.TaxRequest) error {
+	if req.PensionScheme != "" {
+		if err := tax.ValidatePensionScheme(req.PensionScheme); err != nil {
+			return err
+		}
+		switch {
+		case req.Pension == "":
+			return fmt.Errorf("--pension-scheme requires --pension")
+		case req.SelfEmployed:
+			return fmt.Errorf("--pension-scheme cannot be used with --self-employed")
+		case len(req.Jobs) > 0:
+			return fmt.Errorf("--pension-scheme cannot be used with --job")
+		}
+	}
+
+	if req.EmployerPension != "" {
+		switch {
+		case req.SelfEmployed:
+			return fmt.Errorf("--employer-pension cannot be used with --self-employed")
+		case len(req.Jobs) > 0:
+			return fmt.Errorf("--employer-pension cannot be used with --job")
+		}
+		if _, err := tax.ParsePension(req.EmployerPension, float64(req.GrossWage)); err != nil {
+			return fmt.Errorf("--employer-pension: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateTaxCode validates a tax code, if one is set
+func validateTaxCode(code string) error {
+	if code == "" {
+		return nil
+	}
+	if _, err := tax.ParseTaxCode(code); err != nil {
+		return fmt.Errorf("%w\nHint: Use 'listentotaxman taxcode explain %s' to check a tax code", err, code)
+	}
+	return nil
+}
+
+// validateSelfEmployedRequest validates the profit for a self-employed calculation
+func validateSelfEmployedRequest(req *types.TaxRequest) error {
+	if req.GrossWage != 0 {
+		return fmt.Errorf("--income cannot be used with --self-employed\nHint: Use --self-employed --profit %d", req.GrossWage)
+	}
+	if len(req.Jobs) > 0 {
+		return fmt.Errorf("--job cannot be used with --self-employed")
+	}
+	if req.Profit <= 0 {
+		return fmt.Errorf("profit must be greater than 0")
+	}
+	return nil
+}
+
+// parseJobs parses --job values in the form LABEL:INCOME:TAXCODE[:PENSION]
+func parseJobs(specs []string) ([]types.Employment, error) {
+	jobs := make([]types.Employment, 0, len(specs))
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 3 || len(parts) > 4 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid --job '%s' (expected LABEL:INCOME:TAXCODE[:PENSION])", spec)
+		}
+
+		income, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid income in --job '%s': %s", spec, parts[1])
+		}
+
+		job := types.Employment{Label: parts[0], Income: income, TaxCode: parts[2]}
+		if len(parts) == 4 {
+			job.Pension = parts[3]
+		}
+		jobs = append(jobs, job)
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs, nil
+}
+
+// validateJobs validates each employment in a multiple employment calculation
+func validateJobs(req *types.TaxRequest) error {
+	if req.GrossWage != 0 {
+		return fmt.Errorf("--income cannot be used with --job\nHint: Add the salary as another --job")
+	}
+	if req.Profit != 0 {
+		return fmt.Errorf("--profit requires --self-employed flag")
+	}
+	for _, job := range req.Jobs {
+		if job.Income <= 0 {
+			return fmt.Errorf("job '%s': income must be greater than 0", job.Label)
+		}
+		if _, err := tax.ParseTaxCode(job.TaxCode); err != nil {
+			return fmt.Errorf("job '%s': %w", job.Label, err)
+		}
+	}
+	return nil
+}
+
+// validateOtherIncome validates that dividends, savings interest and rental profit are not negative
+func validateOtherIncome(req *types.TaxRequest) error {
+	if req.Dividends < 0 {
+		return fmt.Errorf("--dividends cannot be negative")
+	}
+	if req.SavingsInterest < 0 {
+		return fmt.Errorf("--savings-interest cannot be negative")
+	}
+	if req.RentalProfit < 0 {
+		return fmt.Errorf("--rental-profit cannot be negative")
+	}
 	return nil
 }
 
@@ -362,8 +1041,8 @@ func validateStudentLoanPlan(plan string) error {
 
 // getPeriod gets and validates the period
 func getPeriod(cfg *config.Config) (string, error) {
-	// Period: flag > config > default periodYearly
-	period := periodYearly
+	// Period: flag > config > default yearly
+	period := payperiod.Yearly
 	if flagPeriod != "" {
 		period = flagPeriod
 	} else if cfg.Defaults.Period != "" {
@@ -371,43 +1050,199 @@ func getPeriod(cfg *config.Config) (string, error) {
 	}
 
 	// Validate period
-	if err := validatePeriod(period); err != nil {
+	if err := payperiod.Validate(period); err != nil {
 		return "", err
 	}
 
 	return period, nil
 }
 
-// validatePeriod validates the period value
-func validatePeriod(period string) error {
-	validPeriods := []string{periodYearly, "monthly", "weekly", "daily", "hourly"}
-	for _, vp := range validPeriods {
-		if period == vp {
-			return nil
+// applyPayDays sets the number of pay days in the request's tax year from any
+// pay date, so weekly, fortnightly and four-weekly figures divide by 53, 27 or
+// 14 in years that have an extra pay day
+func applyPayDays(req *types.TaxRequest, period, payday string) error {
+	if payday == "" {
+		return nil
+	}
+
+	interval := payperiod.PayDayInterval(period)
+	if interval == 0 {
+		return fmt.Errorf("--payday only applies to weekly, fortnightly and four-weekly periods, got: %s", period)
+	}
+
+	date, err := time.Parse("2006-01-02", payday)
+	if err != nil {
+		return fmt.Errorf("invalid --payday: %s (use YYYY-MM-DD)", payday)
+	}
+
+	year, err := strconv.Atoi(req.Year)
+	if err != nil {
+		return fmt.Errorf("year must be a valid number: %s", req.Year)
+	}
+
+	req.WorkPattern.PayDays = payperiod.PayDays(year, interval, date)
+	return nil
+}
+
+// applyRate converts an hourly, daily, weekly or monthly rate of pay into the
+// annual gross wage, using the working pattern for hours, days and weeks
+// worked. Daily figures then count the same working days as the rate
+func applyRate(req *types.TaxRequest) error {
+	if req.Rate == nil {
+		return nil
+	}
+
+	switch {
+	case req.Rate.Rate == 0 && req.Rate.Per != "":
+		return fmt.Errorf("--per requires --rate")
+	case req.Rate.Rate <= 0:
+		return fmt.Errorf("--rate must be greater than 0")
+	case req.Rate.Per == "":
+		return fmt.Errorf("--rate requires --per (%s)", strings.Join(payperiod.RateUnits, ", "))
+	case req.GrossWage != 0:
+		return fmt.Errorf("--income cannot be used with --rate")
+	case req.SelfEmployed:
+		return fmt.Errorf("--rate cannot be used with --self-employed")
+	case len(req.Jobs) > 0:
+		return fmt.Errorf("--job cannot be used with --rate")
+	}
+
+	if err := validateWorkPattern(req.WorkPattern); err != nil {
+		return err
+	}
+	req.WorkPattern = payperiod.CountWorkingDays(req.WorkPattern)
+	units, err := payperiod.UnitsPerYear(req.Rate.Per, req.WorkPattern)
+	if err != nil {
+		return err
+	}
+
+	req.Rate.UnitsPerYear = units
+	req.Rate.AnnualIncome = int(math.Round(req.Rate.Rate * units))
+	req.GrossWage = req.Rate.AnnualIncome
+	return nil
+}
+
+// applyProRata reduces a full-time-equivalent salary and working pattern to
+// the fraction worked, keeping the full-time figures for display
+func applyProRata(req *types.TaxRequest) error {
+	proRata := req.ProRata
+	if proRata == nil {
+		return nil
+	}
+
+	fullTimeDays := req.WorkPattern.DaysPerWeek
+	if fullTimeDays == 0 {
+		fullTimeDays = payperiod.DefaultDaysPerWeek
+	}
+
+	switch {
+	case proRata.FTE != 0 && proRata.Days != 0:
+		return fmt.Errorf("--fte cannot be used with --pro-rata-days")
+	case proRata.Days != 0:
+		if proRata.Days < 0 || proRata.Days > fullTimeDays {
+			return fmt.Errorf("--pro-rata-days must be between 0 and the %g days of a full-time week, got: %g", fullTimeDays, proRata.Days)
 		}
+		proRata.FTE = proRata.Days / fullTimeDays
+	case proRata.FTE <= 0 || proRata.FTE > 1:
+		return fmt.Errorf("--fte must be greater than 0 and at most 1, got: %g", proRata.FTE)
+	}
+
+	switch {
+	case req.Rate != nil:
+		return fmt.Errorf("--rate cannot be used with --fte or --pro-rata-days")
+	case req.SelfEmployed:
+		return fmt.Errorf("--self-employed cannot be used with --fte or --pro-rata-days")
+	case len(req.Jobs) > 0:
+		return fmt.Errorf("--job cannot be used with --fte or --pro-rata-days")
+	}
+
+	proRata.FullTimeIncome = req.GrossWage
+	proRata.FullTimePattern = req.WorkPattern
+	req.GrossWage = int(math.Round(float64(req.GrossWage) * proRata.FTE))
+	proRata.Income = req.GrossWage
+	req.WorkPattern = payperiod.ProRata(req.WorkPattern, proRata.FTE)
+	return nil
+}
+
+// validateWorkPattern validates the hours, days and holiday used for daily and hourly figures
+func validateWorkPattern(pattern types.WorkPattern) error {
+	if pattern.HoursPerWeek < 0 || pattern.HoursPerWeek > 168 {
+		return fmt.Errorf("--hours-per-week must be between 0 and 168, got: %g", pattern.HoursPerWeek)
+	}
+	if pattern.DaysPerWeek < 0 || pattern.DaysPerWeek > 7 {
+		return fmt.Errorf("--days-per-week must be between 0 and 7, got: %g", pattern.DaysPerWeek)
+	}
+	if pattern.HolidayDays < 0 {
+		return fmt.Errorf("--holiday-days cannot be negative")
 	}
-	return fmt.Errorf("invalid period: %s (must be one of: yearly, monthly, weekly, daily, hourly)", period)
+	if payperiod.WorkingDays(pattern) <= 0 {
+		return fmt.Errorf("--holiday-days must be fewer than the %g days worked in a year", payperiod.WorkingDays(pattern)+pattern.HolidayDays)
+	}
+	return nil
 }
 
 // displayCheckResult displays the tax calculation result
 func displayCheckResult(resp *types.TaxResponse, period string, req *types.TaxRequest) error {
 	if flagJSON {
 		// Output as JSON - adjust response for period
-		adjustedResp := adjustResponseForPeriod(resp, period)
+		adjustedResp := withDisplayCurrency(adjustResponseForPeriod(resp, period, req.WorkPattern))
 		jsonData, err := json.MarshalIndent(adjustedResp, "", "  ")
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON: %w", err)
 		}
 		fmt.Println(string(jsonData))
-	} else if flagVerbose {
-		// Display detailed breakdown
+	} else {
+		displayCheckTables(resp, period, req)
+	}
+
+	// Chart where each pound goes, with the previous year first
+	if flagChart {
+		results := []types.ComparisonResult{{Label: display.TaxYearLabel(req.Year), Request: req, Response: resp}}
+		if resp.Previous != nil {
+			previous := types.ComparisonResult{Label: display.TaxYearLabel(previousYear(req.Year)), Request: req, Response: resp.Previous}
+			results = append([]types.ComparisonResult{previous}, results...)
+		}
+		printChart(results, period, flagNoUnicode)
+	}
+
+	return nil
+}
+
+// displayCheckTables displays the summary or detailed breakdown, followed by
+// each job, the full-time figures, the previous year and the explanation when
+// the response has them
+func displayCheckTables(resp *types.TaxResponse, period string, req *types.TaxRequest) {
+	if flagVerbose {
 		display.Detailed(resp, period, req)
 	} else {
-		// Display summary table
 		display.Summary(resp, period, req)
 	}
 
-	return nil
+	// Show each job's PAYE alongside the combined figures
+	if resp.Employments != nil {
+		if flagVerbose {
+			fmt.Println()
+		}
+		display.Employments(resp, period, req)
+	}
+
+	// Show part-time pay alongside the full-time figures
+	if resp.FullTime != nil {
+		if flagVerbose {
+			fmt.Println()
+		}
+		display.ProRata(resp, period, req)
+	}
+
+	// Show the same salary in the previous tax year
+	if resp.Previous != nil {
+		display.YearOnYear(resp, period, req)
+	}
+
+	// Show how each figure is worked out
+	if resp.Explanation != nil {
+		display.Explain(resp, period, req)
+	}
 }
 
 // normalizeRegion converts region aliases to their canonical form
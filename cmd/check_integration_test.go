@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mheap/listentotaxman-cli/internal/client"
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
 	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
 )
 
 func TestRunCheck_BasicIncome(t *testing.T) {
@@ -78,7 +82,7 @@ func TestRunCheck_WithJSONOutput(t *testing.T) {
 	flagIncome = 100000
 	flagJSON = true
 	flagVerbose = false
-	flagPeriod = periodYearly
+	flagPeriod = payperiod.Yearly
 
 	// Capture stdout
 	output := testutil.CaptureStdout(t, func() {
@@ -113,7 +117,7 @@ func TestRunCheck_WithVerboseOutput(t *testing.T) {
 	flagIncome = 100000
 	flagJSON = false
 	flagVerbose = true
-	flagPeriod = periodYearly
+	flagPeriod = payperiod.Yearly
 
 	// Capture stdout
 	output := testutil.CaptureStdout(t, func() {
@@ -158,7 +162,7 @@ func TestRunCheck_WithAllFlags(t *testing.T) {
 	flagPartnerIncome = 25000
 	flagJSON = false
 	flagVerbose = false
-	flagPeriod = periodYearly
+	flagPeriod = payperiod.Yearly
 
 	// Capture stdout
 	output := testutil.CaptureStdout(t, func() {
@@ -329,3 +333,518 @@ func TestRunCheck_WithConfigDefaults(t *testing.T) {
 	assert.Contains(t, output, "Gross Salary")
 	assert.Contains(t, output, "Net Pay")
 }
+
+func TestRunCheck_SelfEmployed(t *testing.T) {
+	// Setup viper test
+	testutil.SetupViperTest(t)
+
+	// Create config file
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	// No API mock: self-employed calculations must not hit the API
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperError(500, "Internal Server Error")
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	// Set flags
+	flagIncome = 0
+	flagSelfEmployed = true
+	flagProfit = 60000
+	flagYear = "2025"
+	flagRegion = ""
+	flagPension = ""
+	flagStudentLoan = ""
+	flagJSON = true
+	flagVerbose = false
+	flagPeriod = payperiod.Yearly
+	t.Cleanup(func() {
+		flagSelfEmployed = false
+		flagProfit = 0
+		flagJSON = false
+	})
+
+	// Capture stdout
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	// Verify JSON output includes self-employment figures
+	assert.Equal(t, 0, mockRT.RequestCount)
+	assert.Contains(t, output, `"self_employment":`)
+	assert.Contains(t, output, `"class4_ni": 2456.6`)
+	assert.Contains(t, output, `"employers_ni": 0`)
+}
+
+func TestRunCheck_WithOtherIncome(t *testing.T) {
+	// Setup viper test
+	testutil.SetupViperTest(t)
+
+	// Create config file
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	// Mock API client
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	// Set flags
+	flagIncome = 50000
+	flagYear = "2024"
+	flagPension = ""
+	flagStudentLoan = ""
+	flagDividends = 2000
+	flagSavings = 0
+	flagRentalProfit = 0
+	flagJSON = false
+	flagVerbose = true
+	flagPeriod = payperiod.Yearly
+	t.Cleanup(func() {
+		flagDividends = 0
+		flagVerbose = false
+	})
+
+	// Capture stdout
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	// Other income is not sent to the API
+	body, err := mockRT.GetRequestBody()
+	require.NoError(t, err)
+	assert.NotContains(t, body, "ividends")
+
+	assert.Contains(t, output, "Other Income by Band:")
+	assert.Contains(t, output, "Dividend Allowance")
+}
+
+func TestRunCheck_MultipleJobs(t *testing.T) {
+	// Setup viper test
+	testutil.SetupViperTest(t)
+
+	// Create config file
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	// No API mock: multiple employments are calculated locally
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperError(500, "Internal Server Error")
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	// Set flags
+	flagIncome = 0
+	flagJobs = []string{"Main:60000:1257L", "Side:8000:BR"}
+	flagYear = "2025"
+	flagRegion = ""
+	flagPension = ""
+	flagStudentLoan = ""
+	flagJSON = false
+	flagVerbose = false
+	flagPeriod = payperiod.Yearly
+	t.Cleanup(func() { flagJobs = nil })
+
+	// Capture stdout
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	assert.Equal(t, 0, mockRT.RequestCount)
+	assert.Contains(t, output, "Main")
+	assert.Contains(t, output, "Side")
+	assert.Contains(t, output, "PAYE Deducted:")
+	assert.Contains(t, output, "Expected Underpayment:")
+	assert.Contains(t, output, "£1,600.00")
+}
+
+func TestRunCheck_HourlyWorkPatternFromConfig(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	// A four-day, 32-hour week from the config file
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+  hours-per-week: 32
+  days-per-week: 4
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 50000
+	flagJSON = true
+	flagVerbose = false
+	flagPeriod = payperiod.Hourly
+	t.Cleanup(func() { flagPeriod = payperiod.Yearly })
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	// 50000 / (32 hours x 52 weeks)
+	assert.Contains(t, output, `"gross_pay": 30.048076923076923`)
+}
+
+func TestRunCheck_RateEchoedInJSON(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 0
+	flagJSON = true
+	flagVerbose = false
+	require.NoError(t, checkCmd.Flags().Set("rate", "45"))
+	require.NoError(t, checkCmd.Flags().Set("per", "hour"))
+	t.Cleanup(func() {
+		flagRate, flagPer = 0, ""
+		checkCmd.Flags().Lookup("rate").Changed = false
+		checkCmd.Flags().Lookup("per").Changed = false
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	// The API was asked about the annual equivalent of £45 an hour
+	body, err := mockRT.GetRequestBody()
+	require.NoError(t, err)
+	assert.Contains(t, body, `"grosswage":93600`)
+	assert.Contains(t, output, `"rate": {`)
+	assert.Contains(t, output, `"per": "hour"`)
+	assert.Contains(t, output, `"annual_income": 93600`)
+}
+
+func TestRunCheck_ProRataShowsFullTime(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 60000
+	flagJSON = false
+	flagVerbose = false
+	require.NoError(t, checkCmd.Flags().Set("pro-rata-days", "4"))
+	t.Cleanup(func() {
+		flagProRataDays = 0
+		checkCmd.Flags().Lookup("pro-rata-days").Changed = false
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	// Part-time pay and the full-time figures are both calculated
+	require.Len(t, mockRT.Requests, 2)
+	assert.Contains(t, output, "4 Days/Week")
+	assert.Contains(t, output, "Full-Time")
+	assert.Contains(t, output, "Difference")
+}
+
+func TestRunCheck_VsPrevious(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 60000
+	flagJSON = false
+	flagVerbose = false
+	flagYear = "2024"
+	flagVsPrevious = true
+	t.Cleanup(func() {
+		flagYear = ""
+		flagVsPrevious = false
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	// This year and the previous year are both calculated
+	require.Len(t, mockRT.Requests, 2)
+	assert.Contains(t, output, "║ Field                ║ 2023/24      ║ 2024/25      ║ Change       ║")
+	assert.Contains(t, output, "2023/24 → 2024/25:")
+	assert.Contains(t, output, "  Employee NI Rate: 11.5% → 8%")
+	assert.Contains(t, output, "  Frozen: Personal Allowance")
+}
+
+// apiResponseWithPrevious is an API response that includes the previous tax year
+func apiResponseWithPrevious() string {
+	previous := strings.Replace(testutil.SampleAPIResponse200, `"tax_year": 2024`, `"tax_year": 2023`, 1)
+	return strings.TrimSuffix(testutil.SampleAPIResponse200, "}") + `, "previous": ` + previous + "}"
+}
+
+func TestRunCheck_APIPreviousOnlyWithVsPrevious(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, apiResponseWithPrevious())
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 50000
+	flagJSON = false
+	flagVerbose = false
+	flagYear = "2024"
+	flagChart = true
+	t.Cleanup(func() {
+		flagYear = ""
+		flagChart = false
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		require.NoError(t, runCheck(checkCmd, []string{}))
+	})
+
+	// The API's previous year is not shown unless asked for
+	assert.NotContains(t, output, "2023/24")
+	assert.NotContains(t, output, "Change")
+}
+
+func TestRunCheck_VsPreviousAPIPreviousWithDividends(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, apiResponseWithPrevious())
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 50000
+	flagJSON = true
+	flagVerbose = false
+	flagYear = "2024"
+	flagVsPrevious = true
+	flagDividends = 5000
+	t.Cleanup(func() {
+		flagJSON = false
+		flagYear = ""
+		flagVsPrevious = false
+		flagDividends = 0
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		require.NoError(t, runCheck(checkCmd, []string{}))
+	})
+
+	var resp types.TaxResponse
+	require.NoError(t, json.Unmarshal([]byte(output), &resp))
+
+	// The API's previous year is used, with the dividend tax added as for this year
+	require.Len(t, mockRT.Requests, 1)
+	require.NotNil(t, resp.Previous)
+	assert.Equal(t, 2023, resp.Previous.TaxYear)
+	require.NotNil(t, resp.Previous.OtherIncome)
+	assert.InDelta(t, 5000, resp.Previous.OtherIncome.Dividends, 0.01)
+	assert.Positive(t, resp.Previous.OtherIncome.Tax)
+}
+
+func TestRunCheck_YearsJSON(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 60000
+	flagJSON = true
+	flagVerbose = false
+	flagYears = "2022..2024"
+	t.Cleanup(func() {
+		flagJSON = false
+		flagYears = ""
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	require.Len(t, mockRT.Requests, 3)
+	var years []types.TaxResponse
+	require.NoError(t, json.Unmarshal([]byte(output), &years))
+	require.Len(t, years, 3)
+	assert.Empty(t, years[0].RateChanges)
+	assert.NotEmpty(t, years[1].RateChanges)
+	assert.Contains(t, years[2].RateChanges, types.RateChange{Name: "Employee NI Rate", Kind: "rate", From: 0.115, To: 0.08})
+}
+
+func TestRunCheck_YearsRealJSON(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 60000
+	flagJSON = true
+	flagVerbose = false
+	flagYears = "2022..2024"
+	flagReal = true
+	t.Cleanup(func() {
+		flagJSON = false
+		flagYears = ""
+		flagReal = false
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	var years []types.TaxResponse
+	require.NoError(t, json.Unmarshal([]byte(output), &years))
+	require.Len(t, years, 3)
+	require.NotNil(t, years[0].Real)
+	assert.Equal(t, 2024, years[0].Real.BaseYear)
+	assert.InDelta(t, 133.9/121.7, years[0].Real.Factor, 0.00001)
+	assert.InDelta(t, years[0].NetPay*133.9/121.7, years[0].Real.NetPay, 0.01)
+	assert.InDelta(t, 1.0, years[2].Real.Factor, 0.00001)
+}
+
+func TestRunCheck_ExplainJSON(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, `defaults:
+  year: "2024"
+`)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	flagIncome = 50000
+	flagJSON = true
+	flagVerbose = false
+	flagExplain = true
+	t.Cleanup(func() {
+		flagJSON = false
+		flagExplain = false
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runCheck(checkCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	var resp types.TaxResponse
+	require.NoError(t, json.Unmarshal([]byte(output), &resp))
+	require.NotEmpty(t, resp.Explanation)
+	assert.Contains(t, resp.Explanation, types.ExplanationStep{
+		Section: "Income Tax", Name: "Basic Rate", Amount: 7486, Base: 37430, Rate: 0.20,
+	})
+	assert.Equal(t, types.ExplanationStep{Section: "Net Pay", Name: "Net pay", Amount: 38295.84,
+		Note: "gross pay less income tax, National Insurance, student loan and your pension contribution"},
+		resp.Explanation[len(resp.Explanation)-1])
+}
+
+func TestRunCheck_ExplainWithYears(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	configPath := testutil.CreateTempConfigFile(t, testutil.ValidConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	flagIncome = 50000
+	flagExplain = true
+	flagYears = "2022..2024"
+	t.Cleanup(func() {
+		flagExplain = false
+		flagYears = ""
+	})
+
+	err := runCheck(checkCmd, []string{})
+	assert.EqualError(t, err, "--explain cannot be used with --years")
+}
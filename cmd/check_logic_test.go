@@ -60,32 +60,6 @@ func TestGetDefaultYear(t *testing.T) {
 	}
 }
 
-func TestGetPeriodDivisor(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name   string
-		period string
-		want   float64
-	}{
-		{"yearly", "yearly", 1.0},
-		{"monthly", "monthly", 12.0},
-		{"weekly", "weekly", 52.0},
-		{"daily", "daily", 365.0},
-		{"hourly", "hourly", 2080.0},
-		{"invalid defaults to yearly", "invalid", 1.0},
-		{"empty defaults to yearly", "", 1.0},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got := getPeriodDivisor(tt.period)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestNormalizeRegion(t *testing.T) {
 	t.Parallel()
 
@@ -116,7 +90,7 @@ func TestAdjustResponseForPeriod_Yearly(t *testing.T) {
 	resp := testutil.CreateSampleTaxResponse()
 	original := *resp
 
-	adjusted := adjustResponseForPeriod(resp, "yearly")
+	adjusted := adjustResponseForPeriod(resp, "yearly", types.WorkPattern{})
 
 	// Should return the same response (pointer may differ, but values same)
 	assert.Equal(t, original.GrossPay, adjusted.GrossPay)
@@ -131,7 +105,7 @@ func TestAdjustResponseForPeriod_Monthly(t *testing.T) {
 	resp := testutil.CreateSampleTaxResponse()
 	original := *resp
 
-	adjusted := adjustResponseForPeriod(resp, "monthly")
+	adjusted := adjustResponseForPeriod(resp, "monthly", types.WorkPattern{})
 
 	// All monetary values should be divided by 12
 	assert.InDelta(t, original.GrossPay/12.0, adjusted.GrossPay, 0.01)
@@ -142,13 +116,26 @@ func TestAdjustResponseForPeriod_Monthly(t *testing.T) {
 	assert.InDelta(t, original.TaxFreeAllowance/12.0, adjusted.TaxFreeAllowance, 0.01)
 }
 
+func TestAdjustResponseForPeriod_TaxDueUnchanged(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.CreateSampleTaxResponse()
+	resp.TaxDue = map[string]types.TaxBracket{"0": {Name: "Basic Rate", Rate: 0.2, Amount: 1200}}
+
+	adjusted := adjustResponseForPeriod(resp, "monthly", types.WorkPattern{})
+
+	// The original brackets are left as they were, so a response can be adjusted more than once
+	assert.InDelta(t, 100.0, adjusted.TaxDue["0"].Amount, 0.001)
+	assert.InDelta(t, 1200.0, resp.TaxDue["0"].Amount, 0.001)
+}
+
 func TestAdjustResponseForPeriod_Weekly(t *testing.T) {
 	t.Parallel()
 
 	resp := testutil.CreateSampleTaxResponse()
 	original := *resp
 
-	adjusted := adjustResponseForPeriod(resp, "weekly")
+	adjusted := adjustResponseForPeriod(resp, "weekly", types.WorkPattern{})
 
 	// Spot check - divided by 52
 	assert.InDelta(t, original.GrossPay/52.0, adjusted.GrossPay, 0.01)
@@ -161,13 +148,53 @@ func TestAdjustResponseForPeriod_Hourly(t *testing.T) {
 	resp := testutil.CreateSampleTaxResponse()
 	original := *resp
 
-	adjusted := adjustResponseForPeriod(resp, "hourly")
+	adjusted := adjustResponseForPeriod(resp, "hourly", types.WorkPattern{})
 
 	// Divided by 2080
 	assert.InDelta(t, original.GrossPay/2080.0, adjusted.GrossPay, 0.01)
 	assert.InDelta(t, original.NetPay/2080.0, adjusted.NetPay, 0.01)
 }
 
+func TestAdjustResponseForPeriod_PayPeriods(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		period  string
+		pattern types.WorkPattern
+		divisor float64
+	}{
+		{"quarterly", types.WorkPattern{}, 4.0},
+		{"four-weekly", types.WorkPattern{}, 13.0},
+		{"fortnightly", types.WorkPattern{}, 26.0},
+		{"fortnightly", types.WorkPattern{PayDays: 27}, 27.0},
+		{"weekly", types.WorkPattern{PayDays: 53}, 53.0},
+	}
+
+	for _, tt := range tests {
+		resp := testutil.CreateSampleTaxResponse()
+		original := *resp
+
+		adjusted := adjustResponseForPeriod(resp, tt.period, tt.pattern)
+		assert.InDelta(t, original.GrossPay/tt.divisor, adjusted.GrossPay, 0.01, tt.period)
+		assert.InDelta(t, original.NetPay/tt.divisor, adjusted.NetPay, 0.01, tt.period)
+	}
+}
+
+func TestAdjustResponseForPeriod_WorkPattern(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.CreateSampleTaxResponse()
+	original := *resp
+	pattern := types.WorkPattern{HoursPerWeek: 32, DaysPerWeek: 4}
+
+	// Four 8-hour days a week: 1664 hours and 208 days a year
+	hourly := adjustResponseForPeriod(resp, "hourly", pattern)
+	assert.InDelta(t, original.GrossPay/1664.0, hourly.GrossPay, 0.01)
+
+	daily := adjustResponseForPeriod(resp, "daily", pattern)
+	assert.InDelta(t, original.GrossPay/208.0, daily.GrossPay, 0.01)
+}
+
 func TestAdjustResponseForPeriod_TaxBrackets(t *testing.T) {
 	t.Parallel()
 
@@ -179,7 +206,7 @@ func TestAdjustResponseForPeriod_TaxBrackets(t *testing.T) {
 		}
 	})
 
-	adjusted := adjustResponseForPeriod(resp, "monthly")
+	adjusted := adjustResponseForPeriod(resp, "monthly", types.WorkPattern{})
 
 	// Tax brackets should be adjusted
 	assert.InDelta(t, 7486.0/12.0, adjusted.TaxDue["0"].Amount, 0.01)
@@ -204,7 +231,7 @@ func TestAdjustResponseForPeriod_NestedPrevious(t *testing.T) {
 		r.Previous = previousResp
 	})
 
-	adjusted := adjustResponseForPeriod(resp, "monthly")
+	adjusted := adjustResponseForPeriod(resp, "monthly", types.WorkPattern{})
 
 	// Verify previous response was also adjusted
 	assert.NotNil(t, adjusted.Previous)
@@ -233,7 +260,7 @@ func TestAdjustResponseForPeriod_AllFields(t *testing.T) {
 		r.ChildcareAmount = 100.0
 	})
 
-	adjusted := adjustResponseForPeriod(resp, "monthly")
+	adjusted := adjustResponseForPeriod(resp, "monthly", types.WorkPattern{})
 	divisor := 12.0
 
 	// Verify all monetary fields are adjusted
@@ -254,3 +281,94 @@ func TestAdjustResponseForPeriod_AllFields(t *testing.T) {
 	assert.InDelta(t, 500.0/divisor, adjusted.GrossSacrifice, 0.01)
 	assert.InDelta(t, 100.0/divisor, adjusted.ChildcareAmount, 0.01)
 }
+
+func TestAdjustResponseForPeriod_SelfEmployment(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.SelfEmployment = &types.SelfEmployment{
+			Profit:           60000.0,
+			Class2NI:         180.0,
+			Class4NI:         2400.0,
+			TotalLiability:   14000.0,
+			PaymentOnAccount: 6900.0,
+		}
+	})
+
+	adjusted := adjustResponseForPeriod(resp, "monthly", types.WorkPattern{})
+
+	assert.InDelta(t, 5000.0, adjusted.SelfEmployment.Profit, 0.01)
+	assert.InDelta(t, 15.0, adjusted.SelfEmployment.Class2NI, 0.01)
+	assert.InDelta(t, 200.0, adjusted.SelfEmployment.Class4NI, 0.01)
+	assert.InDelta(t, 14000.0/12.0, adjusted.SelfEmployment.TotalLiability, 0.01)
+	assert.InDelta(t, 575.0, adjusted.SelfEmployment.PaymentOnAccount, 0.01)
+
+	// Original is not modified
+	assert.InDelta(t, 60000.0, resp.SelfEmployment.Profit, 0.01)
+}
+
+func TestAdjustResponseForPeriod_OtherIncome(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.OtherIncome = &types.OtherIncome{
+			Dividends: 12000.0,
+			Tax:       2400.0,
+			Bands: []types.IncomeBand{
+				{Income: "Dividends", Band: "Higher Rate", Rate: 0.3375, Amount: 12000.0, Tax: 2400.0},
+			},
+		}
+	})
+
+	adjusted := adjustResponseForPeriod(resp, "monthly", types.WorkPattern{})
+
+	assert.InDelta(t, 1000.0, adjusted.OtherIncome.Dividends, 0.01)
+	assert.InDelta(t, 200.0, adjusted.OtherIncome.Tax, 0.01)
+	assert.InDelta(t, 1000.0, adjusted.OtherIncome.Bands[0].Amount, 0.01)
+	assert.Equal(t, 0.3375, adjusted.OtherIncome.Bands[0].Rate)
+
+	// Original is not modified
+	assert.InDelta(t, 12000.0, resp.OtherIncome.Bands[0].Amount, 0.01)
+}
+
+func TestAdjustResponseForPeriod_Employments(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.Employments = &types.Employments{
+			Jobs: []types.EmploymentResult{
+				{Label: "Main", TaxCode: "1257L", GrossPay: 60000.0, TaxPaid: 11432.0, NetPay: 45357.6},
+			},
+			PAYETax:      13032.0,
+			Liability:    14632.0,
+			Underpayment: 1600.0,
+		}
+	})
+
+	adjusted := adjustResponseForPeriod(resp, "monthly", types.WorkPattern{})
+
+	assert.InDelta(t, 5000.0, adjusted.Employments.Jobs[0].GrossPay, 0.01)
+	assert.InDelta(t, 1086.0, adjusted.Employments.PAYETax, 0.01)
+	assert.InDelta(t, 133.33, adjusted.Employments.Underpayment, 0.01)
+	assert.Equal(t, "1257L", adjusted.Employments.Jobs[0].TaxCode)
+
+	// Original is not modified
+	assert.InDelta(t, 60000.0, resp.Employments.Jobs[0].GrossPay, 0.01)
+}
+
+func TestAdjustResponseForPeriod_FullTime(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.GrossPay = 48000.0
+		r.ProRata = &types.ProRata{FTE: 0.8, FullTimePattern: types.WorkPattern{DaysPerWeek: 5}}
+		r.FullTime = testutil.CreateSampleTaxResponse(func(f *types.TaxResponse) {
+			f.GrossPay = 60000.0
+		})
+	})
+
+	// Both divide by their own working days, so the day rate is unchanged
+	adjusted := adjustResponseForPeriod(resp, "daily", types.WorkPattern{DaysPerWeek: 4})
+	assert.InDelta(t, 48000.0/208.0, adjusted.GrossPay, 0.01)
+	assert.InDelta(t, 60000.0/260.0, adjusted.FullTime.GrossPay, 0.01)
+}
@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
 	"github.com/mheap/listentotaxman-cli/internal/types"
 )
 
@@ -246,3 +249,478 @@ func TestValidation_Income(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCheckRequest_SelfEmployed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		selfEmployed bool
+		income       int
+		profit       int
+		errorMsg     string
+	}{
+		{"self-employed with profit", true, 0, 60000, ""},
+		{"self-employed without profit", true, 0, 0, "profit must be greater than 0"},
+		{"self-employed with income", true, 50000, 60000, "--income cannot be used with --self-employed"},
+		{"profit without self-employed", false, 0, 60000, "--profit requires --self-employed flag"},
+		{"employed with income", false, 50000, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := &types.TaxRequest{
+				Year:         "2025",
+				GrossWage:    tt.income,
+				SelfEmployed: tt.selfEmployed,
+				Profit:       tt.profit,
+			}
+
+			err := validateCheckRequest(req)
+			if tt.errorMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateOtherIncome(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		req      types.TaxRequest
+		errorMsg string
+	}{
+		{"all positive", types.TaxRequest{Dividends: 1000, SavingsInterest: 500, RentalProfit: 8000}, ""},
+		{"negative dividends", types.TaxRequest{Dividends: -1}, "--dividends cannot be negative"},
+		{"negative savings", types.TaxRequest{SavingsInterest: -1}, "--savings-interest cannot be negative"},
+		{"negative rental", types.TaxRequest{RentalProfit: -1}, "--rental-profit cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateOtherIncome(&tt.req)
+			if tt.errorMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseJobs(t *testing.T) {
+	t.Parallel()
+
+	jobs, err := parseJobs([]string{"Main:60000:1257L", "Side:8000:BR:5%"})
+	require.NoError(t, err)
+	assert.Equal(t, []types.Employment{
+		{Label: "Main", Income: 60000, TaxCode: "1257L"},
+		{Label: "Side", Income: 8000, TaxCode: "BR", Pension: "5%"},
+	}, jobs)
+
+	jobs, err = parseJobs(nil)
+	require.NoError(t, err)
+	assert.Nil(t, jobs)
+}
+
+func TestParseJobs_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec     string
+		errorMsg string
+	}{
+		{"Main:60000", "invalid --job 'Main:60000'"},
+		{"Main:60000:1257L:5%:extra", "invalid --job"},
+		{":60000:1257L", "invalid --job"},
+		{"Main:sixty:1257L", "invalid income in --job 'Main:sixty:1257L': sixty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseJobs([]string{tt.spec})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestValidateCheckRequest_Jobs(t *testing.T) {
+	t.Parallel()
+
+	main := types.Employment{Label: "Main", Income: 60000, TaxCode: "1257L"}
+
+	tests := []struct {
+		name     string
+		req      types.TaxRequest
+		errorMsg string
+	}{
+		{"valid jobs", types.TaxRequest{Jobs: []types.Employment{main}}, ""},
+		{"jobs with income", types.TaxRequest{GrossWage: 50000, Jobs: []types.Employment{main}}, "--income cannot be used with --job"},
+		{"jobs with profit", types.TaxRequest{Profit: 50000, Jobs: []types.Employment{main}}, "--profit requires --self-employed flag"},
+		{"jobs with self-employed", types.TaxRequest{SelfEmployed: true, Profit: 50000, Jobs: []types.Employment{main}}, "--job cannot be used with --self-employed"},
+		{"zero income", types.TaxRequest{Jobs: []types.Employment{{Label: "Side", TaxCode: "BR"}}}, "job 'Side': income must be greater than 0"},
+		{"invalid tax code", types.TaxRequest{Jobs: []types.Employment{{Label: "Side", Income: 100, TaxCode: "1257Q"}}}, "job 'Side': invalid tax code: 1257Q"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.req.Year = "2025"
+			err := validateCheckRequest(&tt.req)
+			if tt.errorMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateTaxCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code     string
+		errorMsg string
+	}{
+		{"", ""},
+		{"1257L", ""},
+		{"K475", ""},
+		{"S1257L W1/M1", ""},
+		{"BR", ""},
+		{"1257", "invalid tax code: 1257"},
+		{"12S57L", "invalid tax code: 12S57L"},
+		{"D3", "invalid tax code: D3 (D3 is only used in Scotland, as SD3)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			t.Parallel()
+
+			req := &types.TaxRequest{Year: "2025", GrossWage: 50000, TaxCode: tt.code}
+			err := validateCheckRequest(req)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				assert.Contains(t, err.Error(), "taxcode explain")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAPIRequest(t *testing.T) {
+	t.Parallel()
+
+	// The API gets the normalised code without its basis
+	req := &types.TaxRequest{GrossWage: 50000, TaxCode: " s1257l  w1/m1"}
+	assert.Equal(t, "S1257L", apiRequest(req).TaxCode)
+	assert.Equal(t, " s1257l  w1/m1", req.TaxCode)
+
+	req.TaxCode = ""
+	assert.Same(t, req, apiRequest(req))
+}
+
+func TestValidateWorkPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pattern  types.WorkPattern
+		errorMsg string
+	}{
+		{"unset", types.WorkPattern{}, ""},
+		{"four day week", types.WorkPattern{HoursPerWeek: 32, DaysPerWeek: 4, HolidayDays: 25}, ""},
+		{"too many hours", types.WorkPattern{HoursPerWeek: 169}, "--hours-per-week must be between 0 and 168, got: 169"},
+		{"negative hours", types.WorkPattern{HoursPerWeek: -1}, "--hours-per-week must be between 0 and 168"},
+		{"too many days", types.WorkPattern{DaysPerWeek: 8}, "--days-per-week must be between 0 and 7, got: 8"},
+		{"negative holiday", types.WorkPattern{HolidayDays: -1}, "--holiday-days cannot be negative"},
+		{"holiday exceeds days worked", types.WorkPattern{DaysPerWeek: 1, HolidayDays: 52}, "--holiday-days must be fewer than the 52 days worked in a year"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := &types.TaxRequest{Year: "2025", GrossWage: 50000, WorkPattern: tt.pattern}
+			err := validateCheckRequest(req)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePensionScheme(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		req      types.TaxRequest
+		errorMsg string
+	}{
+		{"unset", types.TaxRequest{}, ""},
+		{"sacrifice", types.TaxRequest{Pension: "5%", PensionScheme: "sacrifice", EmployerPension: "3%"}, ""},
+		{"employer only", types.TaxRequest{EmployerPension: "3000"}, ""},
+		{"invalid scheme", types.TaxRequest{Pension: "5%", PensionScheme: "stakeholder"}, "invalid pension scheme: stakeholder (must be one of: sacrifice, net-pay, relief-at-source)"},
+		{"scheme without pension", types.TaxRequest{PensionScheme: "net-pay"}, "--pension-scheme requires --pension"},
+		{"scheme self-employed", types.TaxRequest{Pension: "5%", PensionScheme: "sacrifice", SelfEmployed: true}, "--pension-scheme cannot be used with --self-employed"},
+		{"scheme with jobs", types.TaxRequest{Pension: "5%", PensionScheme: "sacrifice", Jobs: []types.Employment{{}}}, "--pension-scheme cannot be used with --job"},
+		{"employer self-employed", types.TaxRequest{EmployerPension: "3%", SelfEmployed: true}, "--employer-pension cannot be used with --self-employed"},
+		{"invalid employer", types.TaxRequest{EmployerPension: "lots"}, "--employer-pension: invalid pension amount: lots"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := tt.req
+			req.GrossWage = 60000
+			err := validatePensionScheme(&req)
+			if tt.errorMsg != "" {
+				assert.EqualError(t, err, tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApplyPayDays(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		period   string
+		payday   string
+		want     int
+		errorMsg string
+	}{
+		{"no payday", "weekly", "", 0, ""},
+		{"week 53", "weekly", "2026-04-05", 53, ""},
+		{"52 weeks", "weekly", "2025-04-11", 52, ""},
+		{"27 fortnights", "fortnightly", "2025-04-06", 27, ""},
+		{"14 four-weekly", "four-weekly", "2025-04-06", 14, ""},
+		{"monthly", "monthly", "2025-04-25", 0, "--payday only applies to weekly, fortnightly and four-weekly periods, got: monthly"},
+		{"invalid date", "weekly", "05/04/2026", 0, "invalid --payday: 05/04/2026 (use YYYY-MM-DD)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := &types.TaxRequest{Year: "2025"}
+			err := applyPayDays(req, tt.period, tt.payday)
+			if tt.errorMsg != "" {
+				assert.EqualError(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req.WorkPattern.PayDays)
+		})
+	}
+}
+
+func TestApplyRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		req      types.TaxRequest
+		want     int
+		errorMsg string
+	}{
+		{"no rate", types.TaxRequest{GrossWage: 50000}, 50000, ""},
+		{"hourly", types.TaxRequest{Rate: &types.IncomeRate{Rate: 45, Per: "hour"}}, 93600, ""},
+		{"hourly part time", types.TaxRequest{Rate: &types.IncomeRate{Rate: 20, Per: "hour"}, WorkPattern: types.WorkPattern{HoursPerWeek: 30, DaysPerWeek: 4, HolidayDays: 24}}, 27600, ""},
+		{"day rate with holiday", types.TaxRequest{Rate: &types.IncomeRate{Rate: 450, Per: "day"}, WorkPattern: types.WorkPattern{HolidayDays: 25}}, 105750, ""},
+		{"weekly", types.TaxRequest{Rate: &types.IncomeRate{Rate: 1000, Per: "week"}}, 52000, ""},
+		{"monthly", types.TaxRequest{Rate: &types.IncomeRate{Rate: 4166.67, Per: "month"}}, 50000, ""},
+		{"per without rate", types.TaxRequest{Rate: &types.IncomeRate{Per: "hour"}}, 0, "--per requires --rate"},
+		{"negative rate", types.TaxRequest{Rate: &types.IncomeRate{Rate: -5, Per: "hour"}}, 0, "--rate must be greater than 0"},
+		{"rate without per", types.TaxRequest{Rate: &types.IncomeRate{Rate: 45}}, 0, "--rate requires --per (hour, day, week, month)"},
+		{"invalid per", types.TaxRequest{Rate: &types.IncomeRate{Rate: 45, Per: "year"}}, 0, "invalid rate unit: year (must be one of: hour, day, week, month)"},
+		{"with income", types.TaxRequest{GrossWage: 50000, Rate: &types.IncomeRate{Rate: 45, Per: "hour"}}, 0, "--income cannot be used with --rate"},
+		{"self-employed", types.TaxRequest{SelfEmployed: true, Rate: &types.IncomeRate{Rate: 45, Per: "hour"}}, 0, "--rate cannot be used with --self-employed"},
+		{"jobs", types.TaxRequest{Jobs: []types.Employment{{Label: "Main"}}, Rate: &types.IncomeRate{Rate: 45, Per: "hour"}}, 0, "--job cannot be used with --rate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := tt.req
+			err := applyRate(&req)
+			if tt.errorMsg != "" {
+				assert.EqualError(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req.GrossWage)
+			if req.Rate != nil {
+				assert.Equal(t, tt.want, req.Rate.AnnualIncome)
+			}
+		})
+	}
+}
+
+func TestApplyRate_DailyRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// Daily figures count the same working days the day rate is paid for
+	req := types.TaxRequest{Rate: &types.IncomeRate{Rate: 200, Per: "day"}}
+	require.NoError(t, applyRate(&req))
+	assert.Equal(t, 52000, req.GrossWage)
+	assert.InDelta(t, 200.0, float64(req.GrossWage)/payperiod.Divisor(payperiod.Daily, req.WorkPattern), 0.001)
+}
+
+func TestApplyProRata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		req      types.TaxRequest
+		want     int
+		fte      float64
+		errorMsg string
+	}{
+		{"no pro rata", types.TaxRequest{GrossWage: 60000}, 60000, 0, ""},
+		{"fte", types.TaxRequest{GrossWage: 60000, ProRata: &types.ProRata{FTE: 0.6}}, 36000, 0.6, ""},
+		{"four day week", types.TaxRequest{GrossWage: 60000, ProRata: &types.ProRata{Days: 4}}, 48000, 0.8, ""},
+		{"days of a four day week", types.TaxRequest{GrossWage: 60000, ProRata: &types.ProRata{Days: 3}, WorkPattern: types.WorkPattern{DaysPerWeek: 4}}, 45000, 0.75, ""},
+		{"both", types.TaxRequest{GrossWage: 60000, ProRata: &types.ProRata{FTE: 0.6, Days: 3}}, 0, 0, "--fte cannot be used with --pro-rata-days"},
+		{"too many days", types.TaxRequest{GrossWage: 60000, ProRata: &types.ProRata{Days: 6}}, 0, 0, "--pro-rata-days must be between 0 and the 5 days of a full-time week, got: 6"},
+		{"fte above one", types.TaxRequest{GrossWage: 60000, ProRata: &types.ProRata{FTE: 1.2}}, 0, 0, "--fte must be greater than 0 and at most 1, got: 1.2"},
+		{"negative fte", types.TaxRequest{GrossWage: 60000, ProRata: &types.ProRata{FTE: -0.5}}, 0, 0, "--fte must be greater than 0 and at most 1, got: -0.5"},
+		{"rate", types.TaxRequest{GrossWage: 93600, Rate: &types.IncomeRate{Rate: 45, Per: "hour"}, ProRata: &types.ProRata{FTE: 0.6}}, 0, 0, "--rate cannot be used with --fte or --pro-rata-days"},
+		{"self-employed", types.TaxRequest{SelfEmployed: true, ProRata: &types.ProRata{FTE: 0.6}}, 0, 0, "--self-employed cannot be used with --fte or --pro-rata-days"},
+		{"jobs", types.TaxRequest{Jobs: []types.Employment{{Label: "Main"}}, ProRata: &types.ProRata{FTE: 0.6}}, 0, 0, "--job cannot be used with --fte or --pro-rata-days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := tt.req
+			err := applyProRata(&req)
+			if tt.errorMsg != "" {
+				assert.EqualError(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req.GrossWage)
+			if req.ProRata != nil {
+				assert.InDelta(t, tt.fte, req.ProRata.FTE, 0.001)
+				assert.Equal(t, tt.req.GrossWage, req.ProRata.FullTimeIncome)
+				assert.Equal(t, tt.want, req.ProRata.Income)
+				assert.Equal(t, tt.req.WorkPattern, req.ProRata.FullTimePattern)
+			}
+		})
+	}
+}
+
+func TestParseYears(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		spec     string
+		want     []string
+		errorMsg string
+	}{
+		{"range", "2022..2025", []string{"2022", "2023", "2024", "2025"}, ""},
+		{"two years", "2024..2025", []string{"2024", "2025"}, ""},
+		{"not a range", "2022-2025", nil, "--years must be a range of tax years such as 2022..2025, got: 2022-2025"},
+		{"short year", "22..25", nil, "--years must be a range of tax years such as 2022..2025"},
+		{"backwards", "2025..2022", nil, "--years must run from an earlier to a later tax year, got: 2025..2022"},
+		{"single year", "2025..2025", nil, "--years must run from an earlier to a later tax year"},
+		{"too many years", "2015..2025", nil, "--years covers at most 5 tax years, got: 11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseYears(tt.spec)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateYearFlags(t *testing.T) {
+	flagYears = "2022..2025"
+	flagVsPrevious = true
+	t.Cleanup(func() {
+		flagYears = ""
+		flagVsPrevious = false
+		flagExplain = false
+		checkCmd.Flags().Lookup("year").Changed = false
+	})
+	assert.EqualError(t, validateYearFlags(checkCmd), "--vs-previous cannot be used with --years")
+
+	flagVsPrevious = false
+	flagExplain = true
+	assert.EqualError(t, validateYearFlags(checkCmd), "--explain cannot be used with --years")
+
+	flagExplain = false
+	assert.NoError(t, validateYearFlags(checkCmd))
+
+	require.NoError(t, checkCmd.Flags().Set("year", "2024"))
+	assert.EqualError(t, validateYearFlags(checkCmd), "--years cannot be used with --year")
+}
+
+func TestValidateYearFlags_Real(t *testing.T) {
+	t.Cleanup(func() {
+		flagReal = false
+		flagCPIFile = ""
+		flagBaseYear = ""
+		flagVsPrevious = false
+	})
+
+	flagCPIFile = "cpi.csv"
+	assert.EqualError(t, validateYearFlags(checkCmd), "--cpi-file requires --real")
+
+	flagCPIFile = ""
+	flagBaseYear = "2024"
+	assert.EqualError(t, validateYearFlags(checkCmd), "--base-year requires --real")
+
+	flagReal = true
+	assert.EqualError(t, validateYearFlags(checkCmd), "--real requires --vs-previous or --years")
+
+	flagVsPrevious = true
+	assert.NoError(t, validateYearFlags(checkCmd))
+}
+
+func TestCalculatePrevious_UsesAPIPrevious(t *testing.T) {
+	// The API's previous year is kept rather than calculated again
+	previous := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) { r.NetPay = 38000 })
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) { r.Previous = previous })
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) { r.Year = "2024" })
+
+	require.NoError(t, calculatePrevious(req, "yearly", resp))
+	assert.Same(t, previous, resp.Previous)
+	assert.NotEmpty(t, resp.RateChanges)
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/mheap/listentotaxman-cli/internal/client"
 	"github.com/mheap/listentotaxman-cli/internal/config"
 	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
 	"github.com/mheap/listentotaxman-cli/internal/types"
 )
 
@@ -19,6 +21,12 @@ const (
 	periodFlagName = "--period"
 )
 
+// globalValueFlags are the global flags that take a value
+var globalValueFlags = []string{periodFlagName, "--payday", "--hours-per-week", "--days-per-week", "--holiday-days", "--cpi-file", "--base-year", "--export-chart", "--style", "--color", "--decimals", "--thousands-separator", "--display-currency", "--fx-rate"}
+
+// globalBoolFlags are the global flags that take no value
+var globalBoolFlags = []string{"--json", "--verbose", "--real", "--chart", "--no-unicode", "--no-symbol"}
+
 // ComparisonOption holds one option's label and tax request parameters
 type ComparisonOption struct {
 	Label   string
@@ -39,23 +47,58 @@ Each --option group represents one scenario and supports all flags from the 'che
 Minimum 2 options required, maximum 4 options supported.
 
 Global Flags (apply to all options):
-  --period PERIOD   Display period (yearly, monthly, weekly, daily, hourly)
-  --json            Output as JSON comparison object
-  --verbose         Show detailed breakdown including tax brackets
+  --period PERIOD     Display period (yearly, quarterly, monthly, four-weekly,
+                      fortnightly, weekly, daily, hourly)
+  --payday DATE       Any pay date (YYYY-MM-DD), to count weekly, fortnightly or
+                      four-weekly pay days in the tax year (e.g. 53 weeks)
+  --hours-per-week N  Hours worked per week, for hourly figures (default: 40)
+  --days-per-week N   Days worked per week, for daily and hourly figures (default: 5)
+  --holiday-days N    Days of holiday a year, taken off daily and hourly figures
+  --json              Output as JSON comparison object
+  --style STYLE       Output style (unicode, ascii, plain)
+  --color WHEN        Colour tables: auto, always or never (default: auto)
+  --decimals N        Decimal places in amounts: 0 or 2 (default: 2)
+  --no-symbol         Show amounts without a currency symbol
+  --thousands-separator SEP
+                      Thousands separator: ',', '.', "'", space or none
+  --display-currency CODE
+                      Show amounts converted from GBP, e.g. EUR (requires --fx-rate)
+  --fx-rate RATE      How many of the display currency make £1, e.g. 1.17
+  --verbose           Show detailed breakdown including tax brackets
+  --real              Show pay in constant prices, adjusted by CPI, for options
+                      in different tax years
+  --cpi-file FILE     CSV of year and cpi columns to use instead of the
+                      built-in CPI index (requires --real)
+  --base-year YEAR    Year whose prices --real figures are shown in
+                      (default: the latest option's year)
+  --chart             Also chart where each pound goes for each option and,
+                      across salaries, net pay and the marginal rate
+  --no-unicode        Draw charts with plain ASCII (requires --chart)
+  --export-chart FILE Write the same charts to an SVG or PNG image, by the
+                      file's extension (e.g. chart.svg)
 
 Per-Option Flags (use after each --option):
-  --income INT         Gross annual salary (required)
+  --income INT         Gross annual salary (required unless --rate)
+  --rate AMOUNT        Rate of pay, converted to an annual salary with --per
+  --per UNIT           Unit for --rate (hour, day, week, month)
+  --fte N              Fraction of full-time worked, with --income as the full-time salary
+  --pro-rata-days N    Days worked of a full-time week, with --income as the full-time salary
   --year YEAR          Tax year (defaults to current tax year)
   --region REGION      Tax region (default: "uk", alias: "england")
   --age AGE            Age (default: "0")
   --pension VALUE      Pension contribution (e.g., "3%" or "3000")
+  --pension-scheme S   Pension scheme (sacrifice, net-pay, relief-at-source)
+  --employer-pension V Employer pension contribution (e.g., "5%" or "3000")
   --student-loan PLAN  Student loan plan (plan1, plan2, plan4, postgraduate, scottish)
   --extra INT          Extra income/deductions
   --tax-code CODE      Tax code (e.g., "1257L")
   --married            Married status (enables marriage allowance)
   --blind              Blind person's allowance
   --no-ni              Exempt from National Insurance
-  --partner-income INT Partner's gross wage (requires --married)`,
+  --partner-income INT Partner's gross wage (requires --married)
+  --dividends INT      Annual dividend income
+  --savings-interest INT Annual savings interest
+  --rental-profit INT  Annual rental profit`,
 	Example: `  # Compare two job offers
   listentotaxman compare \
     --option "Current Job" --income 100000 --pension 3% \
@@ -77,6 +120,38 @@ Per-Option Flags (use after each --option):
     --option "Single" --income 100000 \
     --option "Married" --income 100000 --married --partner-income 25000
 
+  # Compare a day rate contract with a permanent salary
+  listentotaxman compare --days-per-week 5 --holiday-days 25 \
+    --option "Contract" --rate 450 --per day \
+    --option "Permanent" --income 85000
+
+  # Compare salary sacrifice with relief at source
+  listentotaxman compare \
+    --option "Sacrifice" --income 60000 --pension 5% --pension-scheme sacrifice \
+    --option "RAS" --income 60000 --pension 5% --pension-scheme relief-at-source
+
+  # Compare dropping to a four-day week
+  listentotaxman compare \
+    --option "Five Days" --income 60000 \
+    --option "Four Days" --income 60000 --pro-rata-days 4
+
+  # Check whether a pay rise beat inflation
+  listentotaxman compare --real \
+    --option "Last Year" --income 50000 --year 2023 \
+    --option "This Year" --income 53000 --year 2024
+
+  # Chart take-home and marginal rate across salaries
+  listentotaxman compare --chart \
+    --option "40k" --income 40000 \
+    --option "60k" --income 60000 \
+    --option "100k" --income 100000 \
+    --option "125k" --income 125000
+
+  # Save the charts as an image for a presentation
+  listentotaxman compare --export-chart offers.png \
+    --option "Current Job" --income 60000 --pension 5% \
+    --option "New Offer" --income 70000 --pension 3%
+
   # Detailed comparison with verbose mode
   listentotaxman compare --verbose \
     --option "Job 1" --income 100000 \
@@ -113,6 +188,20 @@ func runCompare(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	if err := applyCompareOutput(globalFlags, cfg); err != nil {
+		return err
+	}
+
+	// Count pay days in each option's tax year for week 53 and 27-fortnight
+	// years, then work out part-time pay from any full-time-equivalent salary
+	for _, opt := range options {
+		if err := applyPayDays(opt.Request, period, globalFlags["payday"]); err != nil {
+			return err
+		}
+		if err := applyProRata(opt.Request); err != nil {
+			return fmt.Errorf("option '%s': %w", opt.Label, err)
+		}
+	}
 
 	// Calculate tax for all options
 	results, err := calculateTaxForOptions(options)
@@ -120,9 +209,49 @@ func runCompare(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	// Show pay in constant prices, so options in different tax years compare
+	if err := applyCompareRealTerms(globalFlags, results); err != nil {
+		return err
+	}
+
 	// Display results
 	displayCompareResults(results, period, globalFlags)
 
+	// Write the charts to an image file
+	if path, ok := globalFlags["export-chart"]; ok {
+		return exportChart(path, results, period)
+	}
+
+	return nil
+}
+
+// applyCompareOutput sets the style, currency format and colour from the
+// global flags, as the root command does for other commands, and checks the
+// chart flags
+func applyCompareOutput(globalFlags map[string]string, cfg *config.Config) error {
+	if err := applyStyle(globalFlags["style"], cfg); err != nil {
+		return err
+	}
+	if err := applyCurrency(currencyOptions{
+		decimals:           globalFlags["decimals"],
+		noSymbol:           globalFlags["no-symbol"] == flagValueTrue,
+		thousandsSeparator: globalFlags["thousands-separator"],
+		displayCurrency:    globalFlags["display-currency"],
+		fxRate:             globalFlags["fx-rate"],
+	}, cfg); err != nil {
+		return err
+	}
+	if err := applyColour(globalFlags["color"], cfg); err != nil {
+		return err
+	}
+	if err := validateChartFlags(globalFlags["chart"] == flagValueTrue, globalFlags["no-unicode"] == flagValueTrue, globalFlags["json"] == flagValueTrue); err != nil {
+		return err
+	}
+	if path, ok := globalFlags["export-chart"]; ok {
+		if _, err := chartExportFormat(path); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -152,8 +281,16 @@ func parseAndValidateOptions(cfg *config.Config) (map[string]string, []Compariso
 		return nil, nil, fmt.Errorf("maximum 4 options supported for comparison (found %d)", len(options))
 	}
 
-	// Validate each option
+	// Apply the working pattern for daily and hourly figures to every option
+	if err := applyCompareWorkPattern(globalFlags, cfg, options); err != nil {
+		return nil, nil, err
+	}
+
+	// Convert any rate of pay into the annual salary, then validate each option
 	for i := range options {
+		if err := applyRate(options[i].Request); err != nil {
+			return nil, nil, fmt.Errorf("option '%s': %w", options[i].Label, err)
+		}
 		if err := validateOption(&options[i], cfg); err != nil {
 			return nil, nil, err
 		}
@@ -165,7 +302,7 @@ func parseAndValidateOptions(cfg *config.Config) (map[string]string, []Compariso
 // getComparePeriod gets and validates the period for comparison
 func getComparePeriod(globalFlags map[string]string, cfg *config.Config) (string, error) {
 	// Get period (global flag > config > default)
-	period := periodYearly
+	period := payperiod.Yearly
 	if periodFlag, ok := globalFlags["period"]; ok && periodFlag != "" {
 		period = periodFlag
 	} else if cfg.Defaults.Period != "" {
@@ -173,20 +310,68 @@ func getComparePeriod(globalFlags map[string]string, cfg *config.Config) (string
 	}
 
 	// Validate period
-	if err := validatePeriod(period); err != nil {
+	if err := payperiod.Validate(period); err != nil {
 		return "", err
 	}
 
 	return period, nil
 }
 
+// applyCompareWorkPattern applies the working pattern (global flag > config) to every option
+func applyCompareWorkPattern(globalFlags map[string]string, cfg *config.Config, options []ComparisonOption) error {
+	pattern := types.WorkPattern{
+		HoursPerWeek: cfg.Defaults.HoursPerWeek,
+		DaysPerWeek:  cfg.Defaults.DaysPerWeek,
+		HolidayDays:  cfg.Defaults.HolidayDays,
+	}
+
+	fields := []struct {
+		name   string
+		target *float64
+	}{
+		{"hours-per-week", &pattern.HoursPerWeek},
+		{"days-per-week", &pattern.DaysPerWeek},
+		{"holiday-days", &pattern.HolidayDays},
+	}
+	for _, field := range fields {
+		if val, ok := globalFlags[field.name]; ok {
+			floatVal, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return fmt.Errorf("%s must be a valid number: %s", field.name, val)
+			}
+			*field.target = floatVal
+		}
+	}
+
+	if err := validateWorkPattern(pattern); err != nil {
+		return err
+	}
+
+	for i := range options {
+		options[i].Request.WorkPattern = pattern
+	}
+	return nil
+}
+
 // calculateTaxForOptions calculates tax for all comparison options
 func calculateTaxForOptions(options []ComparisonOption) ([]types.ComparisonResult, error) {
 	apiClient := clientFactory()
 	results := make([]types.ComparisonResult, len(options))
 
 	for i, opt := range options {
-		resp, err := apiClient.CalculateTax(opt.Request)
+		var resp *types.TaxResponse
+		var err error
+		if opt.Request.PensionScheme != "" {
+			resp, err = tax.PensionScheme(opt.Request)
+		} else {
+			resp, err = apiClient.CalculateTax(apiRequest(opt.Request))
+		}
+		if err == nil {
+			err = tax.ApplyEmployerPension(opt.Request, resp)
+		}
+		if err == nil {
+			err = tax.ApplyOtherIncome(opt.Request, resp)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to calculate tax for option '%s': %w", opt.Label, err)
 		}
@@ -200,6 +385,32 @@ func calculateTaxForOptions(options []ComparisonOption) ([]types.ComparisonResul
 	return results, nil
 }
 
+// applyCompareRealTerms adds pay in constant prices to each option's results when --real is set
+func applyCompareRealTerms(globalFlags map[string]string, results []types.ComparisonResult) error {
+	if globalFlags["real"] != flagValueTrue {
+		if _, ok := globalFlags["cpi-file"]; ok {
+			return fmt.Errorf("--cpi-file requires --real")
+		}
+		if _, ok := globalFlags["base-year"]; ok {
+			return fmt.Errorf("--base-year requires --real")
+		}
+		return nil
+	}
+
+	index, err := loadCPI(globalFlags["cpi-file"])
+	if err != nil {
+		return err
+	}
+
+	years := make([]string, len(results))
+	responses := make([]*types.TaxResponse, len(results))
+	for i, result := range results {
+		years[i] = result.Request.Year
+		responses[i] = result.Response
+	}
+	return applyRealTerms(index, globalFlags["base-year"], years, responses)
+}
+
 // displayCompareResults displays the comparison results
 func displayCompareResults(results []types.ComparisonResult, period string, globalFlags map[string]string) {
 	jsonFlag := globalFlags["json"] == flagValueTrue
@@ -210,6 +421,10 @@ func displayCompareResults(results []types.ComparisonResult, period string, glob
 	} else {
 		display.Comparison(results, period, verboseFlag)
 	}
+
+	if !jsonFlag && globalFlags["chart"] == flagValueTrue {
+		printChart(results, period, globalFlags["no-unicode"] == flagValueTrue)
+	}
 }
 
 // parseComparisonArgs parses command-line args into global flags and comparison options
@@ -233,13 +448,11 @@ func parseComparisonArgs(allArgs []string, cfg *config.Config) (map[string]strin
 	globalFlags := make(map[string]string)
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
-		if arg == periodFlagName && i+1 < len(args) {
-			globalFlags["period"] = args[i+1]
+		if isGlobalValueFlag(arg) && i+1 < len(args) {
+			globalFlags[strings.TrimPrefix(arg, "--")] = args[i+1]
 			i++ // Skip value
-		} else if arg == "--json" {
-			globalFlags["json"] = flagValueTrue
-		} else if arg == "--verbose" {
-			globalFlags["verbose"] = flagValueTrue
+		} else if isGlobalBoolFlag(arg) {
+			globalFlags[strings.TrimPrefix(arg, "--")] = flagValueTrue
 		}
 	}
 
@@ -278,6 +491,26 @@ func parseComparisonArgs(allArgs []string, cfg *config.Config) (map[string]strin
 	return globalFlags, options, nil
 }
 
+// isGlobalValueFlag reports whether an argument is a global flag that takes a value
+func isGlobalValueFlag(arg string) bool {
+	for _, flag := range globalValueFlags {
+		if arg == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// isGlobalBoolFlag reports whether an argument is a global flag that takes no value
+func isGlobalBoolFlag(arg string) bool {
+	for _, flag := range globalBoolFlags {
+		if arg == flag {
+			return true
+		}
+	}
+	return false
+}
+
 // parseOptionChunk parses a single option chunk (--option label --flag value ...)
 func parseOptionChunk(chunk []string, cfg *config.Config) (ComparisonOption, error) {
 	if len(chunk) < 2 {
@@ -293,8 +526,8 @@ func parseOptionChunk(chunk []string, cfg *config.Config) (ComparisonOption, err
 		arg := chunk[i]
 
 		// Skip global flags (they're handled separately)
-		if arg == periodFlagName || arg == "--json" || arg == "--verbose" {
-			if arg == periodFlagName && i+1 < len(chunk) {
+		if isGlobalValueFlag(arg) || isGlobalBoolFlag(arg) {
+			if isGlobalValueFlag(arg) && i+1 < len(chunk) {
 				i++ // Skip the value too
 			}
 			continue
@@ -349,6 +582,8 @@ func applyCompareTaxRequestDefaults(flags map[string]string, cfg *config.Config,
 	applyStringField(flags, cfg, req, "region", &req.TaxRegion, cfg.Defaults.Region, "uk")
 	applyStringField(flags, cfg, req, "age", &req.Age, cfg.Defaults.Age, "0")
 	applyStringField(flags, cfg, req, "pension", &req.Pension, cfg.Defaults.Pension, "")
+	applyStringField(flags, cfg, req, "pension-scheme", &req.PensionScheme, cfg.Defaults.PensionScheme, "")
+	applyStringField(flags, cfg, req, "employer-pension", &req.EmployerPension, cfg.Defaults.EmployerPension, "")
 	applyStringField(flags, cfg, req, "student-loan", &req.Plan, cfg.Defaults.StudentLoan, "")
 	applyStringField(flags, cfg, req, "tax-code", &req.TaxCode, cfg.Defaults.TaxCode, "")
 
@@ -357,18 +592,37 @@ func applyCompareTaxRequestDefaults(flags map[string]string, cfg *config.Config,
 		return err
 	}
 
-	// Income is required - check if it exists in flags
-	if _, hasIncome := flags["income"]; !hasIncome {
-		return fmt.Errorf("income is required")
+	// Income or a rate of pay is required
+	_, hasIncome := flags["income"]
+	_, hasRate := flags["rate"]
+	if !hasIncome && !hasRate {
+		return fmt.Errorf("income is required (or --rate and --per)")
 	}
 	if err := applyIntField(flags, cfg, req, "income", &req.GrossWage, 0); err != nil {
 		return err
 	}
+	if err := applyRateFields(flags, req); err != nil {
+		return err
+	}
+	if err := applyProRataFields(flags, req); err != nil {
+		return err
+	}
 
 	if err := applyIntField(flags, cfg, req, "partner-income", &req.PartnerGrossWage, cfg.Defaults.PartnerIncome); err != nil {
 		return err
 	}
 
+	// Other income is per option only
+	if err := applyIntField(flags, cfg, req, "dividends", &req.Dividends, 0); err != nil {
+		return err
+	}
+	if err := applyIntField(flags, cfg, req, "savings-interest", &req.SavingsInterest, 0); err != nil {
+		return err
+	}
+	if err := applyIntField(flags, cfg, req, "rental-profit", &req.RentalProfit, 0); err != nil {
+		return err
+	}
+
 	// Apply boolean fields
 	applyBoolField(flags, cfg, &req.Married, "married", cfg.Defaults.Married)
 	applyBoolField(flags, cfg, &req.Blind, "blind", cfg.Defaults.Blind)
@@ -380,6 +634,52 @@ func applyCompareTaxRequestDefaults(flags map[string]string, cfg *config.Config,
 	return nil
 }
 
+// applyRateFields sets the rate of pay from the rate and per flags, if either is given
+func applyRateFields(flags map[string]string, req *types.TaxRequest) error {
+	rate, hasRate := flags["rate"]
+	per, hasPer := flags["per"]
+	if !hasRate && !hasPer {
+		return nil
+	}
+
+	req.Rate = &types.IncomeRate{Per: per}
+	if hasRate {
+		floatVal, err := strconv.ParseFloat(rate, 64)
+		if err != nil {
+			return fmt.Errorf("rate must be a valid number: %s", rate)
+		}
+		req.Rate.Rate = floatVal
+	}
+	return nil
+}
+
+// applyProRataFields sets the part-time fraction from the fte and pro-rata-days flags, if either is given
+func applyProRataFields(flags map[string]string, req *types.TaxRequest) error {
+	_, hasFTE := flags["fte"]
+	_, hasDays := flags["pro-rata-days"]
+	if !hasFTE && !hasDays {
+		return nil
+	}
+
+	req.ProRata = &types.ProRata{}
+	if err := applyFloatField(flags, "fte", &req.ProRata.FTE); err != nil {
+		return err
+	}
+	return applyFloatField(flags, "pro-rata-days", &req.ProRata.Days)
+}
+
+// applyFloatField applies a decimal field from flags
+func applyFloatField(flags map[string]string, flagName string, target *float64) error {
+	if val, ok := flags[flagName]; ok {
+		floatVal, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return fmt.Errorf("%s must be a valid number: %s", flagName, val)
+		}
+		*target = floatVal
+	}
+	return nil
+}
+
 // applyStringField applies a string field from flags or config defaults
 func applyStringField(flags map[string]string, _ *config.Config, _ *types.TaxRequest, flagName string, target *string, configDefault, hardDefault string) {
 	if val, ok := flags[flagName]; ok {
@@ -441,6 +741,11 @@ func validateOption(opt *ComparisonOption, _ *config.Config) error {
 		return fmt.Errorf("option '%s': --partner-income cannot be negative", opt.Label)
 	}
 
+	// Validate other income is not negative
+	if err := validateOtherIncome(req); err != nil {
+		return fmt.Errorf("option '%s': %w", opt.Label, err)
+	}
+
 	// Validate student loan plan
 	if req.Plan != "" {
 		validPlans := []string{"plan1", "plan2", "plan4", "postgraduate", "scottish"}
@@ -456,5 +761,15 @@ func validateOption(opt *ComparisonOption, _ *config.Config) error {
 		}
 	}
 
+	// Validate the pension scheme and employer pension
+	if err := validatePensionScheme(req); err != nil {
+		return fmt.Errorf("option '%s': %w", opt.Label, err)
+	}
+
+	// Validate tax code before it is sent to the API
+	if err := validateTaxCode(req.TaxCode); err != nil {
+		return fmt.Errorf("option '%s': %w", opt.Label, err)
+	}
+
 	return nil
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -339,3 +340,63 @@ func TestRunCompare_FourOptionsSuccess(t *testing.T) {
 	assert.Contains(t, output, "Job 3")
 	assert.Contains(t, output, "Job 4")
 }
+
+func TestRunCompare_ChartAndExport(t *testing.T) {
+	// Setup viper test
+	testutil.SetupViperTest(t)
+
+	// Create config file
+	configPath := testutil.CreateTempConfigFile(t, testutil.ValidConfigYAML)
+	_ = os.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+	t.Cleanup(func() { _ = os.Unsetenv("LISTENTOTAXMAN_CONFIG") })
+
+	// Mock os.Args
+	originalArgs := os.Args
+	t.Cleanup(func() { os.Args = originalArgs })
+
+	chartPath := filepath.Join(t.TempDir(), "chart.svg")
+	os.Args = []string{
+		"listentotaxman",
+		"compare",
+		"--chart", "--no-unicode", "--export-chart", chartPath,
+		"--option", "Low", "--income", "40000", "--pension", "5%", "--pension-scheme", "sacrifice",
+		"--option", "High", "--income", "60000", "--pension", "5%", "--pension-scheme", "sacrifice",
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runCompare(compareCmd, []string{})
+		require.NoError(t, err)
+	})
+	assert.Contains(t, output, "Where Each Pound Goes - Monthly")
+	assert.Contains(t, output, "# Net Pay")
+
+	svg, err := os.ReadFile(chartPath) // #nosec G304 -- test file
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "<svg")
+	assert.Contains(t, string(svg), "Net Pay by Gross Salary - Monthly")
+}
+
+func TestRunCompare_ExportChartFormat(t *testing.T) {
+	// Setup viper test
+	testutil.SetupViperTest(t)
+
+	// Create config file
+	configPath := testutil.CreateTempConfigFile(t, testutil.ValidConfigYAML)
+	_ = os.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+	t.Cleanup(func() { _ = os.Unsetenv("LISTENTOTAXMAN_CONFIG") })
+
+	// Mock os.Args
+	originalArgs := os.Args
+	t.Cleanup(func() { os.Args = originalArgs })
+
+	os.Args = []string{
+		"listentotaxman",
+		"compare",
+		"--export-chart", "chart.pdf",
+		"--option", "Low", "--income", "40000",
+		"--option", "High", "--income", "60000",
+	}
+
+	err := runCompare(compareCmd, []string{})
+	assert.EqualError(t, err, "--export-chart must be a .svg or .png file, got: chart.pdf")
+}
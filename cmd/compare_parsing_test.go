@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/types"
 )
 
 func TestParseComparisonArgs_BasicTwoOptions(t *testing.T) {
@@ -65,6 +66,77 @@ func TestParseComparisonArgs_WithGlobalFlags(t *testing.T) {
 	assert.Equal(t, "true", globalFlags["verbose"])
 }
 
+func TestParseComparisonArgs_RealTerms(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.Defaults{},
+	}
+
+	args := []string{
+		"listentotaxman",
+		"compare",
+		"--real",
+		"--cpi-file", "cpi.csv",
+		"--option", "Last Year", "--income", "50000", "--year", "2023",
+		"--option", "This Year", "--income", "53000", "--year", "2024", "--base-year", "2023",
+	}
+
+	globalFlags, options, err := parseComparisonArgs(args, cfg)
+	require.NoError(t, err)
+	require.Len(t, options, 2)
+
+	assert.Equal(t, "true", globalFlags["real"])
+	assert.Equal(t, "cpi.csv", globalFlags["cpi-file"])
+	assert.Equal(t, "2023", globalFlags["base-year"])
+	assert.Equal(t, "2024", options[1].Request.Year)
+	assert.Equal(t, 53000, options[1].Request.GrossWage)
+}
+
+func TestParseComparisonArgs_Chart(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.Defaults{},
+	}
+
+	args := []string{
+		"listentotaxman",
+		"compare",
+		"--chart",
+		"--option", "Low", "--income", "40000",
+		"--option", "High", "--income", "60000", "--no-unicode",
+	}
+
+	globalFlags, options, err := parseComparisonArgs(args, cfg)
+	require.NoError(t, err)
+	require.Len(t, options, 2)
+
+	assert.Equal(t, "true", globalFlags["chart"])
+	assert.Equal(t, "true", globalFlags["no-unicode"])
+	assert.Equal(t, 60000, options[1].Request.GrossWage)
+}
+
+func TestParseComparisonArgs_CurrencyFlags(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.Defaults{},
+	}
+
+	args := []string{
+		"listentotaxman",
+		"compare",
+		"--display-currency", "EUR", "--fx-rate", "1.17",
+		"--option", "Low", "--income", "40000",
+		"--option", "High", "--income", "60000", "--no-symbol", "--decimals", "0",
+	}
+
+	globalFlags, options, err := parseComparisonArgs(args, cfg)
+	require.NoError(t, err)
+	require.Len(t, options, 2)
+
+	assert.Equal(t, "EUR", globalFlags["display-currency"])
+	assert.Equal(t, "1.17", globalFlags["fx-rate"])
+	assert.Equal(t, "true", globalFlags["no-symbol"])
+	assert.Equal(t, "0", globalFlags["decimals"])
+	assert.Equal(t, 60000, options[1].Request.GrossWage)
+}
+
 func TestParseComparisonArgs_FourOptions(t *testing.T) {
 	cfg := &config.Config{
 		Defaults: config.Defaults{},
@@ -425,3 +497,141 @@ func TestBuildTaxRequest_StaticFields(t *testing.T) {
 	assert.Equal(t, "json", req.Response)
 	assert.Equal(t, "1", req.Time)
 }
+
+func TestParseComparisonArgs_OtherIncome(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.Defaults{},
+	}
+
+	args := []string{
+		"listentotaxman",
+		"compare",
+		"--option", "Salary", "--income", "60000",
+		"--option", "Investor", "--income", "60000", "--dividends", "5000", "--savings-interest", "1200", "--rental-profit", "8000",
+	}
+
+	_, options, err := parseComparisonArgs(args, cfg)
+	require.NoError(t, err)
+	require.Len(t, options, 2)
+
+	assert.Equal(t, 0, options[0].Request.Dividends)
+	assert.Equal(t, 5000, options[1].Request.Dividends)
+	assert.Equal(t, 1200, options[1].Request.SavingsInterest)
+	assert.Equal(t, 8000, options[1].Request.RentalProfit)
+}
+
+func TestParseComparisonArgs_InvalidDividends(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.Defaults{},
+	}
+
+	args := []string{
+		"listentotaxman",
+		"compare",
+		"--option", "Job 1", "--income", "60000", "--dividends", "lots",
+		"--option", "Job 2", "--income", "60000",
+	}
+
+	_, _, err := parseComparisonArgs(args, cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dividends must be a valid number")
+}
+
+func TestParseComparisonArgs_WorkPatternFlags(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.Defaults{},
+	}
+
+	args := []string{
+		"listentotaxman",
+		"compare",
+		"--period", "hourly",
+		"--option", "Job 1", "--income", "100000", "--hours-per-week", "32",
+		"--option", "Job 2", "--income", "120000",
+		"--days-per-week", "4",
+	}
+
+	globalFlags, options, err := parseComparisonArgs(args, cfg)
+	require.NoError(t, err)
+	require.Len(t, options, 2)
+	assert.Equal(t, "32", globalFlags["hours-per-week"])
+	assert.Equal(t, "4", globalFlags["days-per-week"])
+
+	// Global flags inside an option are not treated as option flags
+	assert.Equal(t, 100000, options[0].Request.GrossWage)
+	assert.Equal(t, 120000, options[1].Request.GrossWage)
+}
+
+func TestApplyCompareWorkPattern(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Defaults: config.Defaults{HoursPerWeek: 37.5, DaysPerWeek: 5, HolidayDays: 25},
+	}
+	options := []ComparisonOption{
+		{Label: "A", Request: &types.TaxRequest{}},
+		{Label: "B", Request: &types.TaxRequest{}},
+	}
+
+	// Flags override config defaults, which fill the rest
+	err := applyCompareWorkPattern(map[string]string{"hours-per-week": "32", "days-per-week": "4"}, cfg, options)
+	require.NoError(t, err)
+
+	want := types.WorkPattern{HoursPerWeek: 32, DaysPerWeek: 4, HolidayDays: 25}
+	assert.Equal(t, want, options[0].Request.WorkPattern)
+	assert.Equal(t, want, options[1].Request.WorkPattern)
+}
+
+func TestApplyCompareWorkPattern_Invalid(t *testing.T) {
+	t.Parallel()
+
+	options := []ComparisonOption{{Label: "A", Request: &types.TaxRequest{}}}
+
+	err := applyCompareWorkPattern(map[string]string{"holiday-days": "lots"}, &config.Config{}, options)
+	assert.EqualError(t, err, "holiday-days must be a valid number: lots")
+
+	err = applyCompareWorkPattern(map[string]string{"days-per-week": "9"}, &config.Config{}, options)
+	assert.ErrorContains(t, err, "--days-per-week must be between 0 and 7")
+}
+
+func TestBuildTaxRequest_Rate(t *testing.T) {
+	t.Parallel()
+
+	// A rate of pay stands in for income until it is converted
+	req, err := buildTaxRequest(map[string]string{"year": "2024", "rate": "450", "per": "day"}, &config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, req.GrossWage)
+	assert.Equal(t, &types.IncomeRate{Rate: 450, Per: "day"}, req.Rate)
+
+	req.WorkPattern = types.WorkPattern{HolidayDays: 25}
+	require.NoError(t, applyRate(req))
+	assert.Equal(t, 105750, req.GrossWage)
+}
+
+func TestBuildTaxRequest_InvalidRate(t *testing.T) {
+	t.Parallel()
+
+	_, err := buildTaxRequest(map[string]string{"year": "2024", "rate": "lots", "per": "hour"}, &config.Config{})
+	assert.EqualError(t, err, "rate must be a valid number: lots")
+}
+
+func TestBuildTaxRequest_ProRata(t *testing.T) {
+	t.Parallel()
+
+	req, err := buildTaxRequest(map[string]string{"year": "2024", "income": "60000", "pro-rata-days": "4"}, &config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, &types.ProRata{Days: 4}, req.ProRata)
+
+	_, err = buildTaxRequest(map[string]string{"year": "2024", "income": "60000", "fte": "most"}, &config.Config{})
+	assert.EqualError(t, err, "fte must be a valid number: most")
+}
+
+func TestBuildTaxRequest_PensionScheme(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Defaults: config.Defaults{EmployerPension: "3%"}}
+	req, err := buildTaxRequest(map[string]string{"year": "2024", "income": "60000", "pension": "5%", "pension-scheme": "sacrifice"}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "sacrifice", req.PensionScheme)
+	assert.Equal(t, "3%", req.EmployerPension)
+}
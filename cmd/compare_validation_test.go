@@ -253,3 +253,42 @@ func TestValidateOption_AllFieldsValid(t *testing.T) {
 	err := validateOption(&opt, cfg)
 	assert.NoError(t, err)
 }
+
+func TestValidateOption_NegativeOtherIncome(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.Defaults{},
+	}
+
+	opt := ComparisonOption{
+		Label: "Job 1",
+		Request: &types.TaxRequest{
+			Year:      "2024",
+			GrossWage: 100000,
+			Dividends: -500,
+		},
+	}
+
+	err := validateOption(&opt, cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--dividends cannot be negative")
+	assert.Contains(t, err.Error(), "Job 1")
+}
+
+func TestValidateOption_InvalidTaxCode(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.Defaults{},
+	}
+
+	opt := ComparisonOption{
+		Label: "Job 1",
+		Request: &types.TaxRequest{
+			Year:      "2024",
+			GrossWage: 100000,
+			TaxCode:   "1257LQ",
+		},
+	}
+
+	err := validateOption(&opt, cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "option 'Job 1': invalid tax code: 1257LQ")
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// currencyOptions are the amount formatting flags, as given
+type currencyOptions struct {
+	decimals           string
+	noSymbol           bool
+	thousandsSeparator string
+	displayCurrency    string
+	fxRate             string
+}
+
+var flagCurrency currencyOptions
+
+// thousandsSeparators are the --thousands-separator names and what they write
+var thousandsSeparators = map[string]string{
+	",":     ",",
+	".":     ".",
+	"'":     "'",
+	"space": " ",
+	"none":  "",
+}
+
+// currencyCodePattern matches an ISO 4217 currency code
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&flagCurrency.decimals, "decimals", "", "Decimal places in amounts: 0 or 2 (default: 2)")
+	flags.BoolVar(&flagCurrency.noSymbol, "no-symbol", false, "Show amounts without a currency symbol")
+	flags.StringVar(&flagCurrency.thousandsSeparator, "thousands-separator", "",
+		"Thousands separator: ',', '.', \"'\", space or none (default: ',')")
+	flags.StringVar(&flagCurrency.displayCurrency, "display-currency", "",
+		"Show amounts converted from GBP to a currency code, e.g. EUR (requires --fx-rate)")
+	flags.StringVar(&flagCurrency.fxRate, "fx-rate", "",
+		"How many of the display currency make £1, e.g. 1.17 (requires --display-currency)")
+}
+
+// applyCurrency sets how amounts are written (flags > config > £ with two
+// decimal places and commas). Amounts are still calculated in pounds
+func applyCurrency(opts currencyOptions, cfg *config.Config) error {
+	opts = resolveCurrencyOptions(opts, cfg)
+	format := display.DefaultCurrencyFormat
+
+	if opts.decimals != "" {
+		if opts.decimals != "0" && opts.decimals != "2" {
+			return fmt.Errorf("--decimals must be 0 or 2, got: %s", opts.decimals)
+		}
+		format.Decimals, _ = strconv.Atoi(opts.decimals)
+	}
+
+	format.NoSymbol = opts.noSymbol
+
+	if opts.thousandsSeparator != "" {
+		separator, ok := thousandsSeparators[opts.thousandsSeparator]
+		if !ok {
+			return fmt.Errorf("--thousands-separator must be ',', '.', \"'\", space or none, got: %s", opts.thousandsSeparator)
+		}
+		format.ThousandsSeparator = separator
+	}
+
+	if err := applyDisplayCurrency(opts, &format); err != nil {
+		return err
+	}
+
+	display.SetCurrencyFormat(format)
+	return nil
+}
+
+// resolveCurrencyOptions returns the formatting options with config file
+// values filling in any flags that were not given. The display currency is
+// upper case, and pounds when neither sets it
+func resolveCurrencyOptions(opts currencyOptions, cfg *config.Config) currencyOptions {
+	defaults := cfg.Defaults
+	return currencyOptions{
+		decimals:           firstNonEmpty(opts.decimals, defaults.Decimals),
+		noSymbol:           opts.noSymbol || defaults.NoSymbol,
+		thousandsSeparator: firstNonEmpty(opts.thousandsSeparator, defaults.ThousandsSeparator),
+		displayCurrency:    strings.ToUpper(firstNonEmpty(opts.displayCurrency, defaults.DisplayCurrency, display.BaseCurrency)),
+		fxRate:             firstNonEmpty(opts.fxRate, defaults.FXRate),
+	}
+}
+
+// applyDisplayCurrency sets the currency amounts are converted into and its
+// rate, which must be given together
+func applyDisplayCurrency(opts currencyOptions, format *display.CurrencyFormat) error {
+	currency, rate := opts.displayCurrency, opts.fxRate
+	if !currencyCodePattern.MatchString(currency) {
+		return fmt.Errorf("--display-currency must be a three-letter currency code, e.g. EUR, got: %s", currency)
+	}
+
+	switch {
+	case currency == display.BaseCurrency && rate != "":
+		return fmt.Errorf("--fx-rate requires --display-currency")
+	case currency != display.BaseCurrency && rate == "":
+		return fmt.Errorf("--display-currency %s requires --fx-rate", currency)
+	case rate != "":
+		value, err := strconv.ParseFloat(rate, 64)
+		if err != nil || value <= 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("--fx-rate must be a positive number, got: %s", rate)
+		}
+		format.Currency = currency
+		format.Rate = value
+	}
+	return nil
+}
+
+// withDisplayCurrency returns a copy of a response, already divided into the
+// display period, with its main figures in the display currency
+func withDisplayCurrency(resp *types.TaxResponse) *types.TaxResponse {
+	converted := display.DisplayCurrency(resp, 1)
+	if converted == nil {
+		return resp
+	}
+	out := *resp
+	out.DisplayCurrency = converted
+	return &out
+}
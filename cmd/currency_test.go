@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+)
+
+func TestApplyCurrency(t *testing.T) {
+	t.Cleanup(func() { display.SetCurrencyFormat(display.DefaultCurrencyFormat) })
+
+	cfg := &config.Config{Defaults: config.Defaults{Decimals: "0", ThousandsSeparator: "space"}}
+	require.NoError(t, applyCurrency(currencyOptions{displayCurrency: "eur", fxRate: "1.17"}, cfg))
+	resp := withDisplayCurrency(testutil.CreateSampleTaxResponse())
+	require.NotNil(t, resp.DisplayCurrency)
+	assert.Equal(t, "EUR", resp.DisplayCurrency.Currency)
+	assert.InDelta(t, 58500, resp.DisplayCurrency.GrossPay, 0.001)
+
+	// The flags win over the config
+	require.NoError(t, applyCurrency(currencyOptions{decimals: "2", thousandsSeparator: "none", noSymbol: true}, cfg))
+	assert.Nil(t, withDisplayCurrency(testutil.CreateSampleTaxResponse()).DisplayCurrency)
+}
+
+func TestResolveCurrencyOptions(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Defaults: config.Defaults{Decimals: "0", ThousandsSeparator: "space", NoSymbol: true, FXRate: "1.17"}}
+	assert.Equal(t, currencyOptions{
+		decimals:           "2",
+		noSymbol:           true,
+		thousandsSeparator: "space",
+		displayCurrency:    "EUR",
+		fxRate:             "1.17",
+	}, resolveCurrencyOptions(currencyOptions{decimals: "2", displayCurrency: "eur"}, cfg))
+
+	// Pounds are the display currency when neither sets one
+	assert.Equal(t, display.BaseCurrency, resolveCurrencyOptions(currencyOptions{}, &config.Config{}).displayCurrency)
+}
+
+func TestApplyCurrency_Errors(t *testing.T) {
+	t.Cleanup(func() { display.SetCurrencyFormat(display.DefaultCurrencyFormat) })
+
+	tests := []struct {
+		name    string
+		opts    currencyOptions
+		wantErr string
+	}{
+		{"decimals", currencyOptions{decimals: "3"}, "--decimals must be 0 or 2, got: 3"},
+		{"separator", currencyOptions{thousandsSeparator: "_"}, `--thousands-separator must be ',', '.', "'", space or none, got: _`},
+		{"currency code", currencyOptions{displayCurrency: "euro", fxRate: "1.17"}, "--display-currency must be a three-letter currency code, e.g. EUR, got: EURO"},
+		{"missing rate", currencyOptions{displayCurrency: "EUR"}, "--display-currency EUR requires --fx-rate"},
+		{"missing currency", currencyOptions{fxRate: "1.17"}, "--fx-rate requires --display-currency"},
+		{"negative rate", currencyOptions{displayCurrency: "EUR", fxRate: "-1"}, "--fx-rate must be a positive number, got: -1"},
+		{"not a number", currencyOptions{displayCurrency: "EUR", fxRate: "NaN"}, "--fx-rate must be a positive number, got: NaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, applyCurrency(tt.opts, &config.Config{}), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+var (
+	flagEmployerCostIncome              int
+	flagEmployerCostBudget              int
+	flagEmployerCostHires               int
+	flagEmployerCostYear                string
+	flagEmployerCostPeriod              string
+	flagEmployerCostPension             string
+	flagEmployerCostEmploymentAllowance bool
+	flagEmployerCostPayBill             int
+	flagEmployerCostJSON                bool
+)
+
+var employerCostCmd = &cobra.Command{
+	Use:   "employer-cost",
+	Short: "Calculate the cost of employing someone",
+	Long: `Calculate the total cost to the business of one or more hires on a salary.
+
+The cost is the salary plus employer's National Insurance, less any
+Employment Allowance (--employment-allowance), plus the apprenticeship levy
+and the employer pension contribution. The levy is 0.5% of a pay bill over
+£3 million, so give your existing annual pay bill with --pay-bill to include
+the levy the hires add to it.
+
+With --budget instead of --income, the salary is solved backwards: the
+highest salary for each hire whose total cost fits within the budget.`,
+	Example: `  listentotaxman employer-cost --income 45000 --employer-pension 5%
+  listentotaxman employer-cost --income 30000 --hires 3 --employment-allowance --period monthly
+  listentotaxman employer-cost --budget 150000 --hires 2 --employer-pension 3%`,
+	RunE: runEmployerCost,
+}
+
+func init() {
+	rootCmd.AddCommand(employerCostCmd)
+
+	employerCostCmd.Flags().IntVar(&flagEmployerCostIncome, "income", 0, "Gross annual salary for each hire")
+	employerCostCmd.Flags().IntVar(&flagEmployerCostBudget, "budget", 0, "Total annual budget for all hires, to solve for the salary")
+	employerCostCmd.Flags().IntVar(&flagEmployerCostHires, "hires", 1, "Number of hires on the same salary")
+	employerCostCmd.Flags().StringVar(&flagEmployerCostYear, "year", "", "Tax year (defaults to current tax year)")
+	employerCostCmd.Flags().StringVar(&flagEmployerCostPeriod, "period", "", "Display period (yearly, quarterly, monthly, four-weekly, fortnightly, weekly, daily, hourly) (default: yearly)")
+	employerCostCmd.Flags().StringVar(&flagEmployerCostPension, "employer-pension", "", "Employer pension contribution for each hire (e.g., 5% or 3000)")
+	employerCostCmd.Flags().BoolVar(&flagEmployerCostEmploymentAllowance, "employment-allowance", false, "Claim the Employment Allowance against employer's NI")
+	employerCostCmd.Flags().IntVar(&flagEmployerCostPayBill, "pay-bill", 0, "Existing annual pay bill, for the apprenticeship levy")
+	employerCostCmd.Flags().BoolVar(&flagEmployerCostJSON, "json", false, "Output as JSON")
+}
+
+func runEmployerCost(_ *cobra.Command, _ []string) error {
+	// Load config file
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	req, err := buildEmployerCostRequest(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Period: flag > config > default yearly
+	period := firstNonEmpty(flagEmployerCostPeriod, cfg.Defaults.Period, payperiod.Yearly)
+	if err := payperiod.Validate(period); err != nil {
+		return err
+	}
+
+	cost, err := tax.EmployerCost(req)
+	if err != nil {
+		return fmt.Errorf("failed to calculate employer cost: %w", err)
+	}
+
+	if flagEmployerCostJSON {
+		jsonData, err := json.MarshalIndent(adjustEmployerCostForPeriod(cost, period), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	display.EmployerCost(cost, period)
+	return nil
+}
+
+// buildEmployerCostRequest builds and validates an EmployerCostRequest from flags and config
+func buildEmployerCostRequest(cfg *config.Config) (*types.EmployerCostRequest, error) {
+	req := &types.EmployerCostRequest{
+		Year:                firstNonEmpty(flagEmployerCostYear, cfg.Defaults.Year, getDefaultYear()),
+		Salary:              flagEmployerCostIncome,
+		Budget:              flagEmployerCostBudget,
+		Hires:               flagEmployerCostHires,
+		EmployerPension:     firstNonEmpty(flagEmployerCostPension, cfg.Defaults.EmployerPension),
+		EmploymentAllowance: flagEmployerCostEmploymentAllowance,
+		PayBill:             flagEmployerCostPayBill,
+	}
+
+	switch {
+	case req.Salary != 0 && req.Budget != 0:
+		return nil, fmt.Errorf("--income cannot be used with --budget")
+	case req.Salary < 0:
+		return nil, fmt.Errorf("--income must be greater than 0")
+	case req.Budget < 0:
+		return nil, fmt.Errorf("--budget must be greater than 0")
+	case req.Salary == 0 && req.Budget == 0:
+		return nil, fmt.Errorf("--income or --budget is required")
+	case req.Hires < 1:
+		return nil, fmt.Errorf("--hires must be at least 1, got: %d", req.Hires)
+	case req.PayBill < 0:
+		return nil, fmt.Errorf("--pay-bill cannot be negative")
+	}
+
+	if _, err := tax.ParsePension(req.EmployerPension, 0); err != nil {
+		return nil, fmt.Errorf("--employer-pension: %w", err)
+	}
+
+	return req, nil
+}
+
+// adjustEmployerCostForPeriod divides the employer's costs into the display period
+func adjustEmployerCostForPeriod(cost *types.EmployerCost, period string) *types.EmployerCost {
+	divisor := payperiod.Divisor(period, types.WorkPattern{})
+
+	adjusted := *cost
+	adjusted.Budget /= divisor
+	adjusted.Salary /= divisor
+	adjusted.GrossPay /= divisor
+	adjusted.EmployersNI /= divisor
+	adjusted.EmploymentAllowance /= divisor
+	adjusted.ApprenticeshipLevy /= divisor
+	adjusted.EmployerPension /= divisor
+	adjusted.TotalCost /= divisor
+	return &adjusted
+}
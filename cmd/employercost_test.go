@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func setEmployerCostFlags(t *testing.T) {
+	t.Helper()
+
+	flagEmployerCostIncome = 30000
+	flagEmployerCostBudget = 0
+	flagEmployerCostHires = 1
+	flagEmployerCostYear = "2025"
+	flagEmployerCostPeriod = "yearly"
+	flagEmployerCostPension = ""
+	flagEmployerCostEmploymentAllowance = false
+	flagEmployerCostPayBill = 0
+	flagEmployerCostJSON = false
+	t.Cleanup(func() {
+		flagEmployerCostIncome = 0
+		flagEmployerCostBudget = 0
+		flagEmployerCostHires = 1
+		flagEmployerCostYear = ""
+		flagEmployerCostPeriod = ""
+		flagEmployerCostPension = ""
+		flagEmployerCostEmploymentAllowance = false
+		flagEmployerCostPayBill = 0
+		flagEmployerCostJSON = false
+	})
+}
+
+func TestRunEmployerCost(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setEmployerCostFlags(t)
+	flagEmployerCostHires = 3
+	flagEmployerCostEmploymentAllowance = true
+	flagEmployerCostPension = "3%"
+	flagEmployerCostPeriod = "monthly"
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runEmployerCost(employerCostCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	assert.Contains(t, output, "Employer Cost for 2025 - Monthly")
+	assert.Contains(t, output, "║ Employer Cost        ║ Per Hire     ║ 3 Hires      ║")
+	assert.Contains(t, output, "║ Employment Allowance ║     -£291.67 ║     -£875.00 ║")
+	assert.Contains(t, output, "║ Total Cost           ║    £2,595.83 ║    £7,787.50 ║")
+}
+
+func TestRunEmployerCost_JSON(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setEmployerCostFlags(t)
+	flagEmployerCostIncome = 0
+	flagEmployerCostBudget = 150000
+	flagEmployerCostHires = 2
+	flagEmployerCostPension = "3%"
+	flagEmployerCostPeriod = "monthly"
+	flagEmployerCostJSON = true
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runEmployerCost(employerCostCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	var cost types.EmployerCost
+	require.NoError(t, json.Unmarshal([]byte(output), &cost))
+	assert.Equal(t, 2, cost.Hires)
+	assert.InDelta(t, 12500, cost.Budget, 0.01)
+	assert.InDelta(t, 64194.0/12, cost.Salary, 0.01)
+	assert.InDelta(t, 149997.84/12, cost.TotalCost, 0.01)
+}
+
+func TestRunEmployerCost_Errors(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	tests := []struct {
+		name     string
+		setup    func()
+		errorMsg string
+	}{
+		{"missing income", func() { flagEmployerCostIncome = 0 }, "--income or --budget is required"},
+		{"income and budget", func() { flagEmployerCostBudget = 100000 }, "--income cannot be used with --budget"},
+		{"negative income", func() { flagEmployerCostIncome = -1 }, "--income must be greater than 0"},
+		{"negative budget", func() { flagEmployerCostIncome, flagEmployerCostBudget = 0, -1 }, "--budget must be greater than 0"},
+		{"no hires", func() { flagEmployerCostHires = 0 }, "--hires must be at least 1, got: 0"},
+		{"negative pay bill", func() { flagEmployerCostPayBill = -1 }, "--pay-bill cannot be negative"},
+		{"invalid pension", func() { flagEmployerCostPension = "lots" }, "--employer-pension: invalid pension amount: lots"},
+		{"invalid period", func() { flagEmployerCostPeriod = "annually" }, "invalid period: annually"},
+		{"unsupported year", func() { flagEmployerCostYear = "2010" }, "no local tax rates for 2010"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEmployerCostFlags(t)
+			tt.setup()
+
+			err := runEmployerCost(employerCostCmd, []string{})
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
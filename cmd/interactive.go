@@ -0,0 +1,444 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// interactivePrompt is a question the interactive wizard asks, setting a field
+type interactivePrompt struct {
+	field    string
+	question string
+	fallback func(cfg *config.Config) string
+}
+
+// interactivePrompts are the wizard's questions, in order. An empty answer
+// leaves the field unset, so the config file default applies.
+var interactivePrompts = []interactivePrompt{
+	{"year", "Tax year", func(cfg *config.Config) string { return firstNonEmpty(cfg.Defaults.Year, getDefaultYear()) }},
+	{"region", "Region (uk, scotland, wales)", func(cfg *config.Config) string { return firstNonEmpty(cfg.Defaults.Region, "uk") }},
+	{"pension", "Pension contribution (e.g. 5% or 3000)", func(cfg *config.Config) string { return cfg.Defaults.Pension }},
+	{"pension-scheme", "Pension scheme (sacrifice, net-pay, relief-at-source)", func(cfg *config.Config) string { return cfg.Defaults.PensionScheme }},
+	{"student-loan", "Student loan plan (plan1, plan2, plan4, postgraduate, scottish)", func(cfg *config.Config) string { return cfg.Defaults.StudentLoan }},
+	{"tax-code", "Tax code (e.g. 1257L)", func(cfg *config.Config) string { return cfg.Defaults.TaxCode }},
+}
+
+// interactiveMaxCompare is the most scenarios compared at once, as for compare
+const interactiveMaxCompare = 4
+
+const interactiveHelp = `Commands:
+  set FIELD VALUE [FIELD VALUE ...]  Change fields and recalculate (e.g. set pension 8%)
+  unset FIELD                        Clear a field back to its default and recalculate
+  name LABEL                         Name the current scenario
+  show [verbose]                     Show the current scenario again
+  fields                             List the current scenario's fields
+  history                            List the scenarios calculated so far
+  compare [N N ...]                  Compare scenarios by number (default: the last 4)
+  help                               Show this help
+  quit                               Leave`
+
+var interactiveCmd = &cobra.Command{
+	Use:   "interactive",
+	Short: "Calculate tax step by step, changing one thing at a time",
+	Long: `Answer a few questions about your income, pension and student loan to see
+your take-home pay, then change one field at a time to see what difference it
+makes, without any flags.
+
+Every calculation is kept as a numbered scenario, and any of them can be
+compared side by side as the compare command does. Leave an answer empty to use
+the default shown in brackets, which comes from the config file.`,
+	Example: `  listentotaxman interactive`,
+	RunE:    runInteractive,
+}
+
+func init() {
+	rootCmd.AddCommand(interactiveCmd)
+}
+
+func runInteractive(cmd *cobra.Command, _ []string) error {
+	// Load config file
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return newInteractiveSession(cfg, cmd.InOrStdin()).run()
+}
+
+// interactiveScenario is one calculation in an interactive session
+type interactiveScenario struct {
+	Label    string
+	Fields   map[string]string
+	Period   string
+	Request  *types.TaxRequest
+	Response *types.TaxResponse
+}
+
+// interactiveSession holds the answers and scenarios of an interactive session
+type interactiveSession struct {
+	cfg     *config.Config
+	input   *bufio.Scanner
+	cache   *calculationCache
+	history []interactiveScenario
+}
+
+// newInteractiveSession creates a session reading answers from input
+func newInteractiveSession(cfg *config.Config, input io.Reader) *interactiveSession {
+	return &interactiveSession{
+		cfg:   cfg,
+		input: bufio.NewScanner(input),
+		cache: newCalculationCache(0),
+	}
+}
+
+// run asks the wizard's questions, shows the result, then reads commands
+// until quit or the end of the input
+func (s *interactiveSession) run() error {
+	fmt.Println("Answer each question, or press enter for the default in brackets.")
+
+	// Ask again until the answers can be calculated
+	for {
+		fields, period, ok := s.wizard()
+		if !ok {
+			return nil
+		}
+		if s.calculate(fields, period) {
+			break
+		}
+	}
+
+	fmt.Println(`Type "set FIELD VALUE" to change something, "compare" to compare scenarios or "help".`)
+	for {
+		fmt.Print("> ")
+		line, ok := s.readLine()
+		if !ok {
+			fmt.Println()
+			return nil
+		}
+		if s.handle(line) {
+			return nil
+		}
+	}
+}
+
+// wizard asks for the income, each prompt's field and the display period
+func (s *interactiveSession) wizard() (map[string]string, string, bool) {
+	fields := make(map[string]string)
+
+	income, ok := s.askIncome()
+	if !ok {
+		return nil, "", false
+	}
+	fields["income"] = income
+
+	for _, prompt := range interactivePrompts {
+		answer, ok := s.ask(prompt.question, prompt.fallback(s.cfg))
+		if !ok {
+			return nil, "", false
+		}
+		if answer != "" {
+			fields[prompt.field] = answer
+		}
+	}
+
+	for {
+		period, ok := s.ask("Show pay per ("+strings.Join(payperiod.Names, ", ")+")",
+			firstNonEmpty(s.cfg.Defaults.Period, payperiod.Yearly))
+		if !ok {
+			return nil, "", false
+		}
+		period = firstNonEmpty(period, s.cfg.Defaults.Period, payperiod.Yearly)
+		if err := payperiod.Validate(period); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		return fields, period, true
+	}
+}
+
+// askIncome asks for the annual income until it is a whole number above 0
+func (s *interactiveSession) askIncome() (string, bool) {
+	for {
+		answer, ok := s.ask("Annual income before tax (£)", "")
+		if !ok {
+			return "", false
+		}
+
+		income := strings.NewReplacer("£", "", ",", "").Replace(answer)
+		if value, err := strconv.Atoi(income); err == nil && value > 0 {
+			return income, true
+		}
+		fmt.Println("Error: income must be a whole number greater than 0, e.g. 45000")
+	}
+}
+
+// ask prints a question with its default and returns the trimmed answer
+func (s *interactiveSession) ask(question, fallback string) (string, bool) {
+	if fallback != "" {
+		fmt.Printf("%s [%s]: ", question, fallback)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+
+	answer, ok := s.readLine()
+	if !ok {
+		fmt.Println()
+	}
+	return answer, ok
+}
+
+// readLine reads the next line of input, returning false at the end of the input
+func (s *interactiveSession) readLine() (string, bool) {
+	if !s.input.Scan() {
+		return "", false
+	}
+	return strings.TrimSpace(s.input.Text()), true
+}
+
+// handle runs a command, returning true when the session should end
+func (s *interactiveSession) handle(line string) bool {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return false
+	}
+
+	current := s.history[len(s.history)-1]
+	switch strings.ToLower(args[0]) {
+	case "set":
+		s.set(current, args[1:])
+	case "unset":
+		s.unset(current, args[1:])
+	case "name":
+		s.name(args[1:])
+	case "show":
+		s.show(current, len(args) > 1 && args[1] == "verbose")
+	case "fields":
+		printInteractiveFields(current)
+	case "history":
+		display.ScenarioHistory(s.results(), current.Period)
+	case "compare":
+		s.compare(args[1:])
+	case "help", "?":
+		fmt.Println(interactiveHelp)
+		fmt.Printf("\nFields: period, %s\n", strings.Join(interactiveFields(), ", "))
+	case "quit", "exit", "q":
+		return true
+	default:
+		fmt.Printf("Unknown command: %s (type \"help\" for the commands)\n", args[0])
+	}
+	return false
+}
+
+// set changes fields of the current scenario and recalculates as a new scenario
+func (s *interactiveSession) set(current interactiveScenario, args []string) {
+	if len(args) == 0 || len(args)%2 != 0 {
+		fmt.Println("Usage: set FIELD VALUE [FIELD VALUE ...]")
+		return
+	}
+
+	fields := maps.Clone(current.Fields)
+	period := current.Period
+	for i := 0; i < len(args); i += 2 {
+		field, value := strings.ToLower(args[i]), args[i+1]
+		if field == "period" {
+			period = value
+			continue
+		}
+		if err := validateInteractiveField(field); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fields[field] = value
+	}
+	s.calculate(fields, period)
+}
+
+// unset clears fields of the current scenario and recalculates as a new scenario
+func (s *interactiveSession) unset(current interactiveScenario, args []string) {
+	if len(args) == 0 {
+		fmt.Println("Usage: unset FIELD [FIELD ...]")
+		return
+	}
+
+	fields := maps.Clone(current.Fields)
+	for _, arg := range args {
+		field := strings.ToLower(arg)
+		if err := validateInteractiveField(field); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		delete(fields, field)
+	}
+	s.calculate(fields, current.Period)
+}
+
+// name renames the current scenario
+func (s *interactiveSession) name(args []string) {
+	label := strings.Join(args, " ")
+	if label == "" {
+		fmt.Println("Usage: name LABEL")
+		return
+	}
+	s.history[len(s.history)-1].Label = label
+	fmt.Printf("Named scenario %d %q\n", len(s.history), label)
+}
+
+// show displays a scenario's result
+func (s *interactiveSession) show(scenario interactiveScenario, verbose bool) {
+	fmt.Printf("\n%s\n", scenario.Label)
+	if verbose {
+		display.Detailed(scenario.Response, scenario.Period, scenario.Request)
+	} else {
+		display.Summary(scenario.Response, scenario.Period, scenario.Request)
+	}
+}
+
+// compare displays scenarios side by side, by number or the most recent
+func (s *interactiveSession) compare(args []string) {
+	results := s.results()
+
+	var chosen []types.ComparisonResult
+	if len(args) == 0 {
+		chosen = results[max(0, len(results)-interactiveMaxCompare):]
+	} else {
+		for _, arg := range args {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 1 || n > len(results) {
+				fmt.Printf("Error: no scenario %s (there are %d)\n", arg, len(results))
+				return
+			}
+			chosen = append(chosen, results[n-1])
+		}
+	}
+
+	if len(chosen) < 2 {
+		fmt.Println(`Error: at least 2 scenarios required for comparison (use "set" to make another)`)
+		return
+	}
+	if len(chosen) > interactiveMaxCompare {
+		fmt.Printf("Error: maximum %d scenarios supported for comparison (found %d)\n", interactiveMaxCompare, len(chosen))
+		return
+	}
+	display.Comparison(chosen, s.history[len(s.history)-1].Period, false)
+}
+
+// calculate builds, validates and calculates a scenario from its fields,
+// adding it to the history and showing the result. Errors are shown and
+// the scenario is not kept.
+func (s *interactiveSession) calculate(fields map[string]string, period string) bool {
+	scenario, err := s.calculateScenario(fields, period)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return false
+	}
+
+	s.history = append(s.history, scenario)
+	s.show(scenario, false)
+	return true
+}
+
+// calculateScenario calculates a scenario, labelled by its position in the history
+func (s *interactiveSession) calculateScenario(fields map[string]string, period string) (interactiveScenario, error) {
+	if err := payperiod.Validate(period); err != nil {
+		return interactiveScenario{}, err
+	}
+
+	req, resp, err := calculateFields(fields, s.cfg, s.cache)
+	if err != nil {
+		return interactiveScenario{}, err
+	}
+
+	return interactiveScenario{
+		Label:    fmt.Sprintf("Scenario %d", len(s.history)+1),
+		Fields:   fields,
+		Period:   period,
+		Request:  req,
+		Response: resp,
+	}, nil
+}
+
+// calculateFields builds, validates and calculates a request from field
+// values, with the config file's working pattern, through the cache
+func calculateFields(fields map[string]string, cfg *config.Config, cache *calculationCache) (*types.TaxRequest, *types.TaxResponse, error) {
+	values, err := batchFields(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := buildCheckRequestFromFields(values, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The config file's working pattern applies, as for check and compare
+	if err := applyCompareWorkPattern(map[string]string{}, cfg, []ComparisonOption{{Request: req}}); err != nil {
+		return nil, nil, err
+	}
+	if err := applyProRata(req); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := cache.calculate(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to calculate tax: %w", err)
+	}
+
+	// Requests share cached responses, so copy before adding this request's rate
+	reqResp := *resp
+	reqResp.Rate = req.Rate
+	return req, &reqResp, nil
+}
+
+// results returns the history as comparison results
+func (s *interactiveSession) results() []types.ComparisonResult {
+	results := make([]types.ComparisonResult, len(s.history))
+	for i, scenario := range s.history {
+		results[i] = types.ComparisonResult{
+			Label:    scenario.Label,
+			Request:  scenario.Request,
+			Response: scenario.Response,
+		}
+	}
+	return results
+}
+
+// printInteractiveFields lists a scenario's fields in the order they can be set
+func printInteractiveFields(scenario interactiveScenario) {
+	fmt.Printf("  %-18s %s\n", "period", scenario.Period)
+	for _, field := range interactiveFields() {
+		if value, ok := scenario.Fields[field]; ok {
+			fmt.Printf("  %-18s %s\n", field, value)
+		}
+	}
+}
+
+// interactiveFields returns the fields that can be set, as for batch rows
+func interactiveFields() []string {
+	fields := []string{}
+	for _, column := range batchColumns {
+		if column != "label" {
+			fields = append(fields, column)
+		}
+	}
+	return fields
+}
+
+// validateInteractiveField checks that a field can be set
+func validateInteractiveField(field string) error {
+	for _, valid := range interactiveFields() {
+		if field == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown field: %s (must be one of: period, %s)", field, strings.Join(interactiveFields(), ", "))
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/client"
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+)
+
+// runInteractiveSession runs a session over the given lines of input and
+// returns the session and its output
+func runInteractiveSession(t *testing.T, lines ...string) (*interactiveSession, string) {
+	t.Helper()
+
+	session := newInteractiveSession(&config.Config{}, strings.NewReader(strings.Join(lines, "\n")+"\n"))
+	output := testutil.CaptureStdout(t, func() {
+		require.NoError(t, session.run())
+	})
+	return session, output
+}
+
+// interactiveAnswers are wizard answers for a 45000 salary sacrifice scenario
+var interactiveAnswers = []string{"£45,000", "2025", "", "5%", "sacrifice", "", "", "monthly"}
+
+func TestInteractiveSession(t *testing.T) {
+	lines := append(append([]string{}, interactiveAnswers...),
+		"set pension 8%",
+		"name More pension",
+		"set income 50000 period yearly",
+		"history",
+		"compare 1 2",
+		"quit",
+	)
+	session, output := runInteractiveSession(t, lines...)
+
+	require.Len(t, session.history, 3)
+	assert.Equal(t, "Scenario 1", session.history[0].Label)
+	assert.Equal(t, "More pension", session.history[1].Label)
+	assert.Equal(t, "8%", session.history[1].Fields["pension"])
+	assert.Equal(t, "5%", session.history[0].Fields["pension"])
+	assert.Equal(t, "50000", session.history[2].Fields["income"])
+	assert.Equal(t, "yearly", session.history[2].Period)
+	assert.Equal(t, "monthly", session.history[1].Period)
+
+	// The first scenario is the salary in the tax year given
+	assert.Equal(t, 45000, session.history[0].Request.GrossWage)
+	assert.Equal(t, "2025", session.history[0].Request.Year)
+	assert.Equal(t, "sacrifice", session.history[0].Request.PensionScheme)
+
+	assert.Contains(t, output, "Annual income before tax (£): ")
+	assert.Contains(t, output, "Region (uk, scotland, wales) [uk]: ")
+	assert.Contains(t, output, "Scenarios - Yearly")
+	assert.Contains(t, output, "* 3 Scenario 3")
+	assert.Contains(t, output, "Scenario 1")
+	assert.Contains(t, output, "More pensi…")
+}
+
+func TestInteractiveSession_Wizard(t *testing.T) {
+	// An invalid income is asked again, and an invalid plan restarts the wizard
+	lines := []string{"lots", "45000", "2025", "", "5%", "sacrifice", "plan9", "", "",
+		"45000", "2025", "", "5%", "sacrifice", "plan2", "", "weekly"}
+	session, output := runInteractiveSession(t, lines...)
+
+	assert.Contains(t, output, "Error: income must be a whole number greater than 0, e.g. 45000")
+	assert.Contains(t, output, "Error: invalid student loan plan: plan9")
+	require.Len(t, session.history, 1)
+	assert.Equal(t, "plan2", session.history[0].Request.Plan)
+	assert.Equal(t, "weekly", session.history[0].Period)
+}
+
+func TestInteractiveSession_Errors(t *testing.T) {
+	lines := append(append([]string{}, interactiveAnswers...),
+		"set salary 50000",
+		"set pension",
+		"set student-loan plan9",
+		"set period fortnightlyish",
+		"unset",
+		"compare",
+		"compare 1 7",
+		"dance",
+	)
+	session, output := runInteractiveSession(t, lines...)
+
+	// Nothing that failed was kept
+	require.Len(t, session.history, 1)
+	assert.Contains(t, output, "Error: unknown field: salary")
+	assert.Contains(t, output, "Usage: set FIELD VALUE [FIELD VALUE ...]")
+	assert.Contains(t, output, "Error: invalid student loan plan: plan9")
+	assert.Contains(t, output, "Error: invalid period: fortnightlyish")
+	assert.Contains(t, output, "Usage: unset FIELD [FIELD ...]")
+	assert.Contains(t, output, "Error: at least 2 scenarios required for comparison")
+	assert.Contains(t, output, "Error: no scenario 7 (there are 1)")
+	assert.Contains(t, output, `Unknown command: dance (type "help" for the commands)`)
+}
+
+func TestInteractiveSession_Unset(t *testing.T) {
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	lines := append(append([]string{}, interactiveAnswers...), "unset pension-scheme pension", "fields")
+	session, output := runInteractiveSession(t, lines...)
+
+	require.Len(t, session.history, 2)
+	assert.NotContains(t, session.history[1].Fields, "pension")
+	assert.Equal(t, 1, mockRT.RequestCount)
+	assert.Contains(t, output, "  income             45000\n")
+}
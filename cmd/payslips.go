@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+var (
+	flagPayslipsIncome      int
+	flagPayslipsYear        string
+	flagPayslipsRegion      string
+	flagPayslipsPension     string
+	flagPayslipsStudentLoan string
+	flagPayslipsTaxCode     string
+	flagPayslipsNoNI        bool
+	flagPayslipsFrequency   string
+	flagPayslipsChanges     []string
+	flagPayslipsPayday      string
+	flagPayslipsJSON        bool
+)
+
+var payslipsCmd = &cobra.Command{
+	Use:   "payslips",
+	Short: "Simulate each payslip in the tax year",
+	Long: `Simulate every payslip in the tax year the way payroll works them out.
+
+Cumulative tax codes are taxed on pay to date, so tax catches up after a pay
+rise or a code change. W1, M1 and X codes tax each period on its own. National
+Insurance and student loan thresholds always apply to each period on its own.
+
+Mid-year changes take effect from a pay period with --change, e.g.
+--change month=7,income=90000 or --change week=20,tax-code=1100L.
+
+With --payday, a weekly, fortnightly or four-weekly year with an extra pay day
+(week 53) has an extra payslip, taxed on a week 1 basis as payroll does.`,
+	Example: `  listentotaxman payslips --income 60000
+  listentotaxman payslips --income 60000 --frequency weekly --tax-code "1257L W1"
+  listentotaxman payslips --income 60000 --change month=7,income=90000
+  listentotaxman payslips --income 60000 --frequency weekly --payday 2026-04-05 --year 2025`,
+	RunE: runPayslips,
+}
+
+func init() {
+	rootCmd.AddCommand(payslipsCmd)
+
+	payslipsCmd.Flags().IntVar(&flagPayslipsIncome, "income", 0, "Gross annual salary (required)")
+	payslipsCmd.Flags().StringVar(&flagPayslipsYear, "year", "", "Tax year (defaults to current tax year)")
+	payslipsCmd.Flags().StringVar(&flagPayslipsRegion, "region", "", "Tax region (default: uk)")
+	payslipsCmd.Flags().StringVar(&flagPayslipsPension, "pension", "", "Pension contribution (e.g., 3% or 3000)")
+	payslipsCmd.Flags().StringVar(&flagPayslipsStudentLoan, "student-loan", "", "Student loan plan (e.g., plan1, plan2, plan4, postgraduate, scottish)")
+	payslipsCmd.Flags().StringVar(&flagPayslipsTaxCode, "tax-code", "", "Tax code, with an optional W1/M1 basis (default: standard code for the year)")
+	payslipsCmd.Flags().BoolVar(&flagPayslipsNoNI, "no-ni", false, "Exempt from National Insurance")
+	payslipsCmd.Flags().StringVar(&flagPayslipsFrequency, "frequency", tax.FrequencyMonthly, "Pay frequency (weekly, fortnightly, four-weekly, monthly)")
+	payslipsCmd.Flags().StringVar(&flagPayslipsPayday, "payday", "", "Any pay date (YYYY-MM-DD), to count weekly, fortnightly or four-weekly pay days (e.g. week 53)")
+	payslipsCmd.Flags().StringArrayVar(&flagPayslipsChanges, "change", nil, "Change from a period onwards, e.g. month=7,income=90000 (repeatable)")
+	payslipsCmd.Flags().BoolVar(&flagPayslipsJSON, "json", false, "Output as JSON")
+}
+
+func runPayslips(cmd *cobra.Command, _ []string) error {
+	// Load config file
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	req, err := buildPayslipsRequest(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tax.PeriodsPerYear(flagPayslipsFrequency); err != nil {
+		return err
+	}
+	if err := applyPayDays(req, flagPayslipsFrequency, flagPayslipsPayday); err != nil {
+		return err
+	}
+
+	changes, err := parseChanges(flagPayslipsChanges, flagPayslipsFrequency)
+	if err != nil {
+		return err
+	}
+
+	slips, err := tax.Payslips(req, flagPayslipsFrequency, changes)
+	if err != nil {
+		return fmt.Errorf("failed to simulate payslips: %w", err)
+	}
+
+	if flagPayslipsJSON {
+		jsonData, err := json.MarshalIndent(slips, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	display.Payslips(slips, req, flagPayslipsFrequency)
+	return nil
+}
+
+// buildPayslipsRequest builds and validates a TaxRequest from payslips flags and config
+func buildPayslipsRequest(cmd *cobra.Command, cfg *config.Config) (*types.TaxRequest, error) {
+	req := &types.TaxRequest{
+		Year:      firstNonEmpty(flagPayslipsYear, cfg.Defaults.Year, getDefaultYear()),
+		TaxRegion: normalizeRegion(firstNonEmpty(flagPayslipsRegion, cfg.Defaults.Region, "uk")),
+		Pension:   firstNonEmpty(flagPayslipsPension, cfg.Defaults.Pension),
+		Plan:      firstNonEmpty(flagPayslipsStudentLoan, cfg.Defaults.StudentLoan),
+		TaxCode:   firstNonEmpty(flagPayslipsTaxCode, cfg.Defaults.TaxCode),
+		GrossWage: flagPayslipsIncome,
+	}
+
+	// No NI: flag > config > default false
+	if (cmd.Flags().Changed("no-ni") && flagPayslipsNoNI) || (!cmd.Flags().Changed("no-ni") && cfg.Defaults.NoNI) {
+		req.ExNI = "y"
+	}
+
+	if err := validateCheckRequest(req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// parseChanges parses --change values such as month=7,income=90000. The period
+// is given as month (monthly pay), week (weekly pay) or period (any frequency).
+func parseChanges(specs []string, frequency string) ([]types.SalaryChange, error) {
+	changes := make([]types.SalaryChange, 0, len(specs))
+	for _, spec := range specs {
+		change, err := parseChange(spec, frequency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --change '%s': %w", spec, err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// parseChange parses a single --change value
+func parseChange(spec, frequency string) (types.SalaryChange, error) {
+	change := types.SalaryChange{}
+	for _, field := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return change, fmt.Errorf("expected key=value, got: %s", field)
+		}
+
+		var err error
+		switch key {
+		case "month", "week", "period":
+			change.Period, err = parseChangePeriod(key, value, frequency)
+		default:
+			err = parseChangeValue(key, value, &change)
+		}
+		if err != nil {
+			return change, err
+		}
+	}
+
+	if change.Period == 0 {
+		return change, fmt.Errorf("missing period (e.g. month=7)")
+	}
+	if change.Income == 0 && change.TaxCode == "" {
+		return change, fmt.Errorf("nothing to change (set income or tax-code)")
+	}
+	return change, nil
+}
+
+// parseChangePeriod parses the period a change starts from. Months only match
+// monthly pay and weeks only weekly pay; a period matches any frequency
+func parseChangePeriod(key, value, frequency string) (int, error) {
+	if (key == "month" && frequency != tax.FrequencyMonthly) || (key == "week" && frequency != tax.FrequencyWeekly) {
+		return 0, fmt.Errorf("%s does not match %s pay (use period=N)", key, frequency)
+	}
+	period, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number: %s", key, value)
+	}
+	return period, nil
+}
+
+// parseChangeValue parses the new income or tax code of a change
+func parseChangeValue(key, value string, change *types.SalaryChange) error {
+	switch key {
+	case "income":
+		income, err := strconv.Atoi(value)
+		if err != nil || income <= 0 {
+			return fmt.Errorf("income must be greater than 0: %s", value)
+		}
+		change.Income = income
+	case "tax-code":
+		if err := validateTaxCode(value); err != nil {
+			return err
+		}
+		change.TaxCode = value
+	default:
+		return fmt.Errorf("unknown key: %s (must be one of: month, week, period, income, tax-code)", key)
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first value that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
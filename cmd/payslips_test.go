@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestParseChange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		spec      string
+		frequency string
+		want      types.SalaryChange
+		errorMsg  string
+	}{
+		{"month income", "month=7,income=90000", "monthly", types.SalaryChange{Period: 7, Income: 90000}, ""},
+		{"week tax code", "week=20, tax-code=1100L", "weekly", types.SalaryChange{Period: 20, TaxCode: "1100L"}, ""},
+		{"period any frequency", "period=3,income=40000", "four-weekly", types.SalaryChange{Period: 3, Income: 40000}, ""},
+		{"month with weekly pay", "month=7,income=90000", "weekly", types.SalaryChange{}, "month does not match weekly pay"},
+		{"week with monthly pay", "week=7,income=90000", "monthly", types.SalaryChange{}, "week does not match monthly pay"},
+		{"missing period", "income=90000", "monthly", types.SalaryChange{}, "missing period"},
+		{"nothing to change", "month=7", "monthly", types.SalaryChange{}, "nothing to change"},
+		{"invalid period", "month=July,income=1", "monthly", types.SalaryChange{}, "month must be a number: July"},
+		{"invalid income", "month=7,income=-5", "monthly", types.SalaryChange{}, "income must be greater than 0"},
+		{"invalid tax code", "month=7,tax-code=12Q", "monthly", types.SalaryChange{}, "invalid tax code: 12Q"},
+		{"unknown key", "month=7,bonus=500", "monthly", types.SalaryChange{}, "unknown key: bonus"},
+		{"not key value", "month=7,income", "monthly", types.SalaryChange{}, "expected key=value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseChange(tt.spec, tt.frequency)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseChanges_WrapsError(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseChanges([]string{"month=1,income=1", "month=x"}, "monthly")
+	assert.ErrorContains(t, err, "invalid --change 'month=x'")
+}
+
+func setPayslipsFlags(t *testing.T) {
+	t.Helper()
+
+	flagPayslipsIncome = 60000
+	flagPayslipsYear = "2025"
+	flagPayslipsRegion = ""
+	flagPayslipsPension = ""
+	flagPayslipsStudentLoan = ""
+	flagPayslipsTaxCode = ""
+	flagPayslipsFrequency = "monthly"
+	flagPayslipsChanges = nil
+	flagPayslipsPayday = ""
+	flagPayslipsJSON = false
+	t.Cleanup(func() {
+		flagPayslipsIncome = 0
+		flagPayslipsYear = ""
+		flagPayslipsTaxCode = ""
+		flagPayslipsFrequency = "monthly"
+		flagPayslipsChanges = nil
+		flagPayslipsPayday = ""
+		flagPayslipsJSON = false
+	})
+}
+
+func TestRunPayslips(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setPayslipsFlags(t)
+	flagPayslipsChanges = []string{"month=7,income=90000"}
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runPayslips(payslipsCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	assert.Contains(t, output, "Payslips for 2025 (uk) - Monthly - Tax Code 1257L")
+	assert.Contains(t, output, "Gross YTD")
+	assert.Contains(t, output, "£7,500.00")
+	assert.Contains(t, output, "£75,000.00")
+}
+
+func TestRunPayslips_JSON(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setPayslipsFlags(t)
+	flagPayslipsFrequency = "weekly"
+	flagPayslipsJSON = true
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runPayslips(payslipsCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	var slips []types.Payslip
+	require.NoError(t, json.Unmarshal([]byte(output), &slips))
+	require.Len(t, slips, 52)
+	assert.InDelta(t, 11432, slips[51].TaxPaidToDate, 0.01)
+}
+
+func TestRunPayslips_Week53(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setPayslipsFlags(t)
+	flagPayslipsFrequency = "weekly"
+	flagPayslipsPayday = "2026-04-05"
+	flagPayslipsJSON = true
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runPayslips(payslipsCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	var slips []types.Payslip
+	require.NoError(t, json.Unmarshal([]byte(output), &slips))
+	require.Len(t, slips, 53)
+	assert.Equal(t, 53, slips[52].Period)
+}
+
+func TestRunPayslips_Errors(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	tests := []struct {
+		name     string
+		setup    func()
+		errorMsg string
+	}{
+		{"missing income", func() { flagPayslipsIncome = 0 }, "income must be greater than 0"},
+		{"invalid frequency", func() { flagPayslipsFrequency = "daily" }, "invalid frequency: daily"},
+		{"invalid change", func() { flagPayslipsChanges = []string{"month=7"} }, "invalid --change"},
+		{"change outside year", func() { flagPayslipsChanges = []string{"month=13,income=1"} }, "change period 13 is outside 1-12"},
+		{"invalid tax code", func() { flagPayslipsTaxCode = "1257" }, "invalid tax code: 1257"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPayslipsFlags(t)
+			tt.setup()
+
+			err := runPayslips(payslipsCmd, []string{})
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
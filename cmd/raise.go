@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+var (
+	flagRaiseFrom          int
+	flagRaiseTo            int
+	flagRaisePercent       float64
+	flagRaiseYear          string
+	flagRaiseRegion        string
+	flagRaisePension       string
+	flagRaisePensionScheme string
+	flagRaiseStudentLoan   string
+	flagRaiseTaxCode       string
+	flagRaisePeriod        string
+	flagRaiseJSON          bool
+)
+
+var raiseCmd = &cobra.Command{
+	Use:   "raise",
+	Short: "Analyse how much of a pay rise reaches take-home pay",
+	Long: `Analyse a pay rise from one salary to another (--to) or by a percentage
+(--percent).
+
+The salary before and after the raise are calculated as for 'check', then
+compared: how much of the raise reaches net pay, the effective marginal rate
+of tax, National Insurance and student loan across the gap, and the extra
+pension contribution that would keep take-home pay unchanged. The extra
+contribution is worked out under --pension-scheme, or salary sacrifice if
+none is given.
+
+Warnings are shown when the raise crosses a threshold, such as the higher
+rate band, the £100,000 personal allowance taper or the student loan
+threshold.`,
+	Example: `  listentotaxman raise --from 62000 --to 68000
+  listentotaxman raise --from 95000 --percent 10 --student-loan plan2
+  listentotaxman raise --from 48000 --to 52000 --pension 5% --pension-scheme sacrifice --period monthly`,
+	RunE: runRaise,
+}
+
+func init() {
+	rootCmd.AddCommand(raiseCmd)
+
+	raiseCmd.Flags().IntVar(&flagRaiseFrom, "from", 0, "Gross annual salary before the raise (default: config income)")
+	raiseCmd.Flags().IntVar(&flagRaiseTo, "to", 0, "Gross annual salary after the raise")
+	raiseCmd.Flags().Float64Var(&flagRaisePercent, "percent", 0, "Raise as a percentage of --from (instead of --to)")
+	raiseCmd.Flags().StringVar(&flagRaiseYear, "year", "", "Tax year (defaults to current tax year)")
+	raiseCmd.Flags().StringVar(&flagRaiseRegion, "region", "", "Tax region (default: uk)")
+	raiseCmd.Flags().StringVar(&flagRaisePension, "pension", "", "Pension contribution (e.g., 3% or 3000)")
+	raiseCmd.Flags().StringVar(&flagRaisePensionScheme, "pension-scheme", "", "Pension scheme (sacrifice, net-pay, relief-at-source), calculated locally")
+	raiseCmd.Flags().StringVar(&flagRaiseStudentLoan, "student-loan", "", "Student loan plan (e.g., plan1, plan2, plan4, postgraduate, scottish)")
+	raiseCmd.Flags().StringVar(&flagRaiseTaxCode, "tax-code", "", "Tax code (e.g., 1257L, K12)")
+	raiseCmd.Flags().StringVar(&flagRaisePeriod, "period", "", "Display period (yearly, quarterly, monthly, four-weekly, fortnightly, weekly, daily, hourly) (default: yearly)")
+	raiseCmd.Flags().BoolVar(&flagRaiseJSON, "json", false, "Output as JSON")
+}
+
+func runRaise(_ *cobra.Command, _ []string) error {
+	// Load config file
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	before, after, err := buildRaiseRequests(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Period: flag > config > default yearly
+	period := firstNonEmpty(flagRaisePeriod, cfg.Defaults.Period, payperiod.Yearly)
+	if err := payperiod.Validate(period); err != nil {
+		return err
+	}
+
+	beforeResp, err := calculateCheck(before)
+	if err != nil {
+		return fmt.Errorf("failed to calculate tax before the raise: %w", err)
+	}
+	afterResp, err := calculateCheck(after)
+	if err != nil {
+		return fmt.Errorf("failed to calculate tax after the raise: %w", err)
+	}
+
+	rise, err := tax.PayRise(after, beforeResp, afterResp)
+	if err != nil {
+		return fmt.Errorf("failed to analyse raise: %w", err)
+	}
+
+	if flagRaiseJSON {
+		jsonData, err := json.MarshalIndent(adjustPayRiseForPeriod(rise, period), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	display.PayRise(rise, period)
+	return nil
+}
+
+// buildRaiseRequests builds and validates the requests for the salary before
+// and after the raise from flags and config
+func buildRaiseRequests(cfg *config.Config) (before, after *types.TaxRequest, err error) {
+	from := flagRaiseFrom
+	if from == 0 {
+		from = cfg.Defaults.Income
+	}
+
+	to := flagRaiseTo
+	switch {
+	case from <= 0:
+		return nil, nil, fmt.Errorf("--from must be greater than 0")
+	case flagRaiseTo != 0 && flagRaisePercent != 0:
+		return nil, nil, fmt.Errorf("--to cannot be used with --percent")
+	case flagRaisePercent < 0:
+		return nil, nil, fmt.Errorf("--percent must be greater than 0")
+	case flagRaisePercent > 0:
+		to = int(math.Round(float64(from) * (1 + flagRaisePercent/100)))
+	case flagRaiseTo == 0:
+		return nil, nil, fmt.Errorf("--to or --percent is required")
+	}
+	if to <= from {
+		return nil, nil, fmt.Errorf("--to must be more than --from, got: %d", to)
+	}
+
+	before = &types.TaxRequest{
+		Year:          firstNonEmpty(flagRaiseYear, cfg.Defaults.Year, getDefaultYear()),
+		TaxRegion:     normalizeRegion(firstNonEmpty(flagRaiseRegion, cfg.Defaults.Region, "uk")),
+		Age:           firstNonEmpty(cfg.Defaults.Age, "0"),
+		Pension:       firstNonEmpty(flagRaisePension, cfg.Defaults.Pension),
+		PensionScheme: firstNonEmpty(flagRaisePensionScheme, cfg.Defaults.PensionScheme),
+		Plan:          firstNonEmpty(flagRaiseStudentLoan, cfg.Defaults.StudentLoan),
+		TaxCode:       firstNonEmpty(flagRaiseTaxCode, cfg.Defaults.TaxCode),
+		GrossWage:     from,
+	}
+	if err := validateCheckRequest(before); err != nil {
+		return nil, nil, err
+	}
+
+	raised := *before
+	raised.GrossWage = to
+	return before, &raised, nil
+}
+
+// adjustPayRiseForPeriod divides the raise's money figures into the display period
+func adjustPayRiseForPeriod(rise *types.PayRise, period string) *types.PayRise {
+	divisor := payperiod.Divisor(period, types.WorkPattern{})
+
+	adjusted := *rise
+	adjusted.From /= divisor
+	adjusted.To /= divisor
+	adjusted.Raise /= divisor
+	adjusted.NetIncrease /= divisor
+	adjusted.ExtraPension /= divisor
+	adjusted.Before = adjustResponseForPeriod(rise.Before, period, types.WorkPattern{})
+	adjusted.After = adjustResponseForPeriod(rise.After, period, types.WorkPattern{})
+	return &adjusted
+}
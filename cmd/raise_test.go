@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func setRaiseFlags(t *testing.T) {
+	t.Helper()
+
+	flagRaiseFrom = 48000
+	flagRaiseTo = 52000
+	flagRaisePercent = 0
+	flagRaiseYear = "2025"
+	flagRaiseRegion = ""
+	flagRaisePension = "0"
+	flagRaisePensionScheme = "sacrifice"
+	flagRaiseStudentLoan = "plan2"
+	flagRaiseTaxCode = ""
+	flagRaisePeriod = "yearly"
+	flagRaiseJSON = false
+	t.Cleanup(func() {
+		flagRaiseFrom = 0
+		flagRaiseTo = 0
+		flagRaisePercent = 0
+		flagRaiseYear = ""
+		flagRaiseRegion = ""
+		flagRaisePension = ""
+		flagRaisePensionScheme = ""
+		flagRaiseStudentLoan = ""
+		flagRaiseTaxCode = ""
+		flagRaisePeriod = ""
+		flagRaiseJSON = false
+	})
+}
+
+func TestRunRaise(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setRaiseFlags(t)
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runRaise(raiseCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	assert.Contains(t, output, "Pay Rise for 2025 - Yearly")
+	assert.Contains(t, output, "║ Gross Salary         ║   £48,000.00 ║   £52,000.00 ║    £4,000.00 ║")
+	assert.Contains(t, output, "Of the £4,000.00 raise, £2,277.80 (56.95%) reaches net pay.")
+	assert.Contains(t, output, "Effective marginal rate: 43.05% (tax, NI and student loan)")
+	assert.Contains(t, output, "⚠ Crosses the Higher Rate Band at £50,270")
+}
+
+func TestRunRaise_JSON(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setRaiseFlags(t)
+	flagRaiseTo = 0
+	flagRaisePercent = 10
+	flagRaisePeriod = "monthly"
+	flagRaiseJSON = true
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runRaise(raiseCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	var rise types.PayRise
+	require.NoError(t, json.Unmarshal([]byte(output), &rise))
+	assert.InDelta(t, 4000.0, rise.From, 0.001)
+	assert.InDelta(t, 4400.0, rise.To, 0.001)
+	assert.InDelta(t, 400.0, rise.Raise, 0.001)
+	assert.InDelta(t, 4400.0, rise.After.GrossPay, 0.001)
+	assert.InDelta(t, 400.0, rise.ExtraPension, 0.01)
+}
+
+func TestBuildRaiseRequests(t *testing.T) {
+	setRaiseFlags(t)
+	flagRaiseTo = 0
+	flagRaisePercent = 5
+
+	before, after, err := buildRaiseRequests(&config.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, 48000, before.GrossWage)
+	assert.Equal(t, 50400, after.GrossWage)
+	assert.Equal(t, "uk", after.TaxRegion)
+	assert.Equal(t, "plan2", after.Plan)
+
+	// --from defaults to the configured income
+	flagRaiseFrom = 0
+	before, _, err = buildRaiseRequests(&config.Config{Defaults: config.Defaults{Income: 60000}})
+	require.NoError(t, err)
+	assert.Equal(t, 60000, before.GrossWage)
+}
+
+func TestBuildRaiseRequests_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    int
+		to      int
+		percent float64
+		want    string
+	}{
+		{"missing from", 0, 52000, 0, "--from must be greater than 0"},
+		{"to and percent", 48000, 52000, 5, "--to cannot be used with --percent"},
+		{"negative percent", 48000, 0, -5, "--percent must be greater than 0"},
+		{"missing to", 48000, 0, 0, "--to or --percent is required"},
+		{"pay cut", 48000, 45000, 0, "--to must be more than --from, got: 45000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRaiseFlags(t)
+			flagRaiseFrom = tt.from
+			flagRaiseTo = tt.to
+			flagRaisePercent = tt.percent
+
+			_, _, err := buildRaiseRequests(&config.Config{})
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
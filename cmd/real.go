@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mheap/listentotaxman-cli/internal/inflation"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// loadCPI loads the CPI index from a CSV file, or the embedded index if no file is given
+func loadCPI(path string) (inflation.Index, error) {
+	if path == "" {
+		return inflation.Embedded(), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CPI file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	index, err := inflation.ReadCSV(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CPI file: %w", err)
+	}
+	return index, nil
+}
+
+// applyRealTerms sets each response's gross and net pay in the base year's
+// prices. Each response is in the prices of the tax year alongside it, and the
+// base year defaults to the latest of them.
+func applyRealTerms(index inflation.Index, baseYear string, years []string, responses []*types.TaxResponse) error {
+	base := 0
+	for _, year := range years {
+		y, err := strconv.Atoi(year)
+		if err != nil {
+			return fmt.Errorf("year must be a valid number: %s", year)
+		}
+		base = max(base, y)
+	}
+	if baseYear != "" {
+		y, err := strconv.Atoi(baseYear)
+		if err != nil {
+			return fmt.Errorf("--base-year must be a valid number: %s", baseYear)
+		}
+		base = y
+	}
+
+	for i, resp := range responses {
+		year, _ := strconv.Atoi(years[i])
+		factor, note, err := index.Factor(year, base)
+		if err != nil {
+			return fmt.Errorf("real terms: %w; give a newer index with --cpi-file or choose --base-year", err)
+		}
+		resp.Real = &types.RealTerms{
+			BaseYear: base,
+			Factor:   factor,
+			GrossPay: resp.GrossPay * factor,
+			NetPay:   resp.NetPay * factor,
+			Note:     note,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/inflation"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestLoadCPI(t *testing.T) {
+	t.Parallel()
+
+	index, err := loadCPI("")
+	require.NoError(t, err)
+	assert.Equal(t, inflation.Embedded(), index)
+
+	path := filepath.Join(t.TempDir(), "cpi.csv")
+	require.NoError(t, os.WriteFile(path, []byte("year,cpi\n2025,138.0\n"), 0600))
+	index, err = loadCPI(path)
+	require.NoError(t, err)
+	assert.Equal(t, inflation.Index{2025: 138.0}, index)
+
+	_, err = loadCPI(filepath.Join(t.TempDir(), "missing.csv"))
+	assert.ErrorContains(t, err, "failed to open CPI file")
+
+	require.NoError(t, os.WriteFile(path, []byte("year,cpi\n"), 0600))
+	_, err = loadCPI(path)
+	assert.EqualError(t, err, "failed to read CPI file: no CPI values found")
+}
+
+func TestApplyRealTerms(t *testing.T) {
+	t.Parallel()
+
+	index := inflation.Index{2023: 130.5, 2024: 133.9}
+	responses := []*types.TaxResponse{
+		testutil.CreateSampleTaxResponse(),
+		testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.GrossPay = 53000
+			r.NetPay = 40500
+		}),
+	}
+
+	// The base year defaults to the latest year
+	require.NoError(t, applyRealTerms(index, "", []string{"2023", "2024"}, responses))
+	assert.Equal(t, 2024, responses[0].Real.BaseYear)
+	assert.InDelta(t, 51302.68, responses[0].Real.GrossPay, 0.01)
+	assert.InDelta(t, 39293.58, responses[0].Real.NetPay, 0.01)
+	assert.Equal(t, &types.RealTerms{BaseYear: 2024, Factor: 1, GrossPay: 53000, NetPay: 40500}, responses[1].Real)
+
+	// An earlier base year deflates later pay
+	require.NoError(t, applyRealTerms(index, "2023", []string{"2023", "2024"}, responses))
+	assert.InDelta(t, 50000.0, responses[0].Real.GrossPay, 0.01)
+	assert.InDelta(t, 51654.22, responses[1].Real.GrossPay, 0.01)
+}
+
+func TestApplyRealTerms_Errors(t *testing.T) {
+	t.Parallel()
+
+	index := inflation.Index{2023: 130.5, 2024: 133.9}
+	responses := []*types.TaxResponse{testutil.CreateSampleTaxResponse()}
+
+	err := applyRealTerms(index, "last", []string{"2024"}, responses)
+	assert.EqualError(t, err, "--base-year must be a valid number: last")
+
+	err = applyRealTerms(index, "", []string{"2022"}, responses)
+	assert.EqualError(t, err, "real terms: no CPI value for 2022 (the index covers 2023-2024); give a newer index with --cpi-file or choose --base-year")
+}
+
+func TestApplyRealTerms_LatestCPI(t *testing.T) {
+	t.Parallel()
+
+	// A year after the index ends uses the latest value, with a note
+	index := inflation.Index{2023: 130.5, 2024: 133.9}
+	responses := []*types.TaxResponse{testutil.CreateSampleTaxResponse(), testutil.CreateSampleTaxResponse()}
+	require.NoError(t, applyRealTerms(index, "", []string{"2024", "2025"}, responses))
+	assert.InDelta(t, 1.0, responses[0].Real.Factor, 0.00001)
+	assert.Equal(t, "no CPI value for 2025, so 2024's is used", responses[0].Real.Note)
+}
+
+func TestEmbeddedCPI_CoversSupportedYears(t *testing.T) {
+	t.Parallel()
+
+	index := inflation.Embedded()
+	for _, year := range tax.SupportedYears() {
+		_, ok := index[year]
+		assert.True(t, ok, "no CPI value for tax year %d", year)
+	}
+}
+
+func TestApplyCompareRealTerms(t *testing.T) {
+	t.Parallel()
+
+	results := []types.ComparisonResult{
+		{
+			Label:    "Last Year",
+			Request:  testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) { r.Year = "2023" }),
+			Response: testutil.CreateSampleTaxResponse(),
+		},
+		{
+			Label:    "This Year",
+			Request:  testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) { r.Year = "2024" }),
+			Response: testutil.CreateSampleTaxResponse(),
+		},
+	}
+
+	require.NoError(t, applyCompareRealTerms(map[string]string{}, results))
+	assert.Nil(t, results[0].Response.Real)
+
+	err := applyCompareRealTerms(map[string]string{"cpi-file": "cpi.csv"}, results)
+	assert.EqualError(t, err, "--cpi-file requires --real")
+
+	err = applyCompareRealTerms(map[string]string{"base-year": "2023"}, results)
+	assert.EqualError(t, err, "--base-year requires --real")
+
+	require.NoError(t, applyCompareRealTerms(map[string]string{"real": flagValueTrue}, results))
+	assert.Equal(t, 2024, results[0].Response.Real.BaseYear)
+	assert.InDelta(t, 51302.68, results[0].Response.Real.GrossPay, 0.01)
+	assert.InDelta(t, 1.0, results[1].Response.Real.Factor, 0.00001)
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+var (
+	flagReconcilePayslips    string
+	flagReconcileFrequency   string
+	flagReconcileRegion      string
+	flagReconcilePension     string
+	flagReconcileStudentLoan string
+	flagReconcileTaxCode     string
+	flagReconcileNoNI        bool
+	flagReconcileTolerance   float64
+	flagReconcileJSON        bool
+)
+
+// payslipDateFormats are the accepted formats for the date column
+var payslipDateFormats = []string{"2006-01-02", "02/01/2006"}
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile --payslips FILE",
+	Short: "Check real payslips against the expected deductions",
+	Long: `Compare real payslips with the tax, National Insurance, student loan and
+pension expected for the same gross pay, and flag differences above a tolerance.
+
+The CSV file needs a header row with the columns date and gross, and any of
+tax, ni, student_loan and pension. Dates are YYYY-MM-DD or DD/MM/YYYY, and
+amounts may include a £ sign and commas. All payslips must be in one tax year.
+
+When deductions differ, likely causes are suggested, such as a wrong tax code,
+an emergency W1/M1 basis that was never reset, a missing NI category or the
+wrong student loan plan.`,
+	Example: `  listentotaxman reconcile --payslips payslips.csv
+  listentotaxman reconcile --payslips payslips.csv --tax-code 1257L --student-loan plan2
+  listentotaxman reconcile --payslips payslips.csv --frequency weekly --tolerance 0.50`,
+	RunE: runReconcile,
+}
+
+func init() {
+	rootCmd.AddCommand(reconcileCmd)
+
+	reconcileCmd.Flags().StringVar(&flagReconcilePayslips, "payslips", "", "CSV file of payslips (required)")
+	reconcileCmd.Flags().StringVar(&flagReconcileFrequency, "frequency", tax.FrequencyMonthly, "Pay frequency (weekly, fortnightly, four-weekly, monthly)")
+	reconcileCmd.Flags().StringVar(&flagReconcileRegion, "region", "", "Tax region (default: uk)")
+	reconcileCmd.Flags().StringVar(&flagReconcilePension, "pension", "", "Expected pension contribution (default: pension on each payslip)")
+	reconcileCmd.Flags().StringVar(&flagReconcileStudentLoan, "student-loan", "", "Student loan plan (e.g., plan1, plan2, plan4, postgraduate, scottish)")
+	reconcileCmd.Flags().StringVar(&flagReconcileTaxCode, "tax-code", "", "Expected tax code (default: standard code for the year)")
+	reconcileCmd.Flags().BoolVar(&flagReconcileNoNI, "no-ni", false, "Exempt from National Insurance")
+	reconcileCmd.Flags().Float64Var(&flagReconcileTolerance, "tolerance", 1.00, "Largest difference in pounds that is not flagged")
+	reconcileCmd.Flags().BoolVar(&flagReconcileJSON, "json", false, "Output as JSON")
+	_ = reconcileCmd.MarkFlagRequired("payslips")
+}
+
+func runReconcile(cmd *cobra.Command, _ []string) error {
+	// Load config file
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if flagReconcileTolerance < 0 {
+		return fmt.Errorf("--tolerance cannot be negative")
+	}
+
+	file, err := os.Open(flagReconcilePayslips)
+	if err != nil {
+		return fmt.Errorf("failed to open payslips: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	year, payslips, missing, err := readPayslipsCSV(file, flagReconcileFrequency)
+	if err != nil {
+		return fmt.Errorf("failed to read payslips: %w", err)
+	}
+
+	req, err := buildReconcileRequest(cmd, cfg, year)
+	if err != nil {
+		return err
+	}
+
+	rec, err := tax.Reconcile(req, flagReconcileFrequency, payslips, flagReconcileTolerance, missing)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile payslips: %w", err)
+	}
+
+	if flagReconcileJSON {
+		jsonData, err := json.MarshalIndent(rec, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	display.Reconciliation(rec)
+	return nil
+}
+
+// buildReconcileRequest builds and validates a TaxRequest from reconcile flags and config
+func buildReconcileRequest(cmd *cobra.Command, cfg *config.Config, year int) (*types.TaxRequest, error) {
+	req := &types.TaxRequest{
+		Year:      strconv.Itoa(year),
+		TaxRegion: normalizeRegion(firstNonEmpty(flagReconcileRegion, cfg.Defaults.Region, "uk")),
+		Pension:   flagReconcilePension,
+		Plan:      firstNonEmpty(flagReconcileStudentLoan, cfg.Defaults.StudentLoan),
+		TaxCode:   firstNonEmpty(flagReconcileTaxCode, cfg.Defaults.TaxCode),
+	}
+
+	// No NI: flag > config > default false
+	if (cmd.Flags().Changed("no-ni") && flagReconcileNoNI) || (!cmd.Flags().Changed("no-ni") && cfg.Defaults.NoNI) {
+		req.ExNI = "y"
+	}
+
+	if req.Plan != "" {
+		if err := validateStudentLoanPlan(req.Plan); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateTaxCode(req.TaxCode); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// payslipDeductionColumns are the optional deduction columns and the deduction each holds
+var payslipDeductionColumns = []struct {
+	column string
+	field  string
+}{
+	{"tax", tax.FieldTax},
+	{"ni", tax.FieldNI},
+	{"student_loan", tax.FieldStudentLoan},
+	{"pension", tax.FieldPension},
+}
+
+// readPayslipsCSV reads payslips from CSV and returns the tax year they fall
+// in and the deductions that have no column, which are not compared
+func readPayslipsCSV(r io.Reader, frequency string) (int, []types.Payslip, []string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("missing header row: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"date", "gross"} {
+		if _, ok := columns[required]; !ok {
+			return 0, nil, nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+	var missing []string
+	for _, deduction := range payslipDeductionColumns {
+		if _, ok := columns[deduction.column]; !ok {
+			missing = append(missing, deduction.field)
+		}
+	}
+
+	year := 0
+	payslips := []types.Payslip{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, nil, nil, err
+		}
+
+		slip, slipYear, err := parsePayslipRecord(record, columns, frequency, len(missing) == 0)
+		if err != nil {
+			return 0, nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if year != 0 && slipYear != year {
+			return 0, nil, nil, fmt.Errorf("line %d: payslips span more than one tax year (%d and %d)", line, year, slipYear)
+		}
+		year = slipYear
+		payslips = append(payslips, slip)
+	}
+
+	if len(payslips) == 0 {
+		return 0, nil, nil, fmt.Errorf("no payslips found")
+	}
+	return year, payslips, missing, nil
+}
+
+// parsePayslipRecord parses one CSV row into a payslip and its tax year. Net
+// pay is only worked out when every deduction has a column
+func parsePayslipRecord(record []string, columns map[string]int, frequency string, allDeductions bool) (types.Payslip, int, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	date, err := parsePayslipDate(field("date"))
+	if err != nil {
+		return types.Payslip{}, 0, err
+	}
+	year, period, err := tax.PeriodForDate(date, frequency)
+	if err != nil {
+		return types.Payslip{}, 0, err
+	}
+
+	slip := types.Payslip{Period: period, Date: date.Format("2006-01-02")}
+	amounts := []struct {
+		name   string
+		target *float64
+	}{
+		{"gross", &slip.GrossPay},
+		{"tax", &slip.TaxPaid},
+		{"ni", &slip.NationalInsurance},
+		{"student_loan", &slip.StudentLoanRepayment},
+		{"pension", &slip.Pension},
+	}
+	for _, amount := range amounts {
+		if *amount.target, err = parseAmount(field(amount.name)); err != nil {
+			return types.Payslip{}, 0, fmt.Errorf("invalid %s: %w", amount.name, err)
+		}
+	}
+	if allDeductions {
+		slip.NetPay = slip.GrossPay - slip.TaxPaid - slip.NationalInsurance - slip.StudentLoanRepayment - slip.Pension
+	}
+
+	return slip, year, nil
+}
+
+// parsePayslipDate parses a payment date in any of the accepted formats
+func parsePayslipDate(value string) (time.Time, error) {
+	for _, format := range payslipDateFormats {
+		if date, err := time.Parse(format, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %s (use YYYY-MM-DD or DD/MM/YYYY)", value)
+}
+
+// parseAmount parses an amount that may include a £ sign and commas. Empty is zero.
+func parseAmount(value string) (float64, error) {
+	cleaned := strings.NewReplacer("£", "", ",", "").Replace(value)
+	if cleaned == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(cleaned, 64)
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// matchingPayslipsCSV is three months on £60,000 with 1257L, as payroll works them out
+const matchingPayslipsCSV = `Date,Gross,Tax,NI,Student_Loan,Pension
+2025-04-25,"£5,000.00",£952.67,£267.55,,
+2025-05-23,5000,952.66,267.55,0,0
+25/06/2025,5000.00,952.67,267.55,0,0
+`
+
+func TestReadPayslipsCSV(t *testing.T) {
+	t.Parallel()
+
+	year, slips, missing, err := readPayslipsCSV(strings.NewReader(matchingPayslipsCSV), "monthly")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2025, year)
+	assert.Empty(t, missing)
+	require.Len(t, slips, 3)
+	assert.Equal(t, 1, slips[0].Period)
+	assert.Equal(t, "2025-04-25", slips[0].Date)
+	assert.InDelta(t, 5000, slips[0].GrossPay, 0.001)
+	assert.InDelta(t, 952.67, slips[0].TaxPaid, 0.001)
+	assert.InDelta(t, 267.55, slips[0].NationalInsurance, 0.001)
+	assert.Zero(t, slips[0].StudentLoanRepayment)
+	assert.InDelta(t, 3779.78, slips[0].NetPay, 0.001)
+	assert.Equal(t, 3, slips[2].Period)
+	assert.Equal(t, "2025-06-25", slips[2].Date)
+}
+
+func TestReadPayslipsCSV_MissingColumns(t *testing.T) {
+	t.Parallel()
+
+	_, slips, missing, err := readPayslipsCSV(strings.NewReader("date,gross,tax\n2025-04-25,5000,952.67\n"), "monthly")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"NI", "Student Loan", "Pension"}, missing)
+	require.Len(t, slips, 1)
+	// Net pay can't be worked out without every deduction
+	assert.Zero(t, slips[0].NetPay)
+}
+
+func TestReadPayslipsCSV_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		csv      string
+		errorMsg string
+	}{
+		{"empty", "", "missing header row"},
+		{"missing gross", "date,tax\n2025-04-25,100\n", "missing gross column"},
+		{"no rows", "date,gross\n", "no payslips found"},
+		{"invalid date", "date,gross\n25 April,5000\n", "line 2: invalid date: 25 April"},
+		{"invalid amount", "date,gross,tax\n2025-04-25,5000,abc\n", "line 2: invalid tax"},
+		{"two tax years", "date,gross\n2025-03-25,5000\n2025-04-25,5000\n", "line 3: payslips span more than one tax year (2024 and 2025)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, _, err := readPayslipsCSV(strings.NewReader(tt.csv), "monthly")
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]float64{
+		"":           0,
+		"1234.56":    1234.56,
+		"£1,234.56":  1234.56,
+		"-12.00":     -12,
+		" £0.50 ":    0.5,
+		"£1,000,000": 1000000,
+	}
+
+	for value, want := range tests {
+		got, err := parseAmount(strings.TrimSpace(value))
+		require.NoError(t, err, value)
+		assert.InDelta(t, want, got, 0.001, value)
+	}
+
+	_, err := parseAmount("12p")
+	assert.Error(t, err)
+}
+
+func setReconcileFlags(t *testing.T, csv string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "payslips.csv")
+	require.NoError(t, os.WriteFile(path, []byte(csv), 0o600))
+
+	flagReconcilePayslips = path
+	flagReconcileFrequency = "monthly"
+	flagReconcileRegion = ""
+	flagReconcilePension = ""
+	flagReconcileStudentLoan = ""
+	flagReconcileTaxCode = ""
+	flagReconcileTolerance = 1
+	flagReconcileJSON = false
+	t.Cleanup(func() {
+		flagReconcilePayslips = ""
+		flagReconcileFrequency = "monthly"
+		flagReconcileStudentLoan = ""
+		flagReconcileTaxCode = ""
+		flagReconcileTolerance = 1
+		flagReconcileJSON = false
+	})
+}
+
+func TestRunReconcile(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setReconcileFlags(t, matchingPayslipsCSV)
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runReconcile(reconcileCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	assert.Contains(t, output, "Payslip Reconciliation for 2025 (Monthly) - Tax Code 1257L")
+	assert.Contains(t, output, "2025-06-25")
+	assert.Contains(t, output, "✓")
+	assert.Contains(t, output, "All 3 payslips match the expected deductions within £1.00")
+}
+
+func TestRunReconcile_JSON(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	// Tax deducted at the basic rate on everything
+	setReconcileFlags(t, "date,gross,tax,ni\n2025-04-25,5000,1000,267.55\n2025-05-23,5000,1000,267.55\n")
+	flagReconcileJSON = true
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runReconcile(reconcileCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	var rec types.Reconciliation
+	require.NoError(t, json.Unmarshal([]byte(output), &rec))
+	require.Len(t, rec.Payslips, 2)
+	require.Len(t, rec.Payslips[0].Differences, 1)
+	assert.Equal(t, "Tax", rec.Payslips[0].Differences[0].Field)
+	assert.InDelta(t, 952.67, rec.Payslips[0].Differences[0].Expected, 0.01)
+	require.Len(t, rec.Findings, 1)
+	assert.Contains(t, rec.Findings[0], "Tax matches tax code BR rather than 1257L")
+}
+
+func TestRunReconcile_NoNIColumn(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	setReconcileFlags(t, "date,gross,tax\n2025-04-25,5000,952.67\n")
+	flagReconcileJSON = true
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runReconcile(reconcileCmd, []string{})
+		require.NoError(t, err)
+	})
+
+	var rec types.Reconciliation
+	require.NoError(t, json.Unmarshal([]byte(output), &rec))
+	require.Len(t, rec.Payslips, 1)
+	// The NI that payroll never reported isn't flagged as none deducted
+	assert.Empty(t, rec.Payslips[0].Differences)
+	assert.Contains(t, rec.Findings, "NI was not checked, as the payslips have no column for it")
+	assert.NotContains(t, strings.Join(rec.Findings, "\n"), "No National Insurance was deducted")
+}
+
+func TestRunReconcile_Errors(t *testing.T) {
+	testutil.SetupViperTest(t)
+	configPath := testutil.CreateTempConfigFile(t, testutil.PartialConfigYAML)
+	t.Setenv("LISTENTOTAXMAN_CONFIG", configPath)
+
+	tests := []struct {
+		name     string
+		setup    func()
+		errorMsg string
+	}{
+		{"missing file", func() { flagReconcilePayslips = filepath.Join(t.TempDir(), "missing.csv") }, "failed to open payslips"},
+		{"invalid frequency", func() { flagReconcileFrequency = "daily" }, "invalid frequency: daily"},
+		{"negative tolerance", func() { flagReconcileTolerance = -1 }, "--tolerance cannot be negative"},
+		{"invalid tax code", func() { flagReconcileTaxCode = "1257" }, "invalid tax code: 1257"},
+		{"invalid student loan", func() { flagReconcileStudentLoan = "plan9" }, "invalid student loan plan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setReconcileFlags(t, matchingPayslipsCSV)
+			tt.setup()
+
+			err := runReconcile(reconcileCmd, []string{})
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// serveCacheSize is the most calculations the server keeps between requests
+const serveCacheSize = 1000
+
+// serveMaxBodyBytes is the largest request body the server reads
+const serveMaxBodyBytes = 1 << 20
+
+// serveQueryParams are the query parameters that apply to a whole check or
+// compare request, as the compare command's global flags do
+var serveQueryParams = []string{"period", "payday", "hours-per-week", "days-per-week", "holiday-days"}
+
+var flagServeAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve tax calculations over a local HTTP API",
+	Long: `Serve the check, compare and rates calculations as a JSON HTTP API, for
+dashboards and scripts that would otherwise parse the CLI output.
+
+Endpoints:
+  POST /v1/check     Calculate one salary
+  POST /v1/compare   Compare 2-4 options
+  GET  /v1/rates     The tax rates for a year (?year=2025)
+
+The check body is a JSON object with the same fields as a batch row: income
+(or rate and per), year, region, age, pension, pension-scheme,
+employer-pension, student-loan, tax-code, extra, married, blind, no-ni,
+partner-income, fte, pro-rata-days, dividends, savings-interest and
+rental-profit. Missing fields fall back to the config file. The compare body
+is {"options": [...]} where each option is a check body with a label.
+
+The period, payday, hours-per-week, days-per-week and holiday-days are query
+parameters, e.g. POST /v1/check?period=monthly. Responses are the same JSON
+as check --json and compare --json; errors are {"error": "..."} with a 400
+status for invalid requests.
+
+Identical calculations are cached for the life of the server. The server
+listens on localhost by default; it has no authentication, so only listen
+on other addresses behind something that has.`,
+	Example: `  listentotaxman serve
+  listentotaxman serve --addr :8080
+  curl -X POST localhost:8080/v1/check?period=monthly -d '{"income": 50000}'`,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&flagServeAddr, "addr", "localhost:8080", "Address to listen on")
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	// Load config file
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	server := &http.Server{
+		Addr:              flagServeAddr,
+		Handler:           newServeHandler(cfg),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+	}
+
+	// Stop accepting requests on Ctrl-C, letting those in progress finish
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Listening on %s\n", flagServeAddr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+// newServeHandler returns the API's routes, sharing one calculation cache
+func newServeHandler(cfg *config.Config) http.Handler {
+	cache := newCalculationCache(serveCacheSize)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/check", func(w http.ResponseWriter, r *http.Request) {
+		serveCheck(w, r, cfg, cache)
+	})
+	mux.HandleFunc("POST /v1/compare", func(w http.ResponseWriter, r *http.Request) {
+		serveCompare(w, r, cfg, cache)
+	})
+	mux.HandleFunc("GET /v1/rates", func(w http.ResponseWriter, r *http.Request) {
+		serveRates(w, r, cfg)
+	})
+	return mux
+}
+
+// serveCheck calculates one salary
+func serveCheck(w http.ResponseWriter, r *http.Request, cfg *config.Config, cache *calculationCache) {
+	var object map[string]interface{}
+	if err := decodeServeBody(w, r, &object); err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	globals := serveGlobals(r)
+	period, err := getComparePeriod(globals, cfg)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	values, err := batchJSONValues(object)
+	if err == nil {
+		values, err = batchFields(values)
+	}
+	var req *types.TaxRequest
+	if err == nil {
+		req, err = buildCheckRequestFromFields(values, cfg)
+	}
+	if err == nil {
+		err = prepareServeOptions(globals, cfg, period, []ComparisonOption{{Request: req}})
+	}
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := calculateCached(req, period, cache)
+	if err == nil {
+		err = serveFullTime(req, resp, period, cache)
+	}
+	if err != nil {
+		writeServeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeServeJSON(w, http.StatusOK, resp)
+}
+
+// serveFullTime adds the full-time figures to a pro-rata response, divided
+// into the period, as check --json does
+func serveFullTime(req *types.TaxRequest, resp *types.TaxResponse, period string, cache *calculationCache) error {
+	if req.ProRata == nil {
+		return nil
+	}
+	if err := calculateFullTime(req, resp, cache.calculate); err != nil {
+		return fmt.Errorf("failed to calculate full-time tax: %w", err)
+	}
+	resp.FullTime = adjustResponseForPeriod(resp.FullTime, period, req.ProRata.FullTimePattern)
+	return nil
+}
+
+// serveCompare compares 2-4 options
+func serveCompare(w http.ResponseWriter, r *http.Request, cfg *config.Config, cache *calculationCache) {
+	var body struct {
+		Options []map[string]interface{} `json:"options"`
+	}
+	if err := decodeServeBody(w, r, &body); err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	globals := serveGlobals(r)
+	period, err := getComparePeriod(globals, cfg)
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	options, err := buildServeOptions(body.Options, cfg)
+	if err == nil {
+		err = prepareServeOptions(globals, cfg, period, options)
+	}
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	results := make([]types.ComparisonResult, len(options))
+	for i, opt := range options {
+		resp, err := cache.calculate(opt.Request)
+		if err != nil {
+			writeServeError(w, http.StatusBadGateway, fmt.Errorf("failed to calculate tax for option '%s': %w", opt.Label, err))
+			return
+		}
+		results[i] = types.ComparisonResult{Label: opt.Label, Request: opt.Request, Response: resp}
+	}
+	writeServeJSON(w, http.StatusOK, display.ComparisonOutput(results, period))
+}
+
+// serveRates returns the tax rates for a year (default: the config or current tax year)
+func serveRates(w http.ResponseWriter, r *http.Request, cfg *config.Config) {
+	year := firstNonEmpty(r.URL.Query().Get("year"), cfg.Defaults.Year, getDefaultYear())
+	if _, err := strconv.Atoi(year); err != nil {
+		writeServeError(w, http.StatusBadRequest, fmt.Errorf("year must be a valid number: %s", year))
+		return
+	}
+
+	rates, err := tax.RatesFor(year)
+	if err != nil {
+		writeServeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeServeJSON(w, http.StatusOK, rates)
+}
+
+// buildServeOptions builds and validates the compare options, as the compare command does
+func buildServeOptions(objects []map[string]interface{}, cfg *config.Config) ([]ComparisonOption, error) {
+	if len(objects) < 2 {
+		return nil, fmt.Errorf("at least 2 options required for comparison")
+	}
+	if len(objects) > 4 {
+		return nil, fmt.Errorf("maximum 4 options supported for comparison (found %d)", len(objects))
+	}
+
+	options := make([]ComparisonOption, len(objects))
+	for i, object := range objects {
+		values, err := batchJSONValues(object)
+		if err != nil {
+			return nil, fmt.Errorf("option %d: %w", i+1, err)
+		}
+		label := strings.TrimSpace(values["label"])
+		if label == "" {
+			return nil, fmt.Errorf("option %d: label is required", i+1)
+		}
+
+		fields, err := batchFields(values)
+		var req *types.TaxRequest
+		if err == nil {
+			req, err = buildTaxRequest(fields, cfg)
+		}
+		if err == nil {
+			err = applyRate(req)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("option '%s': %w", label, err)
+		}
+
+		options[i] = ComparisonOption{Label: label, Request: req}
+		if err := validateOption(&options[i], cfg); err != nil {
+			return nil, err
+		}
+	}
+	return options, nil
+}
+
+// prepareServeOptions applies the working pattern and pay days from the query
+// parameters, then works out part-time pay, as check and compare do
+func prepareServeOptions(globals map[string]string, cfg *config.Config, period string, options []ComparisonOption) error {
+	if err := applyCompareWorkPattern(globals, cfg, options); err != nil {
+		return err
+	}
+	for _, opt := range options {
+		if err := applyPayDays(opt.Request, period, globals["payday"]); err != nil {
+			return err
+		}
+		if err := applyProRata(opt.Request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serveGlobals returns the query parameters that apply to the whole request
+func serveGlobals(r *http.Request) map[string]string {
+	query := r.URL.Query()
+	globals := make(map[string]string)
+	for _, name := range serveQueryParams {
+		if value := query.Get(name); value != "" {
+			globals[name] = value
+		}
+	}
+	return globals
+}
+
+// decodeServeBody decodes a JSON request body
+func decodeServeBody(w http.ResponseWriter, r *http.Request, target interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, serveMaxBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return nil
+}
+
+// writeServeJSON writes a JSON response
+func writeServeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeServeError writes an error as a JSON response
+func writeServeError(w http.ResponseWriter, status int, err error) {
+	writeServeJSON(w, status, map[string]string{"error": err.Error()})
+}
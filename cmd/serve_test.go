@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/client"
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// serveRequest sends a request to a new server handler and returns the response
+func serveRequest(t *testing.T, handler http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return recorder
+}
+
+// serveError returns the error message from an error response
+func serveError(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	return body["error"]
+}
+
+func TestServeCheck(t *testing.T) {
+	t.Parallel()
+
+	handler := newServeHandler(&config.Config{})
+	recorder := serveRequest(t, handler, http.MethodPost, "/v1/check?period=monthly",
+		`{"income": 45000, "year": 2025, "pension": "5%", "pension-scheme": "sacrifice"}`)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var resp types.TaxResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.InDelta(t, 3750.0, resp.GrossPay, 0.01)
+	assert.Equal(t, "sacrifice", resp.PensionScheme.Scheme)
+}
+
+func TestServeCheck_ProRata(t *testing.T) {
+	t.Parallel()
+
+	handler := newServeHandler(&config.Config{})
+	recorder := serveRequest(t, handler, http.MethodPost, "/v1/check?period=monthly",
+		`{"income": 60000, "fte": 0.6, "year": 2025, "pension": "5%", "pension-scheme": "sacrifice"}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	// The full-time figures are included, as with check --json
+	var resp types.TaxResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.InDelta(t, 3000.0, resp.GrossPay, 0.01)
+	require.NotNil(t, resp.ProRata)
+	require.NotNil(t, resp.FullTime)
+	assert.InDelta(t, 5000.0, resp.FullTime.GrossPay, 0.01)
+}
+
+func TestServeCheck_API(t *testing.T) {
+	originalClientFactory := checkClientFactory
+	t.Cleanup(func() { checkClientFactory = originalClientFactory })
+
+	mockRT := testutil.NewMockRoundTripperSuccess(200, testutil.SampleAPIResponse200)
+	checkClientFactory = func() *client.Client {
+		return client.NewWithHTTPClient(&http.Client{Transport: mockRT})
+	}
+
+	// Identical requests are only calculated once
+	handler := newServeHandler(&config.Config{})
+	for i := 0; i < 2; i++ {
+		recorder := serveRequest(t, handler, http.MethodPost, "/v1/check", `{"income": 100000}`)
+		require.Equal(t, http.StatusOK, recorder.Code)
+	}
+	assert.Equal(t, 1, mockRT.RequestCount)
+}
+
+func TestServeCheck_Errors(t *testing.T) {
+	t.Parallel()
+
+	handler := newServeHandler(&config.Config{})
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		want   string
+	}{
+		{"invalid JSON", http.MethodPost, "/v1/check", `{"income":`, http.StatusBadRequest, "invalid JSON body"},
+		{"unknown field", http.MethodPost, "/v1/check", `{"salary": 45000}`, http.StatusBadRequest, "unknown column: salary"},
+		{"missing income", http.MethodPost, "/v1/check", `{"year": "2025"}`, http.StatusBadRequest, "income is required"},
+		{"invalid plan", http.MethodPost, "/v1/check", `{"income": 45000, "student-loan": "plan9"}`, http.StatusBadRequest, "invalid student loan plan: plan9"},
+		{"invalid period", http.MethodPost, "/v1/check?period=hourlyish", `{"income": 45000}`, http.StatusBadRequest, "invalid period"},
+		{"calculation failure", http.MethodPost, "/v1/check", `{"income": 45000, "year": 1999, "pension": "5%", "pension-scheme": "sacrifice"}`, http.StatusBadGateway, "failed to calculate tax"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveRequest(t, handler, tt.method, tt.target, tt.body)
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Contains(t, serveError(t, recorder), tt.want)
+		})
+	}
+
+	// Only the routes' methods are allowed
+	recorder := serveRequest(t, handler, http.MethodGet, "/v1/check", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestServeCompare(t *testing.T) {
+	t.Parallel()
+
+	handler := newServeHandler(&config.Config{})
+	recorder := serveRequest(t, handler, http.MethodPost, "/v1/compare?period=monthly", `{"options": [
+		{"label": "Current", "income": 45000, "year": 2025, "pension": "5%", "pension-scheme": "sacrifice"},
+		{"label": "Offer", "income": 52000, "year": 2025, "pension": "5%", "pension-scheme": "sacrifice"}
+	]}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var body struct {
+		Period     string                        `json:"period"`
+		Comparison map[string]map[string]float64 `json:"comparison"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, "monthly", body.Period)
+	assert.InDelta(t, 3750.0, body.Comparison["gross_pay"]["Current"], 0.01)
+	assert.InDelta(t, 52000.0/12, body.Comparison["gross_pay"]["Offer"], 0.01)
+}
+
+func TestServeCompare_Errors(t *testing.T) {
+	t.Parallel()
+
+	handler := newServeHandler(&config.Config{})
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"one option", `{"options": [{"label": "A", "income": 45000}]}`, "at least 2 options required"},
+		{"five options", `{"options": [{}, {}, {}, {}, {}]}`, "maximum 4 options supported for comparison (found 5)"},
+		{"missing label", `{"options": [{"income": 45000}, {"label": "B", "income": 50000}]}`, "option 1: label is required"},
+		{"invalid option", `{"options": [{"label": "A", "income": 45000}, {"label": "B", "income": -1}]}`, "option 'B': income must be greater than 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveRequest(t, handler, http.MethodPost, "/v1/compare", tt.body)
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			assert.Contains(t, serveError(t, recorder), tt.want)
+		})
+	}
+}
+
+func TestServeRates(t *testing.T) {
+	t.Parallel()
+
+	handler := newServeHandler(&config.Config{})
+	recorder := serveRequest(t, handler, http.MethodGet, "/v1/rates?year=2025", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rates map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rates))
+	assert.InDelta(t, 2025.0, rates["year"], 0)
+	assert.InDelta(t, 12570.0, rates["personal_allowance"], 0)
+	assert.Contains(t, rates, "uk_bands")
+
+	recorder = serveRequest(t, handler, http.MethodGet, "/v1/rates?year=1999", "")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Contains(t, serveError(t, recorder), "no local tax rates for 1999")
+
+	recorder = serveRequest(t, handler, http.MethodGet, "/v1/rates?year=next", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "year must be a valid number: next", serveError(t, recorder))
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/terminal"
+)
+
+var (
+	flagStyle  string
+	flagColour string
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&flagStyle, "style", "",
+		"Output style: "+strings.Join(display.Styles, ", ")+" (default: unicode)")
+	rootCmd.PersistentFlags().StringVar(&flagColour, "color", "",
+		"When to colour tables: "+strings.Join(display.ColourModes, ", ")+" (default: auto)")
+	rootCmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		// Commands that need the config report it failing to load themselves
+		cfg, err := config.Load()
+		if err != nil {
+			cfg = &config.Config{}
+		}
+		if err := applyStyle(flagStyle, cfg); err != nil {
+			return err
+		}
+		if err := applyCurrency(flagCurrency, cfg); err != nil {
+			return err
+		}
+		return applyColour(flagColour, cfg)
+	}
+}
+
+// applyStyle sets the output style (flag > config > unicode)
+func applyStyle(name string, cfg *config.Config) error {
+	style, err := display.ParseStyle(firstNonEmpty(name, cfg.Defaults.Style, string(display.StyleUnicode)))
+	if err != nil {
+		return err
+	}
+	display.SetStyle(style)
+	return nil
+}
+
+// applyColour turns coloured tables on or off (flag > config > auto). Auto
+// colours output to a terminal, unless NO_COLOR is set or TERM is dumb
+func applyColour(mode string, cfg *config.Config) error {
+	mode = firstNonEmpty(mode, cfg.Defaults.Color, display.ColourAuto)
+	if err := display.ValidateColourMode(mode); err != nil {
+		return err
+	}
+
+	switch mode {
+	case display.ColourAlways:
+		display.SetColour(true)
+	case display.ColourNever:
+		display.SetColour(false)
+	default:
+		fd := int(os.Stdout.Fd()) // #nosec G115 -- file descriptors fit in an int
+		display.SetColour(colourTerminal(terminal.IsTerminal(fd), os.Getenv("NO_COLOR"), os.Getenv("TERM")))
+	}
+	return nil
+}
+
+// colourTerminal reports whether auto colour applies to a terminal with the
+// given NO_COLOR and TERM environment variables
+func colourTerminal(isTerminal bool, noColour, term string) bool {
+	return isTerminal && noColour == "" && term != "dumb"
+}
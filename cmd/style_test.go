@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+)
+
+func TestApplyStyle(t *testing.T) {
+	t.Cleanup(func() { display.SetStyle(display.StyleUnicode) })
+
+	require.NoError(t, applyStyle("", &config.Config{}))
+	assert.Equal(t, display.StyleUnicode, display.CurrentStyle())
+
+	cfg := &config.Config{Defaults: config.Defaults{Style: "plain"}}
+	require.NoError(t, applyStyle("", cfg))
+	assert.Equal(t, display.StylePlain, display.CurrentStyle())
+
+	// The flag wins over the config
+	require.NoError(t, applyStyle("ascii", cfg))
+	assert.Equal(t, display.StyleASCII, display.CurrentStyle())
+
+	assert.EqualError(t, applyStyle("boxes", cfg), "style must be one of unicode, ascii, plain, got: boxes")
+}
+
+func TestApplyColour(t *testing.T) {
+	t.Cleanup(func() { display.SetColour(false) })
+
+	cfg := &config.Config{Defaults: config.Defaults{Color: "always"}}
+	require.NoError(t, applyColour("", cfg))
+	assert.True(t, display.ColourEnabled())
+
+	// The flag wins over the config
+	require.NoError(t, applyColour("never", cfg))
+	assert.False(t, display.ColourEnabled())
+
+	assert.EqualError(t, applyColour("sometimes", cfg), "color must be one of auto, always, never, got: sometimes")
+}
+
+func TestColourTerminal(t *testing.T) {
+	assert.True(t, colourTerminal(true, "", "xterm-256color"))
+	assert.False(t, colourTerminal(false, "", "xterm-256color"))
+	assert.False(t, colourTerminal(true, "1", "xterm-256color"))
+	assert.False(t, colourTerminal(true, "", "dumb"))
+}
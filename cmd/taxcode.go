@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+)
+
+var (
+	flagTaxCodeYear string
+	flagTaxCodeJSON bool
+)
+
+var taxCodeCmd = &cobra.Command{
+	Use:   "taxcode",
+	Short: "Work with PAYE tax codes",
+}
+
+var taxCodeExplainCmd = &cobra.Command{
+	Use:   "explain <code>",
+	Short: "Explain what a tax code means",
+	Long: `Decode a PAYE tax code: the tax-free allowance, the S (Scotland) or C (Wales)
+region prefix, K codes that add to taxable pay, flat rate codes (BR, D0-D3, NT)
+and the emergency W1, M1, W1/M1 or X basis.`,
+	Example: `  listentotaxman taxcode explain 1257L
+  listentotaxman taxcode explain K475
+  listentotaxman taxcode explain S1257L W1/M1`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTaxCodeExplain,
+}
+
+func init() {
+	rootCmd.AddCommand(taxCodeCmd)
+	taxCodeCmd.AddCommand(taxCodeExplainCmd)
+
+	taxCodeExplainCmd.Flags().StringVar(&flagTaxCodeYear, "year", "", "Tax year for flat rates (defaults to current tax year)")
+	taxCodeExplainCmd.Flags().BoolVar(&flagTaxCodeJSON, "json", false, "Output as JSON")
+}
+
+// taxCodeExplanation is the JSON form of an explained tax code
+type taxCodeExplanation struct {
+	*tax.TaxCode
+	Cumulative bool    `json:"cumulative"`
+	Year       int     `json:"year"`
+	Rate       float64 `json:"rate,omitempty"`
+}
+
+func runTaxCodeExplain(_ *cobra.Command, args []string) error {
+	// Allow the basis to be passed unquoted, e.g. explain 1257L W1/M1
+	code, err := tax.ParseTaxCode(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	year := flagTaxCodeYear
+	if year == "" {
+		year = getDefaultYear()
+	}
+	rates, err := tax.RatesFor(year)
+	if err != nil {
+		return err
+	}
+
+	flat := tax.Band{}
+	if code.FlatRate != "" {
+		if flat, err = rates.FlatRateBand(code, "uk"); err != nil {
+			return err
+		}
+	}
+
+	if flagTaxCodeJSON {
+		jsonData, err := json.MarshalIndent(taxCodeExplanation{
+			TaxCode:    code,
+			Cumulative: code.Cumulative(),
+			Year:       rates.Year,
+			Rate:       flat.Rate,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	display.TaxCode(code, flat, rates.Year)
+	return nil
+}
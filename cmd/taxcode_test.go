@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+)
+
+func TestRunTaxCodeExplain(t *testing.T) {
+	flagTaxCodeYear = "2025"
+	flagTaxCodeJSON = false
+	t.Cleanup(func() { flagTaxCodeYear = "" })
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "standard code",
+			args:     []string{"1257L"},
+			expected: []string{"Tax Code: 1257L (2025)", "£12,570.00 tax-free pay", "Cumulative"},
+		},
+		{
+			name:     "unquoted emergency basis",
+			args:     []string{"s1257l", "W1/M1"},
+			expected: []string{"Tax Code: S1257L W1/M1", "Scotland (S prefix)", "Emergency (W1/M1)"},
+		},
+		{
+			name:     "k code",
+			args:     []string{"K475"},
+			expected: []string{"£4,750.00 is added to taxable pay", "capped at 50%"},
+		},
+		{
+			name:     "flat rate",
+			args:     []string{"D0"},
+			expected: []string{"taxed at the Higher Rate (40%)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := testutil.CaptureStdout(t, func() {
+				err := runTaxCodeExplain(taxCodeExplainCmd, tt.args)
+				require.NoError(t, err)
+			})
+
+			for _, expected := range tt.expected {
+				assert.Contains(t, output, expected)
+			}
+		})
+	}
+}
+
+func TestRunTaxCodeExplain_JSON(t *testing.T) {
+	flagTaxCodeYear = "2025"
+	flagTaxCodeJSON = true
+	t.Cleanup(func() {
+		flagTaxCodeYear = ""
+		flagTaxCodeJSON = false
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		err := runTaxCodeExplain(taxCodeExplainCmd, []string{"SD0", "M1"})
+		require.NoError(t, err)
+	})
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, "SD0", result["code"])
+	assert.Equal(t, "scotland", result["region"])
+	assert.Equal(t, "M1", result["basis"])
+	assert.Equal(t, false, result["cumulative"])
+	assert.InDelta(t, 0.21, result["rate"], 0.0001)
+}
+
+func TestRunTaxCodeExplain_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		year     string
+		args     []string
+		errorMsg string
+	}{
+		{"invalid code", "2025", []string{"1257Q"}, "invalid tax code: 1257Q"},
+		{"unsupported year", "2019", []string{"1257L"}, "no local tax rates for 2019"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagTaxCodeYear = tt.year
+			t.Cleanup(func() { flagTaxCodeYear = "" })
+
+			err := runTaxCodeExplain(taxCodeExplainCmd, tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
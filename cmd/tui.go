@@ -0,0 +1,489 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/display"
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/terminal"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// tuiDebounce is how long the TUI waits after the last change before recalculating
+const tuiDebounce = 150 * time.Millisecond
+
+// tuiDefaultWidth is the screen width used when the terminal size is unknown
+const tuiDefaultWidth = 80
+
+// tuiSliderWidth is the width of a slider's track in characters
+const tuiSliderWidth = 30
+
+// Keys the TUI responds to
+const (
+	tuiKeyUp = iota + 1
+	tuiKeyDown
+	tuiKeyLeft
+	tuiKeyRight
+	tuiKeyBigLeft
+	tuiKeyBigRight
+	tuiKeyPeriod
+	tuiKeyPin
+	tuiKeyClear
+	tuiKeyQuit
+)
+
+// tuiKeyBytes maps single key presses to keys
+var tuiKeyBytes = map[byte]int{
+	'k': tuiKeyUp, '\t': tuiKeyDown, 'j': tuiKeyDown,
+	'h': tuiKeyLeft, '-': tuiKeyLeft, 'l': tuiKeyRight, '+': tuiKeyRight, '=': tuiKeyRight,
+	'[': tuiKeyBigLeft, ']': tuiKeyBigRight,
+	't': tuiKeyPeriod, 'p': tuiKeyPin, 'c': tuiKeyClear,
+	'q': tuiKeyQuit, 3: tuiKeyQuit, 4: tuiKeyQuit,
+}
+
+// tuiEscapeKeys maps the escape sequences of arrow and page keys to keys
+var tuiEscapeKeys = map[string]int{
+	"\x1b[A": tuiKeyUp, "\x1b[B": tuiKeyDown, "\x1b[C": tuiKeyRight, "\x1b[D": tuiKeyLeft,
+	"\x1b[5~": tuiKeyBigRight, "\x1b[6~": tuiKeyBigLeft,
+}
+
+var (
+	flagTUIIncome        int
+	flagTUIPension       float64
+	flagTUIBonus         int
+	flagTUIPensionScheme string
+	flagTUIPeriod        string
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Explore salary, pension and bonus trade-offs on a live full-screen summary",
+	Long: `Adjust income, pension percentage and bonus with the arrow keys and watch the
+summary update as you go. Pin a scenario as a baseline to see it side by side
+with the current one, and the difference between them.
+
+The pension percentage applies to the salary, not the bonus. The other
+details (tax year, region, student loan, tax code and so on) come from the
+config file.
+
+Keys:
+  ↑/↓ (k/j, tab)   Select income, pension or bonus
+  ←/→ (h/l, -/+)   Adjust by a small step
+  [ ] (PgDn/PgUp)  Adjust by a big step
+  t                Change the display period
+  p                Pin the current scenario as the baseline
+  c                Clear the baseline
+  q                Quit`,
+	Example: `  listentotaxman tui --income 60000 --pension 5 --pension-scheme sacrifice
+  listentotaxman tui --income 85000 --bonus 10000 --period monthly`,
+	RunE: runTUI,
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+
+	tuiCmd.Flags().IntVar(&flagTUIIncome, "income", 0, "Starting gross annual salary (required)")
+	tuiCmd.Flags().Float64Var(&flagTUIPension, "pension", 0, "Starting pension contribution as a percentage of salary")
+	tuiCmd.Flags().IntVar(&flagTUIBonus, "bonus", 0, "Starting annual bonus")
+	tuiCmd.Flags().StringVar(&flagTUIPensionScheme, "pension-scheme", "", "Pension scheme (sacrifice, net-pay, relief-at-source), calculated locally")
+	tuiCmd.Flags().StringVar(&flagTUIPeriod, "period", "", "Display period (yearly, quarterly, monthly, four-weekly, fortnightly, weekly, daily, hourly) (default: yearly)")
+}
+
+func runTUI(_ *cobra.Command, _ []string) error {
+	// Load config file
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	model, err := newTUIModel(cfg)
+	if err != nil {
+		return err
+	}
+
+	// The TUI reads key presses as they are typed, so needs a terminal
+	fd := int(os.Stdin.Fd()) // #nosec G115 -- file descriptors fit in an int
+	if !terminal.IsTerminal(fd) {
+		return fmt.Errorf("tui needs an interactive terminal (use check or interactive instead)")
+	}
+	restore, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to start full-screen mode: %w", err)
+	}
+	defer func() { _ = restore() }()
+
+	// Draw on the alternate screen without a cursor, restoring both on exit
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	outFd := int(os.Stdout.Fd()) // #nosec G115 -- file descriptors fit in an int
+	width := func() int {
+		if w, _, err := terminal.Size(outFd); err == nil && w > 0 {
+			return w
+		}
+		return tuiDefaultWidth
+	}
+
+	cache := newCalculationCache(0)
+	calculate := func(fields map[string]string) (*types.TaxRequest, *types.TaxResponse, error) {
+		return calculateFields(fields, cfg, cache)
+	}
+	return runTUILoop(os.Stdin, os.Stdout, model, calculate, width, tuiDebounce)
+}
+
+// tuiSlider is an adjustable field
+type tuiSlider struct {
+	label   string
+	value   float64
+	step    float64
+	bigStep float64
+	max     float64
+	format  func(float64) string
+}
+
+// adjust moves the slider by steps, keeping it between 0 and its maximum
+func (s *tuiSlider) adjust(step float64) {
+	s.value = math.Max(0, math.Min(s.max, s.value+step))
+}
+
+// tuiScenario is one calculation of the salary, pension and bonus
+type tuiScenario struct {
+	seq      int
+	salary   float64
+	pension  float64
+	bonus    float64
+	request  *types.TaxRequest
+	response *types.TaxResponse
+	err      error
+}
+
+// tuiModel is the state of the TUI: the sliders, the display period and the
+// current and pinned scenarios
+type tuiModel struct {
+	sliders       []tuiSlider
+	selected      int
+	period        string
+	pensionScheme string
+	current       *tuiScenario
+	baseline      *tuiScenario
+	pending       bool
+}
+
+// newTUIModel creates the model from the flags and config file
+func newTUIModel(cfg *config.Config) (*tuiModel, error) {
+	if flagTUIIncome <= 0 {
+		return nil, fmt.Errorf("--income must be greater than 0")
+	}
+	if flagTUIPension < 0 || flagTUIPension > 100 {
+		return nil, fmt.Errorf("--pension must be between 0 and 100, got: %g", flagTUIPension)
+	}
+	if flagTUIBonus < 0 {
+		return nil, fmt.Errorf("--bonus cannot be negative")
+	}
+
+	// Period: flag > config > default yearly
+	period := firstNonEmpty(flagTUIPeriod, cfg.Defaults.Period, payperiod.Yearly)
+	if err := payperiod.Validate(period); err != nil {
+		return nil, err
+	}
+
+	income := float64(flagTUIIncome)
+	return &tuiModel{
+		sliders: []tuiSlider{
+			{label: "Salary", value: income, step: 1000, bigStep: 10000, max: math.Max(250000, 2*income), format: display.FormatWholePounds},
+			{label: "Pension", value: flagTUIPension, step: 1, bigStep: 5, max: 100, format: formatTUIPercent},
+			{label: "Bonus", value: float64(flagTUIBonus), step: 500, bigStep: 5000, max: math.Max(100000, 2*float64(flagTUIBonus)), format: display.FormatWholePounds},
+		},
+		period:        period,
+		pensionScheme: firstNonEmpty(flagTUIPensionScheme, cfg.Defaults.PensionScheme),
+	}, nil
+}
+
+// scenario returns the slider values as a scenario to calculate
+func (m *tuiModel) scenario(seq int) *tuiScenario {
+	return &tuiScenario{seq: seq, salary: m.sliders[0].value, pension: m.sliders[1].value, bonus: m.sliders[2].value}
+}
+
+// fields returns a scenario's field values. The pension is the percentage of
+// the salary as an amount, so the bonus is not pensionable.
+func (m *tuiModel) fields(s *tuiScenario) map[string]string {
+	fields := map[string]string{
+		"income":  strconv.Itoa(int(math.Round(s.salary + s.bonus))),
+		"pension": strconv.Itoa(int(math.Round(s.salary * s.pension / 100))),
+	}
+	if m.pensionScheme != "" {
+		fields["pension-scheme"] = m.pensionScheme
+	}
+	return fields
+}
+
+// handleKey applies a key press, returning whether the sliders changed and
+// whether to quit
+func (m *tuiModel) handleKey(key int) (bool, bool) {
+	slider := &m.sliders[m.selected]
+	switch key {
+	case tuiKeyUp:
+		m.selected = (m.selected + len(m.sliders) - 1) % len(m.sliders)
+	case tuiKeyDown:
+		m.selected = (m.selected + 1) % len(m.sliders)
+	case tuiKeyLeft:
+		slider.adjust(-slider.step)
+		return true, false
+	case tuiKeyRight:
+		slider.adjust(slider.step)
+		return true, false
+	case tuiKeyBigLeft:
+		slider.adjust(-slider.bigStep)
+		return true, false
+	case tuiKeyBigRight:
+		slider.adjust(slider.bigStep)
+		return true, false
+	case tuiKeyPeriod:
+		m.period = nextPeriod(m.period)
+	case tuiKeyPin:
+		if m.current != nil && m.current.err == nil {
+			m.baseline = m.current
+		}
+	case tuiKeyClear:
+		m.baseline = nil
+	case tuiKeyQuit:
+		return false, true
+	}
+	return false, false
+}
+
+// nextPeriod returns the display period after the given one, wrapping round
+func nextPeriod(period string) string {
+	for i, name := range payperiod.Names {
+		if name == period {
+			return payperiod.Names[(i+1)%len(payperiod.Names)]
+		}
+	}
+	return payperiod.Yearly
+}
+
+// runTUILoop reads keys from in and redraws the screen on out until quit or
+// the end of the input. Changes are recalculated once no key has been
+// pressed for the debounce interval, and results arriving out of order are
+// ignored.
+func runTUILoop(in io.Reader, out io.Writer, m *tuiModel,
+	calculate func(map[string]string) (*types.TaxRequest, *types.TaxResponse, error),
+	width func() int, debounce time.Duration) error {
+	keys := make(chan int)
+	go readTUIKeys(in, keys)
+
+	done := make(chan struct{})
+	defer close(done)
+	results := make(chan *tuiScenario)
+	seq := 0
+	start := func() {
+		seq++
+		scenario := m.scenario(seq)
+		fields := m.fields(scenario)
+		go func() {
+			scenario.request, scenario.response, scenario.err = calculate(fields)
+			select {
+			case results <- scenario:
+			case <-done:
+			}
+		}()
+	}
+
+	m.pending = true
+	start()
+	renderTUI(out, m, width())
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			changed, quit := m.handleKey(key)
+			if quit {
+				return nil
+			}
+			if changed {
+				m.pending = true
+				timer = time.After(debounce)
+			}
+		case <-timer:
+			timer = nil
+			start()
+		case scenario := <-results:
+			if m.current == nil || scenario.seq > m.current.seq {
+				m.current = scenario
+			}
+			m.pending = timer != nil || m.current.seq != seq
+		}
+		renderTUI(out, m, width())
+	}
+}
+
+// readTUIKeys decodes key presses from in, closing keys at the end of the input
+func readTUIKeys(in io.Reader, keys chan<- int) {
+	defer close(keys)
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		for _, key := range decodeTUIKeys(buf[:n]) {
+			keys <- key
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// decodeTUIKeys decodes the keys in a read from the terminal, ignoring any
+// it doesn't use
+func decodeTUIKeys(data []byte) []int {
+	keys := []int{}
+	for i := 0; i < len(data); i++ {
+		if data[i] == 0x1b {
+			matched := false
+			for sequence, key := range tuiEscapeKeys {
+				if bytes.HasPrefix(data[i:], []byte(sequence)) {
+					keys = append(keys, key)
+					i += len(sequence) - 1
+					matched = true
+					break
+				}
+			}
+			switch {
+			case matched:
+			case i+1 == len(data):
+				// Escape on its own quits
+				keys = append(keys, tuiKeyQuit)
+			default:
+				i = skipEscapeSequence(data, i)
+			}
+			continue
+		}
+		if key, ok := tuiKeyBytes[data[i]]; ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// skipEscapeSequence returns the index of the last byte of an escape
+// sequence starting at i, such as that of a function key
+func skipEscapeSequence(data []byte, i int) int {
+	if i+1 >= len(data) || data[i+1] != '[' {
+		return i
+	}
+	for j := i + 2; j < len(data); j++ {
+		if data[j] >= 0x40 && data[j] <= 0x7e {
+			return j
+		}
+	}
+	return len(data) - 1
+}
+
+// renderTUI draws the whole screen: the sliders, then the current scenario's
+// summary beside (or above) the pinned baseline's
+func renderTUI(out io.Writer, m *tuiModel, width int) {
+	var screen strings.Builder
+	screen.WriteString("\x1b[H\x1b[2J")
+	screen.WriteString("↑↓ select  ←→ adjust  [ ] big step  t period  p pin baseline  c clear  q quit\n\n")
+
+	for i, slider := range m.sliders {
+		cursor := " "
+		if i == m.selected {
+			cursor = ">"
+		}
+		fmt.Fprintf(&screen, "%s %-8s %10s  %s\n", cursor, slider.label, slider.format(slider.value), sliderTrack(slider))
+	}
+
+	status := ""
+	if m.pending {
+		status = "Calculating…"
+	}
+	fmt.Fprintf(&screen, "\n%s\n", status)
+
+	switch {
+	case m.current == nil:
+	case m.current.err != nil:
+		fmt.Fprintf(&screen, "Error: %v\n", m.current.err)
+	default:
+		current := tuiPane("Current", m.current, m.period)
+		if m.baseline == nil {
+			screen.WriteString(strings.Join(current, "\n") + "\n")
+		} else {
+			baseline := tuiPane("Baseline", m.baseline, m.period)
+			screen.WriteString(joinPanes(current, baseline, width))
+			display.FprintBaselineChange(&screen, m.current.response, m.baseline.response, m.period,
+				m.current.request, m.baseline.request)
+		}
+	}
+
+	_, _ = io.WriteString(out, screen.String())
+}
+
+// tuiPane returns a scenario's title and summary table as lines
+func tuiPane(title string, s *tuiScenario, period string) []string {
+	var summary bytes.Buffer
+	display.FprintSummary(&summary, s.response, period, s.request)
+
+	heading := fmt.Sprintf("%s: %s salary, %s pension, %s bonus", title,
+		display.FormatWholePounds(s.salary), formatTUIPercent(s.pension), display.FormatWholePounds(s.bonus))
+	lines := []string{heading}
+	for _, line := range strings.Split(summary.String(), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// joinPanes places two panes side by side when the screen is wide enough,
+// or one above the other when not
+func joinPanes(left, right []string, width int) string {
+	leftWidth := 0
+	for _, line := range left {
+		leftWidth = max(leftWidth, display.DisplayWidth(line))
+	}
+
+	var joined strings.Builder
+	if leftWidth+2+leftWidth > width {
+		joined.WriteString(strings.Join(left, "\n") + "\n\n")
+		joined.WriteString(strings.Join(right, "\n") + "\n\n")
+		return joined.String()
+	}
+
+	for i := 0; i < max(len(left), len(right)); i++ {
+		line := ""
+		if i < len(left) {
+			line = left[i]
+		}
+		if i < len(right) {
+			line += strings.Repeat(" ", leftWidth+2-display.DisplayWidth(line)) + right[i]
+		}
+		joined.WriteString(line + "\n")
+	}
+	joined.WriteString("\n")
+	return joined.String()
+}
+
+// sliderTrack draws a slider's position between 0 and its maximum
+func sliderTrack(s tuiSlider) string {
+	position := int(math.Round(s.value / s.max * (tuiSliderWidth - 1)))
+	return "├" + strings.Repeat("─", position) + "●" + strings.Repeat("─", tuiSliderWidth-1-position) + "┤"
+}
+
+// formatTUIPercent formats a pension percentage
+func formatTUIPercent(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', -1, 64) + "%"
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/config"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// lockedBuffer is a buffer that is safe to write from the TUI loop while a test reads it
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newTestTUIModel creates a model for a 60000 salary with 5% salary sacrifice
+func newTestTUIModel(t *testing.T) *tuiModel {
+	t.Helper()
+
+	flagTUIIncome = 60000
+	flagTUIPension = 5
+	flagTUIBonus = 0
+	flagTUIPensionScheme = "sacrifice"
+	flagTUIPeriod = ""
+	t.Cleanup(func() {
+		flagTUIIncome = 0
+		flagTUIPension = 0
+		flagTUIPensionScheme = ""
+	})
+
+	m, err := newTUIModel(&config.Config{})
+	require.NoError(t, err)
+	return m
+}
+
+func TestNewTUIModel_Errors(t *testing.T) {
+	t.Cleanup(func() {
+		flagTUIIncome = 0
+		flagTUIPension = 0
+		flagTUIBonus = 0
+		flagTUIPeriod = ""
+	})
+
+	_, err := newTUIModel(&config.Config{})
+	assert.EqualError(t, err, "--income must be greater than 0")
+
+	flagTUIIncome = 60000
+	flagTUIPension = 101
+	_, err = newTUIModel(&config.Config{})
+	assert.EqualError(t, err, "--pension must be between 0 and 100, got: 101")
+
+	flagTUIPension = 5
+	flagTUIBonus = -1
+	_, err = newTUIModel(&config.Config{})
+	assert.EqualError(t, err, "--bonus cannot be negative")
+
+	flagTUIBonus = 0
+	flagTUIPeriod = "hourlyish"
+	_, err = newTUIModel(&config.Config{})
+	assert.ErrorContains(t, err, "invalid period: hourlyish")
+}
+
+func TestTUIModel_HandleKey(t *testing.T) {
+	m := newTestTUIModel(t)
+
+	changed, quit := m.handleKey(tuiKeyRight)
+	assert.True(t, changed)
+	assert.False(t, quit)
+	assert.InDelta(t, 61000.0, m.sliders[0].value, 0)
+
+	// Selection wraps round, and sliders stop at their limits
+	m.handleKey(tuiKeyUp)
+	assert.Equal(t, 2, m.selected)
+	m.handleKey(tuiKeyBigLeft)
+	assert.Zero(t, m.sliders[2].value)
+	m.handleKey(tuiKeyDown)
+	m.handleKey(tuiKeyDown)
+	for i := 0; i < 30; i++ {
+		m.handleKey(tuiKeyBigRight)
+	}
+	assert.InDelta(t, 100.0, m.sliders[1].value, 0)
+
+	changed, _ = m.handleKey(tuiKeyPeriod)
+	assert.False(t, changed)
+	assert.Equal(t, "quarterly", m.period)
+
+	// Only a calculated scenario can be pinned
+	m.handleKey(tuiKeyPin)
+	assert.Nil(t, m.baseline)
+	m.current = &tuiScenario{seq: 1}
+	m.handleKey(tuiKeyPin)
+	assert.Same(t, m.current, m.baseline)
+	m.handleKey(tuiKeyClear)
+	assert.Nil(t, m.baseline)
+
+	_, quit = m.handleKey(tuiKeyQuit)
+	assert.True(t, quit)
+}
+
+func TestTUIModel_Fields(t *testing.T) {
+	m := newTestTUIModel(t)
+
+	// The pension is a percentage of the salary only
+	fields := m.fields(&tuiScenario{salary: 60000, pension: 5, bonus: 10000})
+	assert.Equal(t, map[string]string{"income": "70000", "pension": "3000", "pension-scheme": "sacrifice"}, fields)
+}
+
+func TestDecodeTUIKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := decodeTUIKeys([]byte("\x1b[A\x1b[Bjkx+-[]\x1b[5~\x1b[6~tpcq\x03"))
+	assert.Equal(t, []int{
+		tuiKeyUp, tuiKeyDown, tuiKeyDown, tuiKeyUp, tuiKeyRight, tuiKeyLeft, tuiKeyBigLeft, tuiKeyBigRight,
+		tuiKeyBigRight, tuiKeyBigLeft, tuiKeyPeriod, tuiKeyPin, tuiKeyClear, tuiKeyQuit, tuiKeyQuit,
+	}, keys)
+
+	assert.Equal(t, []int{tuiKeyQuit}, decodeTUIKeys([]byte("\x1b")))
+	assert.Equal(t, []int{tuiKeyRight}, decodeTUIKeys([]byte("\x1b[Z\x1b[C")))
+}
+
+func TestJoinPanes(t *testing.T) {
+	t.Parallel()
+
+	left := []string{"Current", "╔══╗"}
+	right := []string{"Baseline", "╔══╗", "╚══╝"}
+
+	assert.Equal(t, "Current  Baseline\n╔══╗     ╔══╗\n         ╚══╝\n\n", joinPanes(left, right, 80))
+	assert.Equal(t, "Current\n╔══╗\n\nBaseline\n╔══╗\n╚══╝\n\n", joinPanes(left, right, 10))
+
+	// Colour escape sequences take no columns
+	left = []string{"Current", "\x1b[1mNet\x1b[0m"}
+	assert.Equal(t, "Current  Baseline\n\x1b[1mNet\x1b[0m      ╔══╗\n         ╚══╝\n\n", joinPanes(left, right, 18))
+}
+
+func TestRunTUILoop(t *testing.T) {
+	m := newTestTUIModel(t)
+
+	var calls atomic.Int32
+	calculate := func(fields map[string]string) (*types.TaxRequest, *types.TaxResponse, error) {
+		calls.Add(1)
+		req, resp, err := calculateFields(fields, &config.Config{}, newCalculationCache(0))
+		return req, resp, err
+	}
+
+	in, keys := io.Pipe()
+	var out lockedBuffer
+	done := make(chan error)
+	go func() {
+		done <- runTUILoop(in, &out, m, calculate, func() int { return 120 }, 20*time.Millisecond)
+	}()
+
+	// The first scenario is calculated straight away; pin it
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "Current: £60,000") }, time.Second, time.Millisecond)
+	_, err := io.WriteString(keys, "p")
+	require.NoError(t, err)
+
+	// Quick changes are calculated once
+	_, err = io.WriteString(keys, "\x1b[C\x1b[C\x1b[C")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "Current: £63,000") }, time.Second, time.Millisecond)
+	_, err = io.WriteString(keys, "q")
+	require.NoError(t, err)
+	require.NoError(t, <-done)
+
+	assert.Equal(t, int32(2), calls.Load())
+	require.NotNil(t, m.baseline)
+	assert.InDelta(t, 60000.0, m.baseline.salary, 0)
+	assert.InDelta(t, 63000.0, m.current.salary, 0)
+	assert.False(t, m.pending)
+
+	screen := out.String()
+	assert.Contains(t, screen, "> Salary      £63,000  ├")
+	assert.Contains(t, screen, "Current: £63,000 salary, 5% pension, £0 bonus")
+	assert.Contains(t, screen, "Baseline: £60,000 salary, 5% pension, £0 bonus")
+	assert.Contains(t, screen, "Change from baseline - Yearly")
+	assert.Contains(t, screen, fmt.Sprintf("  %-25s %18s", "Gross Salary", "£3,000.00"))
+}
+
+func TestRunTUILoop_Error(t *testing.T) {
+	m := newTestTUIModel(t)
+	m.pensionScheme = "sacrificial"
+
+	var out lockedBuffer
+	calculate := func(fields map[string]string) (*types.TaxRequest, *types.TaxResponse, error) {
+		return calculateFields(fields, &config.Config{}, newCalculationCache(0))
+	}
+
+	// The end of the input ends the loop
+	in, keys := io.Pipe()
+	done := make(chan error)
+	go func() {
+		done <- runTUILoop(in, &out, m, calculate, func() int { return 80 }, time.Millisecond)
+	}()
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "Error:") }, time.Second, time.Millisecond)
+	require.NoError(t, keys.Close())
+	require.NoError(t, <-done)
+
+	assert.Contains(t, out.String(), "Error: invalid pension scheme: sacrificial")
+}
@@ -15,19 +15,31 @@ type Config struct {
 
 // Defaults holds default values for CLI flags
 type Defaults struct {
-	Region        string `mapstructure:"region"`
-	Year          string `mapstructure:"year"`
-	Age           string `mapstructure:"age"`
-	Pension       string `mapstructure:"pension"`
-	StudentLoan   string `mapstructure:"student-loan"`
-	TaxCode       string `mapstructure:"tax-code"`
-	Extra         int    `mapstructure:"extra"`
-	Period        string `mapstructure:"period"`
-	Income        int    `mapstructure:"income"`
-	Married       bool   `mapstructure:"married"`
-	Blind         bool   `mapstructure:"blind"`
-	NoNI          bool   `mapstructure:"no-ni"`
-	PartnerIncome int    `mapstructure:"partner-income"`
+	Region             string  `mapstructure:"region"`
+	Year               string  `mapstructure:"year"`
+	Age                string  `mapstructure:"age"`
+	Pension            string  `mapstructure:"pension"`
+	PensionScheme      string  `mapstructure:"pension-scheme"`
+	EmployerPension    string  `mapstructure:"employer-pension"`
+	StudentLoan        string  `mapstructure:"student-loan"`
+	TaxCode            string  `mapstructure:"tax-code"`
+	Extra              int     `mapstructure:"extra"`
+	Period             string  `mapstructure:"period"`
+	Income             int     `mapstructure:"income"`
+	Married            bool    `mapstructure:"married"`
+	Blind              bool    `mapstructure:"blind"`
+	NoNI               bool    `mapstructure:"no-ni"`
+	PartnerIncome      int     `mapstructure:"partner-income"`
+	HoursPerWeek       float64 `mapstructure:"hours-per-week"`
+	DaysPerWeek        float64 `mapstructure:"days-per-week"`
+	HolidayDays        float64 `mapstructure:"holiday-days"`
+	Style              string  `mapstructure:"style"`
+	Color              string  `mapstructure:"color"`
+	Decimals           string  `mapstructure:"decimals"`
+	NoSymbol           bool    `mapstructure:"no-symbol"`
+	ThousandsSeparator string  `mapstructure:"thousands-separator"`
+	DisplayCurrency    string  `mapstructure:"display-currency"`
+	FXRate             string  `mapstructure:"fx-rate"`
 }
 
 // Load loads the configuration file
@@ -48,6 +60,8 @@ func Load() (*Config, error) {
 	viper.SetDefault("defaults.region", "uk")
 	viper.SetDefault("defaults.age", "0")
 	viper.SetDefault("defaults.pension", "")
+	viper.SetDefault("defaults.pension-scheme", "")
+	viper.SetDefault("defaults.employer-pension", "")
 	viper.SetDefault("defaults.student-loan", "")
 	viper.SetDefault("defaults.tax-code", "")
 	viper.SetDefault("defaults.extra", 0)
@@ -58,6 +72,16 @@ func Load() (*Config, error) {
 	viper.SetDefault("defaults.blind", false)
 	viper.SetDefault("defaults.no-ni", false)
 	viper.SetDefault("defaults.partner-income", 0)
+	viper.SetDefault("defaults.hours-per-week", 0)
+	viper.SetDefault("defaults.days-per-week", 0)
+	viper.SetDefault("defaults.holiday-days", 0)
+	viper.SetDefault("defaults.style", "")
+	viper.SetDefault("defaults.color", "")
+	viper.SetDefault("defaults.decimals", "")
+	viper.SetDefault("defaults.no-symbol", false)
+	viper.SetDefault("defaults.thousands-separator", "")
+	viper.SetDefault("defaults.display-currency", "")
+	viper.SetDefault("defaults.fx-rate", "")
 
 	// Read config file if it exists
 	if _, err := os.Stat(configFile); err == nil {
@@ -77,6 +77,39 @@ func TestLoad_ValidConfigFile(t *testing.T) {
 	assert.Equal(t, 25000, cfg.Defaults.PartnerIncome)
 }
 
+func TestLoad_WorkPattern(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	testutil.CreateTempConfigFile(t, `defaults:
+  hours-per-week: 37.5
+  days-per-week: 4
+  holiday-days: 25
+`)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, 37.5, cfg.Defaults.HoursPerWeek)
+	assert.Equal(t, 4.0, cfg.Defaults.DaysPerWeek)
+	assert.Equal(t, 25.0, cfg.Defaults.HolidayDays)
+}
+
+func TestLoad_PensionScheme(t *testing.T) {
+	testutil.SetupViperTest(t)
+
+	testutil.CreateTempConfigFile(t, `defaults:
+  pension: "5%"
+  pension-scheme: sacrifice
+  employer-pension: "3%"
+`)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "sacrifice", cfg.Defaults.PensionScheme)
+	assert.Equal(t, "3%", cfg.Defaults.EmployerPension)
+}
+
 func TestLoad_PartialConfig(t *testing.T) {
 	testutil.SetupViperTest(t)
 
@@ -146,24 +179,30 @@ func TestLoad_AllDefaultFields(t *testing.T) {
 		_ = os.Setenv("USERPROFILE", originalUserProfile)
 	})
 
+	// Each field's default, read from the loaded defaults
 	tests := []struct {
 		name     string
-		field    string
+		actual   func(Defaults) interface{}
 		expected interface{}
 	}{
-		{"region default", "region", "uk"},
-		{"age default", "age", "0"},
-		{"pension default", "pension", ""},
-		{"student-loan default", "student-loan", ""},
-		{"tax-code default", "tax-code", ""},
-		{"extra default", "extra", 0},
-		{"year default", "year", ""},
-		{"period default", "period", "yearly"},
-		{"income default", "income", 0},
-		{"married default", "married", false},
-		{"blind default", "blind", false},
-		{"no-ni default", "no-ni", false},
-		{"partner-income default", "partner-income", 0},
+		{"region default", func(d Defaults) interface{} { return d.Region }, "uk"},
+		{"age default", func(d Defaults) interface{} { return d.Age }, "0"},
+		{"pension default", func(d Defaults) interface{} { return d.Pension }, ""},
+		{"student-loan default", func(d Defaults) interface{} { return d.StudentLoan }, ""},
+		{"tax-code default", func(d Defaults) interface{} { return d.TaxCode }, ""},
+		{"extra default", func(d Defaults) interface{} { return d.Extra }, 0},
+		{"year default", func(d Defaults) interface{} { return d.Year }, ""},
+		{"period default", func(d Defaults) interface{} { return d.Period }, "yearly"},
+		{"income default", func(d Defaults) interface{} { return d.Income }, 0},
+		{"married default", func(d Defaults) interface{} { return d.Married }, false},
+		{"blind default", func(d Defaults) interface{} { return d.Blind }, false},
+		{"no-ni default", func(d Defaults) interface{} { return d.NoNI }, false},
+		{"partner-income default", func(d Defaults) interface{} { return d.PartnerIncome }, 0},
+		{"hours-per-week default", func(d Defaults) interface{} { return d.HoursPerWeek }, 0.0},
+		{"days-per-week default", func(d Defaults) interface{} { return d.DaysPerWeek }, 0.0},
+		{"holiday-days default", func(d Defaults) interface{} { return d.HolidayDays }, 0.0},
+		{"pension-scheme default", func(d Defaults) interface{} { return d.PensionScheme }, ""},
+		{"employer-pension default", func(d Defaults) interface{} { return d.EmployerPension }, ""},
 	}
 
 	cfg, err := Load()
@@ -171,34 +210,7 @@ func TestLoad_AllDefaultFields(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			switch tt.field {
-			case "region":
-				assert.Equal(t, tt.expected, cfg.Defaults.Region)
-			case "age":
-				assert.Equal(t, tt.expected, cfg.Defaults.Age)
-			case "pension":
-				assert.Equal(t, tt.expected, cfg.Defaults.Pension)
-			case "student-loan":
-				assert.Equal(t, tt.expected, cfg.Defaults.StudentLoan)
-			case "tax-code":
-				assert.Equal(t, tt.expected, cfg.Defaults.TaxCode)
-			case "extra":
-				assert.Equal(t, tt.expected, cfg.Defaults.Extra)
-			case "year":
-				assert.Equal(t, tt.expected, cfg.Defaults.Year)
-			case "period":
-				assert.Equal(t, tt.expected, cfg.Defaults.Period)
-			case "income":
-				assert.Equal(t, tt.expected, cfg.Defaults.Income)
-			case "married":
-				assert.Equal(t, tt.expected, cfg.Defaults.Married)
-			case "blind":
-				assert.Equal(t, tt.expected, cfg.Defaults.Blind)
-			case "no-ni":
-				assert.Equal(t, tt.expected, cfg.Defaults.NoNI)
-			case "partner-income":
-				assert.Equal(t, tt.expected, cfg.Defaults.PartnerIncome)
-			}
+			assert.Equal(t, tt.expected, tt.actual(cfg.Defaults))
 		})
 	}
 }
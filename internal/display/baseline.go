@@ -0,0 +1,38 @@
+package display
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// FprintBaselineChange writes how a result differs from a pinned baseline,
+// for the period
+func FprintBaselineChange(w io.Writer, resp, baseline *types.TaxResponse, period string, req, baselineReq *types.TaxRequest) {
+	styled := newStyleWriter(w)
+	defer styled.flush()
+	w = styled
+
+	divisor := payperiod.Divisor(period, req.WorkPattern)
+	baselineDivisor := payperiod.Divisor(period, baselineReq.WorkPattern)
+
+	rows := []struct {
+		label     string
+		extractor func(*types.TaxResponse) float64
+	}{
+		{"Gross Salary", func(r *types.TaxResponse) float64 { return r.GrossPay }},
+		{"Tax Paid", func(r *types.TaxResponse) float64 { return r.TaxPaid }},
+		{"National Insurance", func(r *types.TaxResponse) float64 { return r.NationalInsurance }},
+		{"Pension (You)", func(r *types.TaxResponse) float64 { return r.PensionYou }},
+		{"Net Pay", func(r *types.TaxResponse) float64 { return r.NetPay }},
+		{"Total Cost", TotalCost},
+	}
+
+	fmt.Fprintf(w, "Change from baseline - %s\n", payperiod.Label(period))
+	for _, row := range rows {
+		change := row.extractor(resp)/divisor - row.extractor(baseline)/baselineDivisor
+		fmt.Fprintf(w, "  %-25s %18s\n", row.label, formatCurrency(change))
+	}
+}
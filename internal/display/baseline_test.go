@@ -0,0 +1,35 @@
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestFprintBaselineChange(t *testing.T) {
+	req := testutil.CreateSampleTaxRequest()
+	baseline := testutil.CreateSampleTaxResponse()
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.GrossPay = 56000
+		r.NetPay = 41775.84
+	})
+
+	var out bytes.Buffer
+	FprintBaselineChange(&out, resp, baseline, "monthly", req, req)
+
+	assert.Contains(t, out.String(), "Change from baseline - Monthly\n")
+	assert.Contains(t, out.String(), fmt.Sprintf("  %-25s %18s\n", "Gross Salary", "£500.00"))
+	assert.Contains(t, out.String(), fmt.Sprintf("  %-25s %18s\n", "Net Pay", "£290.00"))
+	assert.Contains(t, out.String(), fmt.Sprintf("  %-25s %18s\n", "Tax Paid", "£0.00"))
+}
+
+func TestFormatWholePounds(t *testing.T) {
+	assert.Equal(t, "£45,000", FormatWholePounds(45000))
+	assert.Equal(t, "£1,235", FormatWholePounds(1234.56))
+	assert.Equal(t, "£0", FormatWholePounds(0))
+}
@@ -0,0 +1,269 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// chartPlotHeight is the number of rows in a line plot
+const chartPlotHeight = 10
+
+// chartMinWidth is the narrowest chart drawn, whatever the terminal width
+const chartMinWidth = 40
+
+// ChartStyle is how charts are drawn: the width available and whether to
+// use only ASCII characters
+type ChartStyle struct {
+	Width int
+	ASCII bool
+}
+
+// chartGlyphs are the characters a chart is drawn with
+type chartGlyphs struct {
+	segments  []string
+	line      string
+	point     string
+	axisY     string
+	axisX     string
+	axisAngle string
+}
+
+var unicodeGlyphs = chartGlyphs{
+	segments:  []string{"▓", "▒", "░", "▞", "█"},
+	line:      "•",
+	point:     "●",
+	axisY:     "│",
+	axisX:     "─",
+	axisAngle: "└",
+}
+
+var asciiGlyphs = chartGlyphs{
+	segments:  []string{"=", "-", "~", "+", "#"},
+	line:      ".",
+	point:     "*",
+	axisY:     "|",
+	axisX:     "-",
+	axisAngle: "+",
+}
+
+// glyphs returns the characters for the style
+func (s ChartStyle) glyphs() chartGlyphs {
+	if s.ASCII {
+		return asciiGlyphs
+	}
+	return unicodeGlyphs
+}
+
+// width returns the chart width, no narrower than the minimum
+func (s ChartStyle) width() int {
+	return max(s.Width, chartMinWidth)
+}
+
+// chartSegments are where each pound of gross pay goes, in the order drawn
+var chartSegments = []struct {
+	label     string
+	extractor func(*types.TaxResponse) float64
+}{
+	{"Tax", func(r *types.TaxResponse) float64 { return r.TaxPaid }},
+	{"NI", func(r *types.TaxResponse) float64 { return r.NationalInsurance }},
+	{"Student Loan", func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment }},
+	{"Pension", func(r *types.TaxResponse) float64 { return r.PensionYou }},
+	{"Net Pay", func(r *types.TaxResponse) float64 { return r.NetPay }},
+}
+
+// Chart draws where each pound goes for each result as stacked bars and,
+// when the results have different gross pay, net pay and the marginal rate
+// against gross pay as line plots
+func Chart(results []types.ComparisonResult, period string, style ChartStyle) {
+	if len(results) == 0 {
+		return
+	}
+
+	divisors := comparisonDivisors(results, period)
+	printBreakdownBars(results, divisors, period, style)
+
+	// Plot across the results in order of gross pay
+	points := grossPoints(results, divisors)
+	if len(points) >= 2 {
+		net := make([]chartPoint, len(points))
+		for i, p := range points {
+			net[i] = chartPoint{x: p.x, y: p.net}
+		}
+		printLinePlot(fmt.Sprintf("Net Pay by Gross Salary - %s", payperiod.Label(period)), net, formatCurrency, style)
+	}
+	if marginal := marginalPoints(points); len(marginal) >= 2 {
+		printLinePlot("Marginal Rate by Gross Salary", marginal, formatPercent, style)
+	}
+}
+
+// printBreakdownBars draws one bar per result, split into where each pound
+// goes and scaled to the largest result
+func printBreakdownBars(results []types.ComparisonResult, divisors []float64, period string, style ChartStyle) {
+	glyphs := style.glyphs()
+
+	labelWidth := 0
+	totals := make([]float64, len(results))
+	largest := 0.0
+	for i, result := range results {
+		labelWidth = max(labelWidth, displayWidth(result.Label))
+		for _, segment := range chartSegments {
+			totals[i] += math.Max(0, segment.extractor(result.Response)) / divisors[i]
+		}
+		largest = math.Max(largest, totals[i])
+	}
+	amountWidth := 14
+	barWidth := style.width() - labelWidth - amountWidth - 2
+
+	fmt.Printf("\nWhere Each Pound Goes - %s\n\n", payperiod.Label(period))
+	for i, result := range results {
+		values := make([]float64, len(chartSegments))
+		for j, segment := range chartSegments {
+			values[j] = math.Max(0, segment.extractor(result.Response)) / divisors[i]
+		}
+
+		width := 0
+		if largest > 0 {
+			width = int(math.Round(totals[i] / largest * float64(barWidth)))
+		}
+		bar := stackedBar(values, width, glyphs.segments)
+		fmt.Printf("%s %s%s %s\n", padRight(result.Label, labelWidth), bar,
+			strings.Repeat(" ", barWidth-width), padLeft(formatCurrency(totals[i]), amountWidth))
+	}
+
+	legend := make([]string, len(chartSegments))
+	for i, segment := range chartSegments {
+		legend[i] = glyphs.segments[i] + " " + segment.label
+	}
+	fmt.Printf("\n%s\n", strings.Join(legend, "  "))
+}
+
+// stackedBar draws values as consecutive runs of their glyphs filling width,
+// rounding each boundary so the runs always add up to the width
+func stackedBar(values []float64, width int, glyphs []string) string {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	if total == 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	var bar strings.Builder
+	cumulative, drawn := 0.0, 0
+	for i, value := range values {
+		cumulative += value
+		end := int(math.Round(cumulative / total * float64(width)))
+		bar.WriteString(strings.Repeat(glyphs[i], end-drawn))
+		drawn = end
+	}
+	return bar.String()
+}
+
+// chartPoint is a point on a line plot
+type chartPoint struct {
+	x, y float64
+}
+
+// grossPoint is a result's gross and net pay for the period
+type grossPoint struct {
+	x, net float64
+}
+
+// grossPoints returns each distinct gross pay with its net pay, in order of gross pay
+func grossPoints(results []types.ComparisonResult, divisors []float64) []grossPoint {
+	points := []grossPoint{}
+	seen := map[float64]bool{}
+	for i, result := range results {
+		gross := result.Response.GrossPay / divisors[i]
+		if seen[gross] {
+			continue
+		}
+		seen[gross] = true
+		points = append(points, grossPoint{x: gross, net: result.Response.NetPay / divisors[i]})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].x < points[j].x })
+	return points
+}
+
+// marginalPoints returns the share of each step in gross pay lost before it
+// reaches net pay, at the top of the step
+func marginalPoints(points []grossPoint) []chartPoint {
+	marginal := []chartPoint{}
+	for i := 1; i < len(points); i++ {
+		step := points[i].x - points[i-1].x
+		rate := (1 - (points[i].net-points[i-1].net)/step) * 100
+		marginal = append(marginal, chartPoint{x: points[i].x, y: rate})
+	}
+	return marginal
+}
+
+// printLinePlot draws points joined by straight lines, with the y axis
+// labelled at the top and bottom and the x axis at each end
+func printLinePlot(title string, points []chartPoint, formatY func(float64) string, style ChartStyle) {
+	glyphs := style.glyphs()
+
+	minX, maxX := points[0].x, points[len(points)-1].x
+	minY, maxY := points[0].y, points[0].y
+	for _, p := range points {
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	if maxY == minY {
+		maxY = minY + 1
+	}
+
+	topLabel, bottomLabel := formatY(maxY), formatY(minY)
+	labelWidth := max(displayWidth(topLabel), displayWidth(bottomLabel))
+	plotWidth := style.width() - labelWidth - 2
+
+	// Draw the line into a grid, then the points over it
+	grid := make([][]string, chartPlotHeight)
+	for row := range grid {
+		grid[row] = strings.Split(strings.Repeat(" ", plotWidth), "")
+	}
+	column := func(x float64) int {
+		return int(math.Round((x - minX) / (maxX - minX) * float64(plotWidth-1)))
+	}
+	row := func(y float64) int {
+		return chartPlotHeight - 1 - int(math.Round((y-minY)/(maxY-minY)*float64(chartPlotHeight-1)))
+	}
+	for i := 1; i < len(points); i++ {
+		from, to := column(points[i-1].x), column(points[i].x)
+		for c := from; c <= to; c++ {
+			fraction := 0.0
+			if to > from {
+				fraction = float64(c-from) / float64(to-from)
+			}
+			grid[row(points[i-1].y+fraction*(points[i].y-points[i-1].y))][c] = glyphs.line
+		}
+	}
+	for _, p := range points {
+		grid[row(p.y)][column(p.x)] = glyphs.point
+	}
+
+	fmt.Printf("\n%s\n\n", title)
+	for r, cells := range grid {
+		label := ""
+		switch r {
+		case 0:
+			label = topLabel
+		case chartPlotHeight - 1:
+			label = bottomLabel
+		}
+		fmt.Printf("%s %s%s\n", padLeft(label, labelWidth), glyphs.axisY, strings.TrimRight(strings.Join(cells, ""), " "))
+	}
+	fmt.Printf("%s %s%s\n", strings.Repeat(" ", labelWidth), glyphs.axisAngle, strings.Repeat(glyphs.axisX, plotWidth))
+
+	left, right := formatCurrency(minX), formatCurrency(maxX)
+	gap := max(1, plotWidth-displayWidth(left)-displayWidth(right))
+	fmt.Printf("%s  %s%s%s\n", strings.Repeat(" ", labelWidth), left, strings.Repeat(" ", gap), right)
+}
+
+// formatPercent formats a rate as a percentage to one decimal place
+func formatPercent(rate float64) string {
+	return fmt.Sprintf("%.1f%%", rate)
+}
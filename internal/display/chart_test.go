@@ -0,0 +1,80 @@
+package display
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func chartResults() []types.ComparisonResult {
+	return []types.ComparisonResult{
+		{Label: "Low", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.GrossPay, r.TaxPaid, r.NationalInsurance, r.PensionYou, r.NetPay = 40000, 5486, 2194, 0, 32320
+		})},
+		{Label: "Mid", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.GrossPay, r.TaxPaid, r.NationalInsurance, r.PensionYou, r.NetPay = 60000, 11432, 3310, 0, 45258
+		})},
+		{Label: "High", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.GrossPay, r.TaxPaid, r.NationalInsurance, r.PensionYou, r.NetPay = 100000, 27432, 4110, 0, 68458
+		})},
+	}
+}
+
+func TestChart(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Chart(chartResults(), "yearly", ChartStyle{Width: 80})
+	})
+
+	assert.Contains(t, output, "Where Each Pound Goes - Yearly")
+	assert.Contains(t, output, "▓ Tax  ▒ NI  ░ Student Loan  ▞ Pension  █ Net Pay")
+	assert.Contains(t, output, "Net Pay by Gross Salary - Yearly")
+	assert.Contains(t, output, "Marginal Rate by Gross Salary")
+	assert.Contains(t, output, "£68,458.00 │")
+	assert.Contains(t, output, "42.0% │")
+
+	// Every bar line fits the width, with the largest result filling it
+	for _, line := range strings.Split(output, "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), 80, line)
+	}
+	assert.Regexp(t, `High ▓+▒+█+ +£100,000.00\n`, output)
+}
+
+func TestChart_ASCII(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Chart(chartResults(), "monthly", ChartStyle{Width: 60, ASCII: true})
+	})
+
+	assert.Contains(t, output, "Where Each Pound Goes - Monthly")
+	assert.Contains(t, output, "= Tax  - NI  ~ Student Loan  + Pension  # Net Pay")
+	for _, line := range strings.Split(output, "\n") {
+		for _, r := range line {
+			if r > 127 && r != '£' {
+				t.Fatalf("non-ASCII %q in %q", r, line)
+			}
+		}
+	}
+}
+
+func TestChart_SameGrossSkipsLinePlots(t *testing.T) {
+	results := chartResults()[:1]
+	results = append(results, types.ComparisonResult{Label: "Again", Request: results[0].Request, Response: results[0].Response})
+
+	output := testutil.CaptureStdout(t, func() {
+		Chart(results, "yearly", ChartStyle{Width: 80})
+	})
+	assert.Contains(t, output, "Where Each Pound Goes")
+	assert.NotContains(t, output, "Net Pay by Gross Salary")
+	assert.NotContains(t, output, "Marginal Rate")
+}
+
+func TestStackedBar(t *testing.T) {
+	assert.Equal(t, "aabbbbbbbb", stackedBar([]float64{20, 0, 80}, 10, []string{"a", "x", "b"}))
+	assert.Equal(t, "   ", stackedBar([]float64{0, 0}, 3, []string{"a", "b"}))
+
+	// Rounding never leaves the bar short or long
+	assert.Len(t, stackedBar([]float64{1, 1, 1}, 10, []string{"a", "b", "c"}), 10)
+}
@@ -0,0 +1,193 @@
+package display
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// svgCanvas draws a chart as SVG elements
+type svgCanvas struct {
+	width, height int
+	body          strings.Builder
+}
+
+func newSVGCanvas(width, height int) *svgCanvas {
+	return &svgCanvas{width: width, height: height}
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, fill string) {
+	fmt.Fprintf(&c.body, "  <rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"%s\"/>\n", x, y, w, h, fill)
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2 float64, stroke string) {
+	fmt.Fprintf(&c.body, "  <line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"%s\" stroke-width=\"2\"/>\n", x1, y1, x2, y2, stroke)
+}
+
+func (c *svgCanvas) circle(x, y, r float64, fill string) {
+	fmt.Fprintf(&c.body, "  <circle cx=\"%.1f\" cy=\"%.1f\" r=\"%.1f\" fill=\"%s\"/>\n", x, y, r, fill)
+}
+
+func (c *svgCanvas) text(x, y float64, s string, anchor textAnchor) {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(s))
+	fmt.Fprintf(&c.body, "  <text x=\"%.1f\" y=\"%.1f\" text-anchor=\"%s\">%s</text>\n", x, y, anchor, escaped.String())
+}
+
+// write writes the SVG document
+func (c *svgCanvas) write(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12" fill="%s">
+  <rect width="100%%" height="100%%" fill="#ffffff"/>
+%s</svg>
+`, c.width, c.height, c.width, c.height, chartTextColour, c.body.String())
+	return err
+}
+
+// pngCanvas draws a chart into an image, with lines two pixels wide
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func newPNGCanvas(width, height int) *pngCanvas {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	return &pngCanvas{img: img}
+}
+
+func (c *pngCanvas) rect(x, y, w, h float64, fill string) {
+	bounds := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(c.img, bounds, image.NewUniform(parseHexColour(fill)), image.Point{}, draw.Src)
+}
+
+func (c *pngCanvas) line(x1, y1, x2, y2 float64, stroke string) {
+	colour := parseHexColour(stroke)
+	steps := int(math.Max(math.Abs(x2-x1), math.Abs(y2-y1)))
+	for i := 0; i <= steps; i++ {
+		fraction := 0.0
+		if steps > 0 {
+			fraction = float64(i) / float64(steps)
+		}
+		x := int(math.Round(x1 + fraction*(x2-x1)))
+		y := int(math.Round(y1 + fraction*(y2-y1)))
+		c.img.Set(x, y, colour)
+		c.img.Set(x+1, y, colour)
+		c.img.Set(x, y+1, colour)
+	}
+}
+
+func (c *pngCanvas) circle(x, y, r float64, fill string) {
+	colour := parseHexColour(fill)
+	for py := int(y - r); py <= int(y+r); py++ {
+		for px := int(x - r); px <= int(x+r); px++ {
+			if math.Hypot(float64(px)-x, float64(py)-y) <= r {
+				c.img.Set(px, py, colour)
+			}
+		}
+	}
+}
+
+func (c *pngCanvas) text(x, y float64, s string, anchor textAnchor) {
+	drawer := font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(parseHexColour(chartTextColour)),
+		Face: chartFace,
+	}
+	width := drawer.MeasureString(s).Round()
+	switch anchor {
+	case anchorMiddle:
+		x -= float64(width) / 2
+	case anchorEnd:
+		x -= float64(width)
+	}
+	drawer.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	drawer.DrawString(s)
+}
+
+// write encodes the image as a PNG
+func (c *pngCanvas) write(w io.Writer) error {
+	return png.Encode(w, c.img)
+}
+
+// parseHexColour parses a #rrggbb colour
+func parseHexColour(hex string) color.RGBA {
+	value, _ := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff} // #nosec G115 -- each byte is masked by the conversion
+}
+
+// currencyGlyphs are the currency signs drawn in the 6x13 cell of
+// basicfont.Face7x13, which only covers ASCII
+var currencyGlyphs = []struct {
+	r    rune
+	rows []string
+}{
+	{'£', []string{
+		"......",
+		"......",
+		"..##..",
+		".#..#.",
+		".#....",
+		".#....",
+		"####..",
+		".#....",
+		".#....",
+		".#....",
+		"#####.",
+		"......",
+		"......",
+	}},
+	{'€', []string{
+		"......",
+		"......",
+		"..###.",
+		".#...#",
+		"#.....",
+		"####..",
+		"#.....",
+		"####..",
+		"#.....",
+		".#...#",
+		"..###.",
+		"......",
+		"......",
+	}},
+}
+
+// chartFace is basicfont.Face7x13 with currency signs added, for PNG charts
+var chartFace = newChartFace()
+
+func newChartFace() *basicfont.Face {
+	base := basicfont.Face7x13
+	cellHeight := base.Ascent + base.Descent
+	glyphs := base.Mask.Bounds().Dy() / cellHeight
+
+	mask := image.NewAlpha(image.Rect(0, 0, base.Width, (glyphs+len(currencyGlyphs))*cellHeight))
+	draw.Draw(mask, base.Mask.Bounds(), base.Mask, image.Point{}, draw.Src)
+	ranges := append([]basicfont.Range{}, base.Ranges...)
+	for i, glyph := range currencyGlyphs {
+		top := (glyphs + i) * cellHeight
+		for y, row := range glyph.rows {
+			for x, pixel := range row {
+				if pixel == '#' {
+					mask.SetAlpha(x, top+y, color.Alpha{A: 0xff})
+				}
+			}
+		}
+		ranges = append(ranges, basicfont.Range{Low: glyph.r, High: glyph.r + 1, Offset: glyphs + i})
+	}
+
+	face := *base
+	face.Mask = mask
+	face.Ranges = ranges
+	return &face
+}
@@ -0,0 +1,218 @@
+package display
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Chart image formats
+const (
+	ChartSVG = "svg"
+	ChartPNG = "png"
+)
+
+// Chart image layout, in pixels
+const (
+	chartImageWidth      = 800
+	chartImageMargin     = 20
+	chartBarRowHeight    = 30
+	chartBarHeight       = 20
+	chartImagePlotHeight = 200
+	chartPlotSpacing     = 70
+	chartPlotTicks       = 5
+	chartCharWidth       = 7
+)
+
+// Chart image colours: one per segment, in the order of chartSegments
+var chartSegmentColours = []string{"#d62728", "#ff7f0e", "#9467bd", "#2ca02c", "#1f77b4"}
+
+const (
+	chartTextColour = "#333333"
+	chartAxisColour = "#666666"
+	chartGridColour = "#dddddd"
+	chartLineColour = "#1f77b4"
+)
+
+// chartCanvas is what a chart image is drawn on. Positions are in pixels from
+// the top left, and text is positioned at its baseline
+type chartCanvas interface {
+	rect(x, y, w, h float64, fill string)
+	line(x1, y1, x2, y2 float64, stroke string)
+	circle(x, y, r float64, fill string)
+	text(x, y float64, s string, anchor textAnchor)
+}
+
+// textAnchor is which part of the text is at its position
+type textAnchor string
+
+const (
+	anchorStart  textAnchor = "start"
+	anchorMiddle textAnchor = "middle"
+	anchorEnd    textAnchor = "end"
+)
+
+// ExportChart writes the same charts as Chart as an SVG or PNG image: where
+// each pound goes for each result and, across different gross pay, net pay
+// and the marginal rate
+func ExportChart(w io.Writer, format string, results []types.ComparisonResult, period string) error {
+	if len(results) == 0 {
+		return fmt.Errorf("no results to chart")
+	}
+
+	divisors := comparisonDivisors(results, period)
+	points := grossPoints(results, divisors)
+	marginal := marginalPoints(points)
+
+	plots := 0
+	if len(points) >= 2 {
+		plots++
+	}
+	if len(marginal) >= 2 {
+		plots++
+	}
+	height := chartImageMargin + 30 + len(results)*chartBarRowHeight + 40 + plots*(chartImagePlotHeight+chartPlotSpacing) + chartImageMargin
+
+	switch format {
+	case ChartSVG:
+		canvas := newSVGCanvas(chartImageWidth, height)
+		drawChartImage(canvas, results, divisors, points, marginal, period)
+		return canvas.write(w)
+	case ChartPNG:
+		canvas := newPNGCanvas(chartImageWidth, height)
+		drawChartImage(canvas, results, divisors, points, marginal, period)
+		return canvas.write(w)
+	default:
+		return fmt.Errorf("chart format must be svg or png, got: %s", format)
+	}
+}
+
+// drawChartImage draws the breakdown bars, then the line plots below them
+func drawChartImage(c chartCanvas, results []types.ComparisonResult, divisors []float64, points []grossPoint, marginal []chartPoint, period string) {
+	y := drawBreakdownImage(c, results, divisors, period, chartImageMargin)
+
+	if len(points) >= 2 {
+		net := make([]chartPoint, len(points))
+		for i, p := range points {
+			net[i] = chartPoint{x: p.x, y: p.net}
+		}
+		y = drawPlotImage(c, fmt.Sprintf("Net Pay by Gross Salary - %s", payperiod.Label(period)), net, FormatWholePounds, y)
+	}
+	if len(marginal) >= 2 {
+		drawPlotImage(c, "Marginal Rate by Gross Salary", marginal, formatPercent, y)
+	}
+}
+
+// drawBreakdownImage draws one stacked bar per result scaled to the largest,
+// with a legend, and returns where the next chart starts
+func drawBreakdownImage(c chartCanvas, results []types.ComparisonResult, divisors []float64, period string, top float64) float64 {
+	c.text(chartImageMargin, top+14, fmt.Sprintf("Where Each Pound Goes - %s", payperiod.Label(period)), anchorStart)
+
+	labelWidth := 0.0
+	values := make([][]float64, len(results))
+	totals := make([]float64, len(results))
+	largest := 0.0
+	for i, result := range results {
+		labelWidth = math.Max(labelWidth, float64(len([]rune(result.Label))*chartCharWidth))
+		values[i] = make([]float64, len(chartSegments))
+		for j, segment := range chartSegments {
+			values[i][j] = math.Max(0, segment.extractor(result.Response)) / divisors[i]
+			totals[i] += values[i][j]
+		}
+		largest = math.Max(largest, totals[i])
+	}
+
+	amountWidth := 100.0
+	barLeft := chartImageMargin + labelWidth + 10
+	barWidth := chartImageWidth - chartImageMargin - amountWidth - barLeft
+
+	y := top + 30
+	for i, result := range results {
+		c.text(chartImageMargin, y+15, result.Label, anchorStart)
+		x := barLeft
+		for j, value := range values[i] {
+			if largest == 0 || value == 0 {
+				continue
+			}
+			width := value / largest * barWidth
+			c.rect(x, y, width, chartBarHeight, chartSegmentColours[j])
+			x += width
+		}
+		c.text(chartImageWidth-chartImageMargin, y+15, formatCurrency(totals[i]), anchorEnd)
+		y += chartBarRowHeight
+	}
+
+	// Legend
+	y += 15
+	x := float64(chartImageMargin)
+	for i, segment := range chartSegments {
+		c.rect(x, y-10, 10, 10, chartSegmentColours[i])
+		c.text(x+15, y, segment.label, anchorStart)
+		x += 15 + float64(len(segment.label)*chartCharWidth) + 20
+	}
+	return y + 25
+}
+
+// drawPlotImage draws points joined by lines on axes from zero, with gridlines
+// at even ticks, and returns where the next chart starts
+func drawPlotImage(c chartCanvas, title string, points []chartPoint, formatY func(float64) string, top float64) float64 {
+	c.text(chartImageMargin, top+14, title, anchorStart)
+
+	minX, maxX := points[0].x, points[len(points)-1].x
+	minY, maxY := 0.0, 0.0
+	for _, p := range points {
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	maxY = niceCeil(maxY)
+	minY = -niceCeil(-minY)
+	if maxY == minY {
+		maxY = minY + 1
+	}
+
+	left := float64(chartImageMargin + 80)
+	right := float64(chartImageWidth - chartImageMargin - 40)
+	plotTop := top + 30
+	bottom := plotTop + chartImagePlotHeight
+	toX := func(x float64) float64 { return left + (x-minX)/(maxX-minX)*(right-left) }
+	toY := func(y float64) float64 { return bottom - (y-minY)/(maxY-minY)*(bottom-plotTop) }
+
+	// Gridlines and tick labels
+	for i := 0; i < chartPlotTicks; i++ {
+		fraction := float64(i) / float64(chartPlotTicks-1)
+		y := minY + fraction*(maxY-minY)
+		c.line(left, toY(y), right, toY(y), chartGridColour)
+		c.text(left-8, toY(y)+4, formatY(y), anchorEnd)
+
+		x := minX + fraction*(maxX-minX)
+		c.line(toX(x), bottom, toX(x), bottom+5, chartAxisColour)
+		c.text(toX(x), bottom+20, FormatWholePounds(x), anchorMiddle)
+	}
+	c.line(left, plotTop, left, bottom, chartAxisColour)
+	c.line(left, bottom, right, bottom, chartAxisColour)
+
+	for i := 1; i < len(points); i++ {
+		c.line(toX(points[i-1].x), toY(points[i-1].y), toX(points[i].x), toY(points[i].y), chartLineColour)
+	}
+	for _, p := range points {
+		c.circle(toX(p.x), toY(p.y), 4, chartLineColour)
+	}
+	return bottom + chartPlotSpacing - 30
+}
+
+// niceCeil rounds a positive value up to a round number for the top of an
+// axis: 1, 2, 2.5 or 5 times a power of ten
+func niceCeil(value float64) float64 {
+	if value <= 0 {
+		return 0
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(value)))
+	for _, step := range []float64{1, 2, 2.5, 5, 10} {
+		if value <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
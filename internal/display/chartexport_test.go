@@ -0,0 +1,66 @@
+package display
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestExportChart_SVG(t *testing.T) {
+	results := chartResults()
+	results[0].Label = "Low & Slow"
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportChart(&buf, ChartSVG, results, "monthly"))
+
+	svg := buf.String()
+	assert.Contains(t, svg, `<svg xmlns="http://www.w3.org/2000/svg" width="800" height="740"`)
+	assert.Contains(t, svg, "Where Each Pound Goes - Monthly")
+	assert.Contains(t, svg, "Low &amp; Slow")
+	assert.Contains(t, svg, "£8,333.33")
+	assert.Contains(t, svg, "Net Pay by Gross Salary - Monthly")
+	assert.Contains(t, svg, "Marginal Rate by Gross Salary")
+	for _, colour := range chartSegmentColours {
+		if colour == "#9467bd" {
+			continue // no student loan in the sample results
+		}
+		assert.Contains(t, svg, `fill="`+colour+`"`)
+	}
+}
+
+func TestExportChart_PNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportChart(&buf, ChartPNG, chartResults()[:1], "yearly"))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	// One bar and no line plots, as there is only one gross pay
+	assert.Equal(t, 800, img.Bounds().Dx())
+	assert.Equal(t, 20+30+30+40+20, img.Bounds().Dy())
+}
+
+func TestExportChart_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	assert.EqualError(t, ExportChart(&buf, "pdf", chartResults(), "yearly"), "chart format must be svg or png, got: pdf")
+	assert.EqualError(t, ExportChart(&buf, ChartSVG, nil, "yearly"), "no results to chart")
+}
+
+func TestNiceCeil(t *testing.T) {
+	assert.Equal(t, 0.0, niceCeil(0))
+	assert.Equal(t, 50.0, niceCeil(42))
+	assert.Equal(t, 2500.0, niceCeil(2100))
+	assert.Equal(t, 100000.0, niceCeil(68458))
+	assert.Equal(t, 10.0, niceCeil(10))
+}
+
+func TestChartFace_PoundSign(t *testing.T) {
+	_, mask, _, advance, ok := chartFace.Glyph(fixed.Point26_6{}, '£')
+	assert.True(t, ok)
+	assert.Equal(t, 7, advance.Round())
+	assert.Equal(t, 6, mask.Bounds().Dx())
+}
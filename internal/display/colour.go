@@ -0,0 +1,136 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Colour modes: when to colour output
+const (
+	ColourAuto   = "auto"
+	ColourAlways = "always"
+	ColourNever  = "never"
+)
+
+// ColourModes are the colour mode names, for help text
+var ColourModes = []string{ColourAuto, ColourAlways, ColourNever}
+
+// colourRole is what a coloured value means
+type colourRole int
+
+const (
+	roleNone colourRole = iota
+	roleHighlight
+	roleDim
+	roleBetter
+	roleWorse
+)
+
+// colourTheme is the ANSI escape sequence for each role: net pay in bold,
+// deductions dimmed, and the best and worst options in green and red
+var colourTheme = map[colourRole]string{
+	roleHighlight: "\x1b[1m",
+	roleDim:       "\x1b[2m",
+	roleBetter:    "\x1b[32m",
+	roleWorse:     "\x1b[31m",
+}
+
+const colourReset = "\x1b[0m"
+
+// colourEnabled is whether output is coloured
+var colourEnabled bool
+
+// ValidateColourMode checks a colour mode name
+func ValidateColourMode(mode string) error {
+	for _, valid := range ColourModes {
+		if mode == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("color must be one of %s, got: %s", strings.Join(ColourModes, ", "), mode)
+}
+
+// SetColour turns coloured output on or off
+func SetColour(enabled bool) {
+	colourEnabled = enabled
+}
+
+// ColourEnabled reports whether output is coloured
+func ColourEnabled() bool {
+	return colourEnabled
+}
+
+// colourise wraps text in the colour for its role. Plain output is never
+// coloured, as its escape sequences would be read out or logged
+func colourise(text string, role colourRole) string {
+	if !colourEnabled || role == roleNone || outputStyle == StylePlain {
+		return text
+	}
+	return colourTheme[role] + text + colourReset
+}
+
+// Which way is better for a comparison row's values
+const (
+	higherIsBetter = 1
+	lowerIsBetter  = -1
+)
+
+// comparisonDirections are the rows whose best and worst options are
+// coloured, and which way is better. Rows that are neither, such as pension
+// contributions and employer costs, are left alone
+var comparisonDirections = map[string]int{
+	"Gross Salary":        higherIsBetter,
+	"Tax Free Allowance":  higherIsBetter,
+	"Pension Claimback":   higherIsBetter,
+	"Net Pay":             higherIsBetter,
+	"Real Gross Salary":   higherIsBetter,
+	"Real Net Pay":        higherIsBetter,
+	"Tax Paid":            lowerIsBetter,
+	"Total Tax":           lowerIsBetter,
+	"Basic Rate Tax":      lowerIsBetter,
+	"Higher Rate Tax":     lowerIsBetter,
+	"Additional Rate Tax": lowerIsBetter,
+	"National Insurance":  lowerIsBetter,
+	"Student Loan":        lowerIsBetter,
+}
+
+// comparisonLabelRoles are the rows whose labels are highlighted or dimmed
+var comparisonLabelRoles = map[string]colourRole{
+	"Net Pay":            roleHighlight,
+	"Tax Paid":           roleDim,
+	"National Insurance": roleDim,
+	"Student Loan":       roleDim,
+	"Pension (You)":      roleDim,
+}
+
+// rankRoles returns the colour of each value in a comparison row: the best
+// in green and the worst in red, or none when the row has no better way or
+// every value is the same
+func rankRoles(fieldName string, values []float64) []colourRole {
+	roles := make([]colourRole, len(values))
+	direction, ok := comparisonDirections[fieldName]
+	if !ok || len(values) < 2 {
+		return roles
+	}
+
+	// Compare to the penny shown, so rounding differences are not ranked
+	scores := make([]float64, len(values))
+	for i, value := range values {
+		scores[i] = math.Round(value*100) * float64(direction)
+	}
+	best, worst := slices.Max(scores), slices.Min(scores)
+	if best == worst {
+		return roles
+	}
+	for i, score := range scores {
+		switch score {
+		case best:
+			roles[i] = roleBetter
+		case worst:
+			roles[i] = roleWorse
+		}
+	}
+	return roles
+}
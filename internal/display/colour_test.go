@@ -0,0 +1,85 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// useColour turns coloured output on for a test
+func useColour(t *testing.T) {
+	t.Helper()
+	SetColour(true)
+	t.Cleanup(func() { SetColour(false) })
+}
+
+func TestValidateColourMode(t *testing.T) {
+	assert.NoError(t, ValidateColourMode("always"))
+	assert.EqualError(t, ValidateColourMode("sometimes"), "color must be one of auto, always, never, got: sometimes")
+}
+
+func TestColourise(t *testing.T) {
+	assert.Equal(t, "Net Pay", colourise("Net Pay", roleHighlight))
+
+	useColour(t)
+	assert.Equal(t, "\x1b[1mNet Pay\x1b[0m", colourise("Net Pay", roleHighlight))
+	assert.Equal(t, "Net Pay", colourise("Net Pay", roleNone))
+
+	useStyle(t, StylePlain)
+	assert.Equal(t, "Net Pay", colourise("Net Pay", roleHighlight))
+}
+
+func TestRankRoles(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldName string
+		values    []float64
+		expected  []colourRole
+	}{
+		{"higher is better", "Net Pay", []float64{100, 300, 200}, []colourRole{roleWorse, roleBetter, roleNone}},
+		{"lower is better", "Tax Paid", []float64{100, 300, 200}, []colourRole{roleBetter, roleWorse, roleNone}},
+		{"ties share a colour", "Net Pay", []float64{300, 100, 300, 200}, []colourRole{roleBetter, roleWorse, roleBetter, roleNone}},
+		{"all the same", "Net Pay", []float64{100, 100.001}, []colourRole{roleNone, roleNone}},
+		{"not ranked", "Pension (You)", []float64{100, 300}, []colourRole{roleNone, roleNone}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rankRoles(tt.fieldName, tt.values))
+		})
+	}
+}
+
+func TestComparison_Colour(t *testing.T) {
+	useColour(t)
+	results := []types.ComparisonResult{
+		{Label: "Current", Response: testutil.CreateSampleTaxResponse(), Request: testutil.CreateSampleTaxRequest()},
+		{Label: "Raise", Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.NetPay = 40000
+			r.TaxPaid = 8000
+		}), Request: testutil.CreateSampleTaxRequest()},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(results, "yearly", false)
+	})
+
+	assert.Contains(t, output, "\x1b[1mNet Pay             \x1b[0m")
+	assert.Contains(t, output, "\x1b[31m  £38,295.84\x1b[0m ║ \x1b[32m  £40,000.00\x1b[0m")
+	assert.Contains(t, output, "\x1b[2mTax Paid            \x1b[0m")
+}
+
+func TestSummary_Colour(t *testing.T) {
+	useColour(t)
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(testutil.CreateSampleTaxResponse(), "yearly", testutil.CreateSampleTaxRequest())
+	})
+
+	// Padding is inside the colour, so the box still lines up
+	assert.Contains(t, output, "║ \x1b[1mNet Pay                  \x1b[0m \x1b[1m        £38,295.84\x1b[0m ║")
+	assert.Contains(t, output, "║ \x1b[2mNational Insurance       \x1b[0m")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
 	"github.com/mheap/listentotaxman-cli/internal/types"
 )
 
@@ -15,21 +16,17 @@ const (
 
 // Comparison displays a comparison table for multiple tax calculations
 func Comparison(results []types.ComparisonResult, period string, verbose bool) {
-	divisor := getPeriodDivisor(period)
+	divisors := comparisonDivisors(results, period)
 
 	// Calculate table dimensions
 	numOptions := len(results)
 	fieldColWidth := 20
 	valueColWidth := 12
 
-	// Truncate labels if needed (max 11 chars to fit in 12-char column)
+	// Truncate labels if needed (max 11 columns to fit in 12-column column)
 	labels := make([]string, numOptions)
 	for i, result := range results {
-		if len(result.Label) > 11 {
-			labels[i] = result.Label[:10] + "…"
-		} else {
-			labels[i] = result.Label
-		}
+		labels[i] = truncateWidth(result.Label, 11)
 	}
 
 	// Generate borders
@@ -39,17 +36,18 @@ func Comparison(results []types.ComparisonResult, period string, verbose bool) {
 	bottomBorder := generateBorder(numOptions, fieldColWidth, valueColWidth, "bottom")
 
 	// Print header
-	fmt.Println()
-	fmt.Println(topBorder)
+	fmt.Fprintln(stdout)
+	printCurrencyNote()
+	fmt.Fprintln(stdout, topBorder)
 
 	// Print label row
-	fmt.Print("║ ")
-	fmt.Printf("%-*s", fieldColWidth, "Field")
+	fmt.Fprint(stdout, "║ ")
+	fmt.Fprintf(stdout, "%-*s", fieldColWidth, "Field")
 	for _, label := range labels {
-		fmt.Print(" ║ ")
-		fmt.Printf("%-*s", valueColWidth, label)
+		fmt.Fprint(stdout, " ║ ")
+		fmt.Fprint(stdout, padRight(label, valueColWidth))
 	}
-	fmt.Println(" ║")
+	fmt.Fprintln(stdout, " ║")
 
 	// Print status row if any option has status flags
 	hasStatus := false
@@ -72,121 +70,203 @@ func Comparison(results []types.ComparisonResult, period string, verbose bool) {
 	}
 
 	if hasStatus {
-		fmt.Print("║ ")
-		fmt.Printf("%-*s", fieldColWidth, "Status")
+		fmt.Fprint(stdout, "║ ")
+		fmt.Fprintf(stdout, "%-*s", fieldColWidth, "Status")
 		for _, status := range statusLines {
-			fmt.Print(" ║ ")
-			fmt.Printf("%-*s", valueColWidth, status)
+			fmt.Fprint(stdout, " ║ ")
+			fmt.Fprintf(stdout, "%-*s", valueColWidth, status)
 		}
-		fmt.Println(" ║")
+		fmt.Fprintln(stdout, " ║")
 	}
 
-	fmt.Println(midBorder)
+	printRequestTextRows(results, fieldColWidth, valueColWidth)
+
+	fmt.Fprintln(stdout, midBorder)
 
 	// Print fields
 	if verbose {
-		printComparisonFieldVerbose(results, divisor, fieldColWidth, valueColWidth)
+		printComparisonFieldVerbose(results, divisors, fieldColWidth, valueColWidth)
 	} else {
-		printComparisonFieldSummary(results, divisor, fieldColWidth, valueColWidth)
+		printComparisonFieldSummary(results, divisors, fieldColWidth, valueColWidth)
 	}
+	printRealTermsRows(results, divisors, fieldColWidth, valueColWidth)
 
 	// Print separator before employer costs
-	fmt.Println(sepBorder)
+	fmt.Fprintln(stdout, sepBorder)
 
 	// Employer costs
-	printComparisonRow("Employer's NI", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Employer's NI", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.EmployersNI })
-	printComparisonRow("Pension (HMRC)", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Pension (HMRC)", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.PensionHMRC })
+	printEmployerPensionRow(results, divisors, fieldColWidth, valueColWidth)
 
 	// Total cost
-	printComparisonRow("Total Cost", results, divisor, fieldColWidth, valueColWidth,
-		func(r *types.TaxResponse) float64 { return r.GrossPay + r.EmployersNI + r.PensionHMRC })
+	printComparisonRow("Total Cost", results, divisors, fieldColWidth, valueColWidth, TotalCost)
+
+	fmt.Fprintln(stdout, bottomBorder)
+	printRealTermsNote(results)
+	fmt.Fprintln(stdout)
+}
 
-	fmt.Println(bottomBorder)
-	fmt.Println()
+// comparisonDivisors returns each option's period divisor. Options share the
+// display period, but a part-time option works fewer days and hours.
+func comparisonDivisors(results []types.ComparisonResult, period string) []float64 {
+	divisors := make([]float64, len(results))
+	for i, result := range results {
+		pattern := types.WorkPattern{}
+		if result.Request != nil {
+			pattern = result.Request.WorkPattern
+		}
+		divisors[i] = payperiod.Divisor(period, pattern)
+	}
+	return divisors
 }
 
 // printComparisonFieldSummary prints summary fields (non-verbose mode)
-func printComparisonFieldSummary(results []types.ComparisonResult, divisor float64, fieldColWidth, valueColWidth int) {
-	printComparisonRow("Gross Salary", results, divisor, fieldColWidth, valueColWidth,
+func printComparisonFieldSummary(results []types.ComparisonResult, divisors []float64, fieldColWidth, valueColWidth int) {
+	printComparisonRow("Gross Salary", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.GrossPay })
-	printComparisonRow("Tax Paid", results, divisor, fieldColWidth, valueColWidth,
+	printOtherIncomeRow(results, divisors, fieldColWidth, valueColWidth)
+	printComparisonRow("Tax Paid", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.TaxPaid })
-	printComparisonRow("National Insurance", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("National Insurance", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.NationalInsurance })
-	printComparisonRow("Student Loan", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Student Loan", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment })
-	printComparisonRow("Pension (You)", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Pension (You)", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.PensionYou })
-	printComparisonRow("Net Pay", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Net Pay", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.NetPay })
 }
 
 // printComparisonFieldVerbose prints all fields (verbose mode)
-func printComparisonFieldVerbose(results []types.ComparisonResult, divisor float64, fieldColWidth, valueColWidth int) {
+func printComparisonFieldVerbose(results []types.ComparisonResult, divisors []float64, fieldColWidth, valueColWidth int) {
 	// Income section
-	printComparisonRow("Gross Salary", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Gross Salary", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.GrossPay })
-	printComparisonRow("Additional Gross", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Additional Gross", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.AdditionalGross })
-	printComparisonRow("Tax Free Allowance", results, divisor, fieldColWidth, valueColWidth,
+	printOtherIncomeRow(results, divisors, fieldColWidth, valueColWidth)
+	printComparisonRow("Tax Free Allowance", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.TaxFreeAllowance })
-	printComparisonRow("Taxable Pay", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Taxable Pay", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.TaxablePay })
 
 	// Tax breakdown
-	printComparisonRow("Basic Rate Tax", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Basic Rate Tax", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 {
 			if bracket, ok := r.TaxDue["0"]; ok {
 				return bracket.Amount
 			}
 			return 0
 		})
-	printComparisonRow("Higher Rate Tax", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Higher Rate Tax", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 {
 			if bracket, ok := r.TaxDue["1"]; ok {
 				return bracket.Amount
 			}
 			return 0
 		})
-	printComparisonRow("Additional Rate Tax", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Additional Rate Tax", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 {
 			if bracket, ok := r.TaxDue["2"]; ok {
 				return bracket.Amount
 			}
 			return 0
 		})
-	printComparisonRow("Total Tax", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Total Tax", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.TaxPaid })
 
 	// Deductions
-	printComparisonRow("National Insurance", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("National Insurance", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.NationalInsurance })
-	printComparisonRow("Student Loan", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Student Loan", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment })
-	printComparisonRow("Pension (You)", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Pension (You)", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.PensionYou })
-	printComparisonRow("Pension Claimback", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Pension Claimback", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.PensionClaimback })
 
 	// Net pay
-	printComparisonRow("Net Pay", results, divisor, fieldColWidth, valueColWidth,
+	printComparisonRow("Net Pay", results, divisors, fieldColWidth, valueColWidth,
 		func(r *types.TaxResponse) float64 { return r.NetPay })
 }
 
-// printComparisonRow prints a single comparison row
-func printComparisonRow(fieldName string, results []types.ComparisonResult, divisor float64, fieldColWidth, valueColWidth int, extractor func(*types.TaxResponse) float64) {
-	fmt.Print("║ ")
-	fmt.Printf("%-*s", fieldColWidth, fieldName)
+// printOtherIncomeRow prints the other income row when any option has rental, savings or dividend income
+func printOtherIncomeRow(results []types.ComparisonResult, divisors []float64, fieldColWidth, valueColWidth int) {
+	for _, result := range results {
+		if result.Response.OtherIncome != nil {
+			printComparisonRow("Other Income", results, divisors, fieldColWidth, valueColWidth, otherIncomeTotal)
+			return
+		}
+	}
+}
 
+// printRequestTextRows prints the rate of pay and part-time rows when any
+// option's salary was entered as a rate or pro rata
+func printRequestTextRows(results []types.ComparisonResult, fieldColWidth, valueColWidth int) {
+	rates := make([]string, len(results))
+	proRatas := make([]string, len(results))
+	for i, result := range results {
+		if result.Request == nil {
+			continue
+		}
+		if result.Request.Rate != nil {
+			rates[i] = rateLabel(result.Request.Rate)
+		}
+		if result.Request.ProRata != nil {
+			proRatas[i] = proRataLabel(result.Request.ProRata)
+		}
+	}
+
+	printComparisonText("Rate", rates, fieldColWidth, valueColWidth)
+	printComparisonText("Pro Rata", proRatas, fieldColWidth, valueColWidth)
+}
+
+// printComparisonText prints a row of text values, unless every value is empty
+func printComparisonText(fieldName string, values []string, fieldColWidth, valueColWidth int) {
+	if strings.Join(values, "") == "" {
+		return
+	}
+
+	fmt.Fprint(stdout, "║ ")
+	fmt.Fprintf(stdout, "%-*s", fieldColWidth, fieldName)
+	for _, value := range values {
+		fmt.Fprint(stdout, " ║ ")
+		fmt.Fprint(stdout, padRight(truncateWidth(value, valueColWidth), valueColWidth))
+	}
+	fmt.Fprintln(stdout, " ║")
+}
+
+// printEmployerPensionRow prints the employer pension row when any option has an employer contribution
+func printEmployerPensionRow(results []types.ComparisonResult, divisors []float64, fieldColWidth, valueColWidth int) {
 	for _, result := range results {
-		value := extractor(result.Response) / divisor
-		fmt.Print(" ║ ")
-		fmt.Printf("%*s", valueColWidth, formatCurrency(value))
+		if employerPension(result.Response) > 0 {
+			printComparisonRow("Pension (Employer)", results, divisors, fieldColWidth, valueColWidth, employerPension)
+			return
+		}
 	}
+}
 
-	fmt.Println(" ║")
+// printComparisonRow prints a single comparison row, with the best option in
+// green and the worst in red when colour is on
+func printComparisonRow(fieldName string, results []types.ComparisonResult, divisors []float64, fieldColWidth, valueColWidth int, extractor func(*types.TaxResponse) float64) {
+	values := make([]float64, len(results))
+	for i, result := range results {
+		values[i] = extractor(result.Response) / divisors[i]
+	}
+	roles := rankRoles(fieldName, values)
+
+	fmt.Fprint(stdout, "║ ")
+	fmt.Fprint(stdout, colourise(padRight(fieldName, fieldColWidth), comparisonLabelRoles[fieldName]))
+
+	for i, value := range values {
+		fmt.Fprint(stdout, " ║ ")
+		fmt.Fprint(stdout, colourise(padLeft(formatCurrency(value), valueColWidth), roles[i]))
+	}
+
+	fmt.Fprintln(stdout, " ║")
 }
 
 // generateBorder generates a table border based on the number of options
@@ -220,61 +300,86 @@ func generateBorder(numOptions, fieldColWidth, valueColWidth int, borderType str
 
 // ComparisonJSON displays comparison results as a JSON comparison object
 func ComparisonJSON(results []types.ComparisonResult, period string) {
-	divisor := getPeriodDivisor(period)
+	// Marshal to JSON
+	jsonData, err := json.MarshalIndent(ComparisonOutput(results, period), "", "  ")
+	if err != nil {
+		fmt.Fprintf(stdout, "Error marshalling JSON: %v\n", err)
+		return
+	}
+
+	fmt.Fprintln(stdout, string(jsonData))
+}
+
+// ComparisonOutput builds the structured comparison: each field by option
+// label, divided into the period, and each option's metadata
+func ComparisonOutput(results []types.ComparisonResult, period string) map[string]interface{} {
+	divisors := comparisonDivisors(results, period)
 
 	// Build comparison object structure
 	output := map[string]interface{}{
 		"period":     period,
-		"comparison": buildComparisonFields(results, divisor),
-		"metadata":   buildMetadata(results),
+		"comparison": buildComparisonFields(results, divisors),
+		"metadata":   buildMetadata(results, divisors),
 	}
-
-	// Marshal to JSON
-	jsonData, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		fmt.Printf("Error marshalling JSON: %v\n", err)
-		return
+	if len(results) > 0 && results[0].Request != nil {
+		if payDays := payperiod.PayDaysFor(period, results[0].Request.WorkPattern); payDays > 0 {
+			output["pay_days"] = payDays
+		}
 	}
-
-	fmt.Println(string(jsonData))
+	return output
 }
 
 // buildComparisonFields builds the comparison object with all fields
-func buildComparisonFields(results []types.ComparisonResult, divisor float64) map[string]map[string]float64 {
+func buildComparisonFields(results []types.ComparisonResult, divisors []float64) map[string]map[string]float64 {
 	fields := map[string]map[string]float64{
-		"gross_pay":                   extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.GrossPay }),
-		"taxable_pay":                 extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.TaxablePay }),
-		"additional_gross":            extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.AdditionalGross }),
-		"tax_free_allowance":          extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.TaxFreeAllowance }),
-		"tax_paid":                    extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.TaxPaid }),
-		"national_insurance":          extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.NationalInsurance }),
-		"student_loan":                extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment }),
-		"pension_you":                 extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.PensionYou }),
-		"pension_hmrc":                extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.PensionHMRC }),
-		"pension_claimback":           extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.PensionClaimback }),
-		"net_pay":                     extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.NetPay }),
-		"employers_ni":                extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.EmployersNI }),
-		"total_cost":                  extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.GrossPay + r.EmployersNI + r.PensionHMRC }),
-		"gross_sacrifice":             extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.GrossSacrifice }),
-		"childcare_amount":            extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.ChildcareAmount }),
-		"tax_free_married":            extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.TaxFreeMarried }),
-		"tax_free_marriage_allowance": extractField(results, divisor, func(r *types.TaxResponse) float64 { return r.TaxFreeMarriageAllowance }),
+		"gross_pay":                   extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.GrossPay }),
+		"taxable_pay":                 extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.TaxablePay }),
+		"additional_gross":            extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.AdditionalGross }),
+		"tax_free_allowance":          extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.TaxFreeAllowance }),
+		"tax_paid":                    extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.TaxPaid }),
+		"national_insurance":          extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.NationalInsurance }),
+		"student_loan":                extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment }),
+		"pension_you":                 extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.PensionYou }),
+		"pension_hmrc":                extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.PensionHMRC }),
+		"pension_claimback":           extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.PensionClaimback }),
+		"net_pay":                     extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.NetPay }),
+		"employers_ni":                extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.EmployersNI }),
+		"total_cost":                  extractField(results, divisors, TotalCost),
+		"pension_employer":            extractField(results, divisors, employerPension),
+		"employee_ni_saving":          extractField(results, divisors, employeeNISaving),
+		"gross_sacrifice":             extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.GrossSacrifice }),
+		"childcare_amount":            extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.ChildcareAmount }),
+		"tax_free_married":            extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.TaxFreeMarried }),
+		"tax_free_marriage_allowance": extractField(results, divisors, func(r *types.TaxResponse) float64 { return r.TaxFreeMarriageAllowance }),
+		"other_income":                extractField(results, divisors, otherIncomeTotal),
+		"other_income_tax": extractField(results, divisors, func(r *types.TaxResponse) float64 {
+			if r.OtherIncome == nil {
+				return 0
+			}
+			return r.OtherIncome.Tax
+		}),
+	}
+
+	// Add gross and net pay in constant prices
+	if realBaseYear(results) != 0 {
+		fields["real_gross_pay"] = extractField(results, divisors, realGrossPay)
+		fields["real_net_pay"] = extractField(results, divisors, realNetPay)
 	}
 
 	// Add tax brackets
-	fields["basic_rate_tax"] = extractField(results, divisor, func(r *types.TaxResponse) float64 {
+	fields["basic_rate_tax"] = extractField(results, divisors, func(r *types.TaxResponse) float64 {
 		if bracket, ok := r.TaxDue["0"]; ok {
 			return bracket.Amount
 		}
 		return 0
 	})
-	fields["higher_rate_tax"] = extractField(results, divisor, func(r *types.TaxResponse) float64 {
+	fields["higher_rate_tax"] = extractField(results, divisors, func(r *types.TaxResponse) float64 {
 		if bracket, ok := r.TaxDue["1"]; ok {
 			return bracket.Amount
 		}
 		return 0
 	})
-	fields["additional_rate_tax"] = extractField(results, divisor, func(r *types.TaxResponse) float64 {
+	fields["additional_rate_tax"] = extractField(results, divisors, func(r *types.TaxResponse) float64 {
 		if bracket, ok := r.TaxDue["2"]; ok {
 			return bracket.Amount
 		}
@@ -285,26 +390,38 @@ func buildComparisonFields(results []types.ComparisonResult, divisor float64) ma
 }
 
 // buildMetadata builds metadata section with tax year, region, code per option
-func buildMetadata(results []types.ComparisonResult) map[string]map[string]interface{} {
+func buildMetadata(results []types.ComparisonResult, divisors []float64) map[string]map[string]interface{} {
 	metadata := make(map[string]map[string]interface{})
 
-	for _, result := range results {
+	for i, result := range results {
 		metadata[result.Label] = map[string]interface{}{
 			"tax_year":   result.Response.TaxYear,
 			"tax_region": result.Response.TaxRegion,
 			"tax_code":   result.Response.TaxCode,
 		}
+		if result.Request != nil && result.Request.Rate != nil {
+			metadata[result.Label]["rate"] = result.Request.Rate
+		}
+		if result.Request != nil && result.Request.ProRata != nil {
+			metadata[result.Label]["pro_rata"] = result.Request.ProRata
+		}
+		if result.Response.Real != nil {
+			metadata[result.Label]["real"] = result.Response.Real
+		}
+		if converted := DisplayCurrency(result.Response, divisors[i]); converted != nil {
+			metadata[result.Label]["display_currency"] = converted
+		}
 	}
 
 	return metadata
 }
 
-// extractField extracts a specific field from all results and applies divisor
-func extractField(results []types.ComparisonResult, divisor float64, extractor func(*types.TaxResponse) float64) map[string]float64 {
+// extractField extracts a specific field from all results and applies each option's divisor
+func extractField(results []types.ComparisonResult, divisors []float64, extractor func(*types.TaxResponse) float64) map[string]float64 {
 	field := make(map[string]float64)
 
-	for _, result := range results {
-		field[result.Label] = extractor(result.Response) / divisor
+	for i, result := range results {
+		field[result.Label] = extractor(result.Response) / divisors[i]
 	}
 
 	return field
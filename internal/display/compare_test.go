@@ -92,7 +92,7 @@ func TestExtractField(t *testing.T) {
 		},
 	}
 
-	field := extractField(results, 12.0, func(r *types.TaxResponse) float64 {
+	field := extractField(results, []float64{12.0, 12.0}, func(r *types.TaxResponse) float64 {
 		return r.NetPay
 	})
 
@@ -115,7 +115,7 @@ func TestBuildComparisonFields(t *testing.T) {
 		},
 	}
 
-	fields := buildComparisonFields(results, 1.0)
+	fields := buildComparisonFields(results, []float64{1.0, 1.0})
 
 	// Verify all expected fields exist
 	expectedFields := []string{
@@ -157,7 +157,7 @@ func TestBuildMetadata(t *testing.T) {
 		},
 	}
 
-	metadata := buildMetadata(results)
+	metadata := buildMetadata(results, []float64{1, 1})
 
 	assert.Equal(t, 2, len(metadata))
 	assert.Contains(t, metadata, "Option1")
@@ -253,6 +253,28 @@ func TestComparison_Verbose(t *testing.T) {
 	assert.Contains(t, output, "Basic Rate Tax")
 }
 
+func TestComparisonJSON_PayDays(t *testing.T) {
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.WorkPattern.PayDays = 53
+	})
+	results := []types.ComparisonResult{
+		{Label: "Option1", Request: req, Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) { r.NetPay = 53000.0 })},
+		{Label: "Option2", Request: req, Response: testutil.CreateSampleTaxResponse()},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		ComparisonJSON(results, "weekly")
+	})
+
+	var parsed map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &parsed))
+	assert.Equal(t, "weekly", parsed["period"])
+	assert.InDelta(t, 53.0, parsed["pay_days"], 0.001)
+
+	netPay := parsed["comparison"].(map[string]interface{})["net_pay"].(map[string]interface{})
+	assert.InDelta(t, 1000.0, netPay["Option1"], 0.001)
+}
+
 func TestComparisonJSON(t *testing.T) {
 	results := []types.ComparisonResult{
 		{
@@ -281,6 +303,7 @@ func TestComparisonJSON(t *testing.T) {
 	assert.Equal(t, "monthly", parsed["period"])
 	assert.Contains(t, parsed, "comparison")
 	assert.Contains(t, parsed, "metadata")
+	assert.NotContains(t, parsed, "pay_days")
 
 	// Verify comparison fields
 	comparison := parsed["comparison"].(map[string]interface{})
@@ -310,7 +333,7 @@ func TestPrintComparisonRow(t *testing.T) {
 	}
 
 	output := testutil.CaptureStdout(t, func() {
-		printComparisonRow("Test Field", results, 1.0, 20, 12,
+		printComparisonRow("Test Field", results, []float64{1.0, 1.0}, 20, 12,
 			func(r *types.TaxResponse) float64 { return r.GrossPay })
 	})
 
@@ -374,3 +397,84 @@ func TestComparison_LabelTruncation(t *testing.T) {
 	// May or may not truncate depending on label column width, just verify it doesn't crash
 	assert.NotEmpty(t, output)
 }
+
+func TestComparison_OtherIncome(t *testing.T) {
+	results := []types.ComparisonResult{
+		{
+			Label:    "Salary",
+			Request:  testutil.CreateSampleTaxRequest(),
+			Response: testutil.CreateSampleTaxResponse(),
+		},
+		{
+			Label:   "Investor",
+			Request: testutil.CreateSampleTaxRequest(),
+			Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+				r.OtherIncome = &types.OtherIncome{Dividends: 5000.0, Tax: 1000.0}
+			}),
+		},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(results, "yearly", false)
+	})
+	assert.Contains(t, output, "Other Income")
+	assert.Contains(t, output, "£5,000.00")
+
+	fields := buildComparisonFields(results, []float64{1.0, 1.0})
+	assert.InDelta(t, 5000.0, fields["other_income"]["Investor"], 0.01)
+	assert.InDelta(t, 1000.0, fields["other_income_tax"]["Investor"], 0.01)
+	assert.InDelta(t, 0.0, fields["other_income_tax"]["Salary"], 0.01)
+
+	// Without other income the row is omitted
+	output = testutil.CaptureStdout(t, func() {
+		Comparison(results[:1], "yearly", true)
+	})
+	assert.NotContains(t, output, "Other Income")
+}
+
+func TestComparison_Rate(t *testing.T) {
+	contract := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Rate = &types.IncomeRate{Rate: 450, Per: "day", UnitsPerYear: 235, AnnualIncome: 105750}
+	})
+	results := []types.ComparisonResult{
+		{Label: "Contract", Request: contract, Response: testutil.CreateSampleTaxResponse()},
+		{Label: "Permanent", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse()},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(results, "yearly", false)
+	})
+	assert.Contains(t, output, "║ Rate                 ║ £450/day     ║              ║")
+
+	output = testutil.CaptureStdout(t, func() {
+		ComparisonJSON(results, "yearly")
+	})
+
+	var parsed map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &parsed))
+	metadata := parsed["metadata"].(map[string]interface{})
+	rate := metadata["Contract"].(map[string]interface{})["rate"].(map[string]interface{})
+	assert.InDelta(t, 450.0, rate["rate"], 0.001)
+	assert.Equal(t, "day", rate["per"])
+	assert.InDelta(t, 105750.0, rate["annual_income"], 0.001)
+	assert.NotContains(t, metadata["Permanent"], "rate")
+}
+
+func TestComparison_ProRata(t *testing.T) {
+	fourDays := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.ProRata = &types.ProRata{FTE: 0.8, Days: 4, FullTimeIncome: 60000, Income: 48000}
+		r.WorkPattern = types.WorkPattern{HoursPerWeek: 32, DaysPerWeek: 4}
+	})
+	results := []types.ComparisonResult{
+		{Label: "Five Days", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) { r.GrossPay = 60000.0 })},
+		{Label: "Four Days", Request: fourDays, Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) { r.GrossPay = 48000.0 })},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(results, "hourly", false)
+	})
+	assert.Contains(t, output, "║ Pro Rata             ║              ║ 4 Days/Week  ║")
+
+	// Each option divides by its own hours, so the hourly rate is the same
+	assert.Contains(t, output, "║ Gross Salary         ║       £28.85 ║       £28.85 ║")
+}
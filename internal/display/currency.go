@@ -0,0 +1,115 @@
+package display
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// BaseCurrency is the currency amounts are calculated in
+const BaseCurrency = "GBP"
+
+// CurrencyFormat is how amounts are written. Amounts are always calculated in
+// pounds and only converted to the display currency when written
+type CurrencyFormat struct {
+	// Decimals is the number of decimal places: 0 or 2
+	Decimals int
+	// NoSymbol leaves out the currency symbol
+	NoSymbol bool
+	// ThousandsSeparator separates groups of thousands, or "" for none. With
+	// "." the decimal separator is ","
+	ThousandsSeparator string
+	// Currency is the ISO 4217 code of the display currency
+	Currency string
+	// Rate is how many of the display currency make one pound
+	Rate float64
+}
+
+// DefaultCurrencyFormat writes pounds and pence with commas, e.g. £45,000.00
+var DefaultCurrencyFormat = CurrencyFormat{Decimals: 2, ThousandsSeparator: ",", Currency: BaseCurrency, Rate: 1}
+
+// currencyFormat is the format every amount is written in
+var currencyFormat = DefaultCurrencyFormat
+
+// currencySymbols are the symbols written before amounts in each currency.
+// Other currencies are written with their code, e.g. CHF 1,000.00
+var currencySymbols = map[string]string{
+	"GBP": "£",
+	"EUR": "€",
+	"USD": "$",
+}
+
+// SetCurrencyFormat sets how amounts are written
+func SetCurrencyFormat(format CurrencyFormat) {
+	currencyFormat = format
+}
+
+// converting reports whether amounts are converted from pounds
+func converting() bool {
+	return currencyFormat.Currency != BaseCurrency
+}
+
+// currencySymbol returns what is written before an amount. The ASCII style
+// leaves out symbols outside ASCII, as it always has the pound sign
+func currencySymbol() string {
+	if currencyFormat.NoSymbol {
+		return ""
+	}
+	symbol, ok := currencySymbols[currencyFormat.Currency]
+	if !ok {
+		return currencyFormat.Currency + " "
+	}
+	if outputStyle == StyleASCII && symbol != "$" {
+		return ""
+	}
+	return symbol
+}
+
+// decimalSeparator returns what separates pounds from pence: "," when
+// thousands are separated by "."
+func decimalSeparator() string {
+	if currencyFormat.ThousandsSeparator == "." {
+		return ","
+	}
+	return "."
+}
+
+// currencyNote describes the conversion from pounds, or "" when amounts are
+// in pounds
+func currencyNote() string {
+	if !converting() {
+		return ""
+	}
+	return fmt.Sprintf("Amounts in %s at %s per %s", currencyFormat.Currency, formatQuantity(currencyFormat.Rate), BaseCurrency)
+}
+
+// printCurrencyNote prints the conversion from pounds above a table, if any
+func printCurrencyNote() {
+	if note := currencyNote(); note != "" {
+		fmt.Fprintln(stdout, note)
+	}
+}
+
+// DisplayCurrency returns the main figures of a response, divided into a
+// period by divisor, in the display currency. JSON output carries them
+// alongside the figures in pounds. It returns nil when amounts are in pounds
+func DisplayCurrency(resp *types.TaxResponse, divisor float64) *types.DisplayCurrency {
+	if !converting() {
+		return nil
+	}
+	// Round to the penny, or cent, as converting adds float noise
+	convert := func(amount float64) float64 {
+		return math.Round(amount*currencyFormat.Rate/divisor*100) / 100
+	}
+	return &types.DisplayCurrency{
+		Currency:             currencyFormat.Currency,
+		Rate:                 currencyFormat.Rate,
+		GrossPay:             convert(resp.GrossPay),
+		TaxPaid:              convert(resp.TaxPaid),
+		NationalInsurance:    convert(resp.NationalInsurance),
+		StudentLoanRepayment: convert(resp.StudentLoanRepayment),
+		PensionYou:           convert(resp.PensionYou),
+		NetPay:               convert(resp.NetPay),
+	}
+}
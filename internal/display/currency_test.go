@@ -0,0 +1,85 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// useCurrencyFormat sets how amounts are written for a test
+func useCurrencyFormat(t *testing.T, format CurrencyFormat) {
+	t.Helper()
+	SetCurrencyFormat(format)
+	t.Cleanup(func() { SetCurrencyFormat(DefaultCurrencyFormat) })
+}
+
+// euroFormat shows amounts in euros at 1.17 to the pound
+var euroFormat = CurrencyFormat{Decimals: 2, ThousandsSeparator: ",", Currency: "EUR", Rate: 1.17}
+
+func TestFormatCurrency_Formats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format func(*CurrencyFormat)
+		want   string
+	}{
+		{"default", func(*CurrencyFormat) {}, "£45,000.40"},
+		{"no decimals", func(f *CurrencyFormat) { f.Decimals = 0 }, "£45,000"},
+		{"no symbol", func(f *CurrencyFormat) { f.NoSymbol = true }, "45,000.40"},
+		{"dot separator", func(f *CurrencyFormat) { f.ThousandsSeparator = "." }, "£45.000,40"},
+		{"no separator", func(f *CurrencyFormat) { f.ThousandsSeparator = "" }, "£45000.40"},
+		{"euros", func(f *CurrencyFormat) { *f = euroFormat }, "€52,650.47"},
+		{"currency code", func(f *CurrencyFormat) { f.Currency, f.Rate = "CHF", 1.1 }, "CHF 49,500.44"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := DefaultCurrencyFormat
+			tt.format(&format)
+			useCurrencyFormat(t, format)
+			assert.Equal(t, tt.want, formatCurrency(45000.40))
+		})
+	}
+}
+
+func TestFormatWholePounds_Euros(t *testing.T) {
+	assert.Equal(t, "£45,001", FormatWholePounds(45000.5))
+
+	useCurrencyFormat(t, euroFormat)
+	assert.Equal(t, "€52,650", FormatWholePounds(45000))
+}
+
+func TestSummary_CurrencyNote(t *testing.T) {
+	useCurrencyFormat(t, euroFormat)
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(testutil.CreateSampleTaxResponse(), "yearly", testutil.CreateSampleTaxRequest())
+	})
+
+	assert.Contains(t, output, "║ Amounts in EUR at 1.17 per GBP               ║")
+	assert.Contains(t, output, "€58,500.00")
+}
+
+func TestComparisonOutput_DisplayCurrency(t *testing.T) {
+	useCurrencyFormat(t, euroFormat)
+	results := []types.ComparisonResult{
+		{Label: "Current", Response: testutil.CreateSampleTaxResponse(), Request: testutil.CreateSampleTaxRequest()},
+	}
+
+	output := ComparisonOutput(results, "monthly")
+
+	// Amounts stay in pounds, with the converted figures alongside
+	assert.InDelta(t, 50000.0/12, output["comparison"].(map[string]map[string]float64)["gross_pay"]["Current"], 0.001)
+	converted, ok := output["metadata"].(map[string]map[string]interface{})["Current"]["display_currency"].(*types.DisplayCurrency)
+	require.True(t, ok)
+	assert.Equal(t, "EUR", converted.Currency)
+	assert.Equal(t, 1.17, converted.Rate)
+	assert.Equal(t, 4875.0, converted.GrossPay)
+}
+
+func TestDisplayCurrency_Pounds(t *testing.T) {
+	assert.Nil(t, DisplayCurrency(testutil.CreateSampleTaxResponse(), 1))
+}
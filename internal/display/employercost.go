@@ -0,0 +1,69 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// EmployerCost displays the cost to the business of one or more hires, per
+// hire and in total. Employment Allowance, apprenticeship levy and pension
+// rows are only shown when they apply.
+func EmployerCost(cost *types.EmployerCost, period string) {
+	divisor := payperiod.Divisor(period, types.WorkPattern{})
+	fieldColWidth := 20
+	valueColWidth := 12
+
+	header := fmt.Sprintf("Employer Cost for %d - %s", cost.TaxYear, payperiod.Label(period))
+	if cost.Budget > 0 {
+		header += " - Budget " + formatCurrency(cost.Budget/divisor)
+	}
+	fmt.Fprintf(stdout, "\n%s\n\n", header)
+
+	rows := []struct {
+		label string
+		value float64
+		show  bool
+	}{
+		{"Salary", cost.GrossPay, true},
+		{"Employer's NI", cost.EmployersNI, true},
+		{"Employment Allowance", -cost.EmploymentAllowance, cost.EmploymentAllowance > 0},
+		{"Apprenticeship Levy", cost.ApprenticeshipLevy, cost.ApprenticeshipLevy > 0},
+		{"Pension (Employer)", cost.EmployerPension, cost.EmployerPension > 0},
+	}
+
+	// Several hires show the cost of each alongside the total
+	columns := 1
+	headers := []string{"Total"}
+	if cost.Hires > 1 {
+		columns = 2
+		headers = []string{"Per Hire", fmt.Sprintf("%d Hires", cost.Hires)}
+	}
+	printRow := func(label string, value float64) {
+		fmt.Fprintf(stdout, "║ %-*s ║", fieldColWidth, label)
+		if columns == 2 {
+			fmt.Fprintf(stdout, " %*s ║", valueColWidth, formatCurrency(value/float64(cost.Hires)/divisor))
+		}
+		fmt.Fprintf(stdout, " %*s ║\n", valueColWidth, formatCurrency(value/divisor))
+	}
+
+	fmt.Fprintln(stdout, generateBorder(columns, fieldColWidth, valueColWidth, "top"))
+	fmt.Fprintf(stdout, "║ %-*s ║", fieldColWidth, "Employer Cost")
+	for _, h := range headers {
+		fmt.Fprintf(stdout, " %-*s ║", valueColWidth, h)
+	}
+	fmt.Fprintln(stdout)
+	fmt.Fprintln(stdout, generateBorder(columns, fieldColWidth, valueColWidth, "middle"))
+
+	for _, row := range rows {
+		if row.show {
+			printRow(row.label, row.value)
+		}
+	}
+
+	fmt.Fprintln(stdout, generateBorder(columns, fieldColWidth, valueColWidth, "separator"))
+	printRow("Total Cost", cost.TotalCost)
+	fmt.Fprintln(stdout, generateBorder(columns, fieldColWidth, valueColWidth, "bottom"))
+	fmt.Fprintln(stdout)
+}
@@ -0,0 +1,58 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestEmployerCost(t *testing.T) {
+	cost := &types.EmployerCost{
+		TaxYear:     2025,
+		Hires:       1,
+		Salary:      45000,
+		GrossPay:    45000,
+		EmployersNI: 6000,
+		TotalCost:   51000,
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		EmployerCost(cost, "yearly")
+	})
+	assert.Contains(t, output, "Employer Cost for 2025 - Yearly")
+	assert.Contains(t, output, "║ Employer Cost        ║ Total        ║")
+	assert.Contains(t, output, "║ Salary               ║   £45,000.00 ║")
+	assert.Contains(t, output, "║ Employer's NI        ║    £6,000.00 ║")
+	assert.Contains(t, output, "║ Total Cost           ║   £51,000.00 ║")
+
+	// Rows that don't apply are left out
+	assert.NotContains(t, output, "Employment Allowance")
+	assert.NotContains(t, output, "Apprenticeship Levy")
+	assert.NotContains(t, output, "Pension (Employer)")
+}
+
+func TestEmployerCost_SeveralHires(t *testing.T) {
+	cost := &types.EmployerCost{
+		TaxYear:            2025,
+		Hires:              10,
+		Budget:             700000,
+		Salary:             60000,
+		GrossPay:           600000,
+		EmployersNI:        82500,
+		ApprenticeshipLevy: 3000,
+		EmployerPension:    30000,
+		TotalCost:          715500,
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		EmployerCost(cost, "monthly")
+	})
+	assert.Contains(t, output, "Employer Cost for 2025 - Monthly - Budget £58,333.33")
+	assert.Contains(t, output, "║ Employer Cost        ║ Per Hire     ║ 10 Hires     ║")
+	assert.Contains(t, output, "║ Apprenticeship Levy  ║       £25.00 ║      £250.00 ║")
+	assert.Contains(t, output, "║ Pension (Employer)   ║      £250.00 ║    £2,500.00 ║")
+	assert.Contains(t, output, "║ Total Cost           ║    £5,962.50 ║   £59,625.00 ║")
+}
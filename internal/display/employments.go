@@ -0,0 +1,108 @@
+package display
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Employments displays the PAYE deductions for each job side by side, followed
+// by the expected under or overpayment against the year-end liability
+func Employments(resp *types.TaxResponse, period string, req *types.TaxRequest) {
+	employments := resp.Employments
+	if employments == nil {
+		return
+	}
+
+	divisor := payperiod.Divisor(period, req.WorkPattern)
+	fieldColWidth := 20
+	valueColWidth := 12
+	numColumns := len(employments.Jobs) + 1
+
+	// Show each job followed by the combined total
+	columns := make([]types.EmploymentResult, 0, numColumns)
+	columns = append(columns, employments.Jobs...)
+	columns = append(columns, employmentTotals(employments.Jobs))
+
+	fmt.Fprintln(stdout, generateBorder(numColumns, fieldColWidth, valueColWidth, "top"))
+	printEmploymentText("Employment", columns, fieldColWidth, valueColWidth, func(job types.EmploymentResult) string {
+		return truncateWidth(job.Label, valueColWidth-1)
+	})
+	printEmploymentText("Tax Code", columns, fieldColWidth, valueColWidth, func(job types.EmploymentResult) string {
+		return job.TaxCode
+	})
+	fmt.Fprintln(stdout, generateBorder(numColumns, fieldColWidth, valueColWidth, "middle"))
+
+	printEmploymentRow("Gross Pay", columns, divisor, fieldColWidth, valueColWidth,
+		func(job types.EmploymentResult) float64 { return job.GrossPay })
+	printEmploymentRow("Pension", columns, divisor, fieldColWidth, valueColWidth,
+		func(job types.EmploymentResult) float64 { return job.Pension })
+	printEmploymentRow("PAYE Tax", columns, divisor, fieldColWidth, valueColWidth,
+		func(job types.EmploymentResult) float64 { return job.TaxPaid })
+	printEmploymentRow("National Insurance", columns, divisor, fieldColWidth, valueColWidth,
+		func(job types.EmploymentResult) float64 { return job.NationalInsurance })
+	if resp.StudentLoanRepayment > 0 {
+		printEmploymentRow("Student Loan", columns, divisor, fieldColWidth, valueColWidth,
+			func(job types.EmploymentResult) float64 { return job.StudentLoanRepayment })
+	}
+	printEmploymentRow("Net Pay", columns, divisor, fieldColWidth, valueColWidth,
+		func(job types.EmploymentResult) float64 { return job.NetPay })
+
+	fmt.Fprintln(stdout, generateBorder(numColumns, fieldColWidth, valueColWidth, "separator"))
+	printEmploymentRow("Employer's NI", columns, divisor, fieldColWidth, valueColWidth,
+		func(job types.EmploymentResult) float64 { return job.EmployersNI })
+	fmt.Fprintln(stdout, generateBorder(numColumns, fieldColWidth, valueColWidth, "bottom"))
+	fmt.Fprintln(stdout)
+
+	// Reconcile PAYE against the liability on combined income
+	fmt.Fprintf(stdout, "PAYE Deducted:         %15s\n", formatCurrency(employments.PAYETax/divisor))
+	fmt.Fprintf(stdout, "Year-End Liability:    %15s\n", formatCurrency(employments.Liability/divisor))
+	switch {
+	case employments.Underpayment > 0.005:
+		fmt.Fprintf(stdout, "Expected Underpayment: %15s\n", formatCurrency(employments.Underpayment/divisor))
+	case employments.Underpayment < -0.005:
+		fmt.Fprintf(stdout, "Expected Overpayment:  %15s\n", formatCurrency(math.Abs(employments.Underpayment)/divisor))
+	default:
+		fmt.Fprintln(stdout, "PAYE matches the year-end liability")
+	}
+	fmt.Fprintln(stdout)
+}
+
+// employmentTotals sums the figures for every job
+func employmentTotals(jobs []types.EmploymentResult) types.EmploymentResult {
+	total := types.EmploymentResult{Label: "Total"}
+	for _, job := range jobs {
+		total.GrossPay += job.GrossPay
+		total.Pension += job.Pension
+		total.TaxPaid += job.TaxPaid
+		total.NationalInsurance += job.NationalInsurance
+		total.StudentLoanRepayment += job.StudentLoanRepayment
+		total.EmployersNI += job.EmployersNI
+		total.NetPay += job.NetPay
+	}
+	return total
+}
+
+// printEmploymentText prints a row of text values, one per job
+func printEmploymentText(fieldName string, jobs []types.EmploymentResult, fieldColWidth, valueColWidth int, extractor func(types.EmploymentResult) string) {
+	fmt.Fprint(stdout, "║ ")
+	fmt.Fprintf(stdout, "%-*s", fieldColWidth, fieldName)
+	for _, job := range jobs {
+		fmt.Fprint(stdout, " ║ ")
+		fmt.Fprintf(stdout, "%-*s", valueColWidth, extractor(job))
+	}
+	fmt.Fprintln(stdout, " ║")
+}
+
+// printEmploymentRow prints a row of currency values, one per job
+func printEmploymentRow(fieldName string, jobs []types.EmploymentResult, divisor float64, fieldColWidth, valueColWidth int, extractor func(types.EmploymentResult) float64) {
+	fmt.Fprint(stdout, "║ ")
+	fmt.Fprintf(stdout, "%-*s", fieldColWidth, fieldName)
+	for _, job := range jobs {
+		fmt.Fprint(stdout, " ║ ")
+		fmt.Fprintf(stdout, "%*s", valueColWidth, formatCurrency(extractor(job)/divisor))
+	}
+	fmt.Fprintln(stdout, " ║")
+}
@@ -0,0 +1,75 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func employmentsResponse(underpayment float64) *types.TaxResponse {
+	return testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.Employments = &types.Employments{
+			Jobs: []types.EmploymentResult{
+				{Label: "Main", TaxCode: "1257L", GrossPay: 60000.0, TaxPaid: 11432.0, NationalInsurance: 3210.6, NetPay: 45357.4},
+				{Label: "Weekend Bar Work", TaxCode: "BR", GrossPay: 8000.0, TaxPaid: 1600.0, NetPay: 6400.0},
+			},
+			PAYETax:      13032.0,
+			Liability:    13032.0 + underpayment,
+			Underpayment: underpayment,
+		}
+	})
+}
+
+func TestEmployments(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Employments(employmentsResponse(1600.0), "yearly", testutil.CreateSampleTaxRequest())
+	})
+
+	assert.Contains(t, output, "Main")
+	assert.Contains(t, output, "Weekend Ba…")
+	assert.Contains(t, output, "1257L")
+	assert.Contains(t, output, "Total")
+	assert.Contains(t, output, "£68,000.00")
+	assert.Contains(t, output, "£13,032.00")
+	assert.Contains(t, output, "Expected Underpayment:")
+	assert.Contains(t, output, "£1,600.00")
+}
+
+func TestEmployments_Reconciliation(t *testing.T) {
+	tests := []struct {
+		name         string
+		underpayment float64
+		expected     string
+	}{
+		{"overpayment", -514.0, "Expected Overpayment:          £514.00"},
+		{"matched", 0, "PAYE matches the year-end liability"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := testutil.CaptureStdout(t, func() {
+				Employments(employmentsResponse(tt.underpayment), "yearly", testutil.CreateSampleTaxRequest())
+			})
+			assert.Contains(t, output, tt.expected)
+		})
+	}
+}
+
+func TestEmployments_Monthly(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Employments(employmentsResponse(1200.0), "monthly", testutil.CreateSampleTaxRequest())
+	})
+
+	assert.Contains(t, output, "£5,000.00")
+	assert.Contains(t, output, "£100.00")
+}
+
+func TestEmployments_None(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Employments(testutil.CreateSampleTaxResponse(), "yearly", testutil.CreateSampleTaxRequest())
+	})
+	assert.Empty(t, output)
+}
@@ -0,0 +1,67 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Explain displays the steps a calculation takes, a section at a time, with
+// the amount each produces. A step with a rate shows the amount the rate is
+// applied to and the threshold it starts from, e.g.
+// "Higher Rate: £12,430.00 over £37,700.00 × 40%"
+func Explain(resp *types.TaxResponse, period string, req *types.TaxRequest) {
+	if len(resp.Explanation) == 0 {
+		return
+	}
+	divisor := payperiod.Divisor(period, req.WorkPattern)
+
+	fmt.Fprintf(stdout, "\nHow This Is Worked Out - %s - %s\n", TaxYearLabel(strconv.Itoa(resp.TaxYear)), payperiod.Label(period))
+	printCurrencyNote()
+
+	section := ""
+	for _, step := range resp.Explanation {
+		if step.Section != section {
+			section = step.Section
+			fmt.Fprintf(stdout, "\n%s\n", section)
+		}
+		amount := formatCurrency(step.Amount / divisor)
+		if outputStyle == StylePlain {
+			// Plain output gives the amount first, then how it is reached
+			if working := explainWorking(step, divisor); working != "" {
+				amount += " (" + working + ")"
+			}
+			fmt.Fprintf(stdout, "  %s: %s\n", step.Name, amount)
+		} else {
+			fmt.Fprintf(stdout, "  %s %s\n", padRight(explainLabel(step, divisor), 52), padLeft(amount, 14))
+		}
+		if step.Note != "" {
+			fmt.Fprintf(stdout, "    %s\n", step.Note)
+		}
+	}
+	fmt.Fprintln(stdout)
+}
+
+// explainLabel returns a step's name with the amount its rate is applied to
+// and the threshold that amount is over
+func explainLabel(step types.ExplanationStep, divisor float64) string {
+	if working := explainWorking(step, divisor); working != "" {
+		return step.Name + ": " + working
+	}
+	return step.Name
+}
+
+// explainWorking returns the amount a step's rate is applied to and the
+// threshold that amount is over, or nothing for a step without a rate
+func explainWorking(step types.ExplanationStep, divisor float64) string {
+	if step.Rate == 0 {
+		return ""
+	}
+	working := formatCurrency(step.Base / divisor)
+	if step.Threshold > 0 {
+		working += " over " + formatCurrency(step.Threshold/divisor)
+	}
+	return working + " × " + formatRate(step.Rate) + "%"
+}
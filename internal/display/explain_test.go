@@ -0,0 +1,38 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestExplain(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.Explanation = []types.ExplanationStep{
+			{Section: "Pay", Name: "Gross pay", Amount: 60000},
+			{Section: "Income Tax", Name: "Higher Rate", Amount: 4000, Base: 10000, Rate: 0.40, Threshold: 37700},
+			{Section: "Net Pay", Name: "Net pay", Amount: 45000, Note: "gross pay less deductions"},
+		}
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		Explain(resp, "monthly", testutil.CreateSampleTaxRequest())
+	})
+
+	assert.Contains(t, output, "How This Is Worked Out - 2024/25 - Monthly\n\nPay\n")
+	assert.Contains(t, output, "  Gross pay ")
+	assert.Regexp(t, `  Higher Rate: £833\.33 over £3,141\.67 × 40% +£333\.33\n`, output)
+	assert.Contains(t, output, "\nNet Pay\n")
+	assert.Contains(t, output, "    gross pay less deductions\n")
+}
+
+func TestExplain_NoSteps(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Explain(testutil.CreateSampleTaxResponse(), "yearly", testutil.CreateSampleTaxRequest())
+	})
+
+	assert.Empty(t, output)
+}
@@ -0,0 +1,150 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// payslipColumn is one column of the payslip table
+type payslipColumn struct {
+	header string
+	value  func(types.Payslip) string
+}
+
+// payslipColWidth fits amounts up to £999,999.99
+const payslipColWidth = 12
+
+// Payslips displays a table of every pay period in the year with
+// year-to-date totals. Student loan, pension and tax code columns are only
+// shown when they apply.
+func Payslips(slips []types.Payslip, req *types.TaxRequest, frequency string) {
+	if len(slips) == 0 {
+		return
+	}
+
+	columns := payslipColumns(slips)
+
+	header := fmt.Sprintf("Payslips for %s (%s) - %s", req.Year, req.TaxRegion, getFrequencyLabel(frequency))
+	if !taxCodeChanges(slips) {
+		header += " - Tax Code " + slips[0].TaxCode
+	}
+	fmt.Fprintf(stdout, "\n%s\n\n", header)
+
+	// Plain output names each value rather than lining it up under a header
+	if outputStyle == StylePlain {
+		headers := make([]string, len(columns))
+		for i, column := range columns {
+			headers[i] = column.header
+		}
+		for _, slip := range slips {
+			values := make([]string, len(columns))
+			for i, column := range columns {
+				values[i] = column.value(slip)
+			}
+			fmt.Fprintln(stdout, plainRecord(fmt.Sprintf("%s %d", payslipPeriodName(frequency), slip.Period), headers, values))
+		}
+		fmt.Fprintln(stdout)
+		return
+	}
+
+	// Header row, with columns widened to fit longer headers
+	widths := make([]int, len(columns))
+	ruleWidth := 9
+	for i, column := range columns {
+		widths[i] = max(payslipColWidth, displayWidth(column.header))
+		ruleWidth += widths[i] + 1
+	}
+	fmt.Fprintf(stdout, "%-9s", payslipPeriodName(frequency))
+	for i, column := range columns {
+		fmt.Fprintf(stdout, " %s", padLeft(column.header, widths[i]))
+	}
+	fmt.Fprintln(stdout)
+	fmt.Fprintln(stdout, strings.Repeat("─", ruleWidth))
+
+	for _, slip := range slips {
+		fmt.Fprintf(stdout, "%-9d", slip.Period)
+		for i, column := range columns {
+			fmt.Fprintf(stdout, " %s", padLeft(column.value(slip), widths[i]))
+		}
+		fmt.Fprintln(stdout)
+	}
+	fmt.Fprintln(stdout)
+}
+
+// payslipColumns returns the columns to show for these payslips
+func payslipColumns(slips []types.Payslip) []payslipColumn {
+	hasStudentLoan, hasPension := false, false
+	for _, slip := range slips {
+		hasStudentLoan = hasStudentLoan || slip.StudentLoanRepayment != 0
+		hasPension = hasPension || slip.Pension != 0
+	}
+
+	columns := []payslipColumn{}
+	if taxCodeChanges(slips) {
+		columns = append(columns, payslipColumn{"Tax Code", func(s types.Payslip) string { return s.TaxCode }})
+	}
+	columns = append(columns,
+		payslipColumn{"Gross", func(s types.Payslip) string { return formatCurrency(s.GrossPay) }},
+		payslipColumn{"Tax", func(s types.Payslip) string { return formatCurrency(s.TaxPaid) }},
+		payslipColumn{"NI", func(s types.Payslip) string { return formatCurrency(s.NationalInsurance) }},
+	)
+	if hasStudentLoan {
+		columns = append(columns, payslipColumn{"Student Loan", func(s types.Payslip) string { return formatCurrency(s.StudentLoanRepayment) }})
+	}
+	if hasPension {
+		columns = append(columns, payslipColumn{"Pension", func(s types.Payslip) string { return formatCurrency(s.Pension) }})
+	}
+	columns = append(columns,
+		payslipColumn{"Net", func(s types.Payslip) string { return formatCurrency(s.NetPay) }},
+		payslipColumn{"Gross YTD", func(s types.Payslip) string { return formatCurrency(s.GrossPayToDate) }},
+		payslipColumn{"Tax YTD", func(s types.Payslip) string { return formatCurrency(s.TaxPaidToDate) }},
+		payslipColumn{"NI YTD", func(s types.Payslip) string { return formatCurrency(s.NationalInsuranceToDate) }},
+	)
+	if hasStudentLoan {
+		columns = append(columns, payslipColumn{"Student Loan YTD", func(s types.Payslip) string { return formatCurrency(s.StudentLoanToDate) }})
+	}
+	if hasPension {
+		columns = append(columns, payslipColumn{"Pension YTD", func(s types.Payslip) string { return formatCurrency(s.PensionToDate) }})
+	}
+	return append(columns, payslipColumn{"Net YTD", func(s types.Payslip) string { return formatCurrency(s.NetPayToDate) }})
+}
+
+// taxCodeChanges reports whether the tax code changes during the year
+func taxCodeChanges(slips []types.Payslip) bool {
+	for _, slip := range slips {
+		if slip.TaxCode != slips[0].TaxCode {
+			return true
+		}
+	}
+	return false
+}
+
+// getFrequencyLabel returns a human-readable label for a pay frequency
+func getFrequencyLabel(frequency string) string {
+	switch frequency {
+	case "weekly":
+		return "Weekly"
+	case "fortnightly":
+		return "Fortnightly"
+	case "four-weekly":
+		return "Four-Weekly"
+	default:
+		return "Monthly"
+	}
+}
+
+// payslipPeriodName returns the name of a single pay period, used as the first column header
+func payslipPeriodName(frequency string) string {
+	switch frequency {
+	case "weekly":
+		return "Week"
+	case "fortnightly":
+		return "Fortnight"
+	case "four-weekly":
+		return "Period"
+	default:
+		return "Month"
+	}
+}
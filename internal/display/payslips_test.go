@@ -0,0 +1,113 @@
+package display
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func samplePayslips(codes ...string) []types.Payslip {
+	slips := make([]types.Payslip, len(codes))
+	for i, code := range codes {
+		slips[i] = types.Payslip{
+			Period:         i + 1,
+			TaxCode:        code,
+			GrossPay:       5000.0,
+			TaxPaid:        952.67,
+			NetPay:         3779.78,
+			GrossPayToDate: 5000.0 * float64(i+1),
+			NetPayToDate:   3779.78 * float64(i+1),
+		}
+	}
+	return slips
+}
+
+func TestPayslips(t *testing.T) {
+	req := testutil.CreateSampleTaxRequest()
+
+	output := testutil.CaptureStdout(t, func() {
+		Payslips(samplePayslips("1257L", "1257L"), req, "monthly")
+	})
+
+	assert.Contains(t, output, "Payslips for 2024 (uk) - Monthly - Tax Code 1257L")
+	assert.Contains(t, output, "Month")
+	assert.Contains(t, output, "£10,000.00")
+	assert.Contains(t, output, "Net YTD")
+
+	// Columns without values are hidden
+	assert.NotContains(t, output, "Student Loan")
+	assert.NotContains(t, output, "Pension")
+}
+
+func TestPayslips_OptionalColumns(t *testing.T) {
+	req := testutil.CreateSampleTaxRequest()
+	slips := samplePayslips("1257L M1", "1257L")
+	slips[0].StudentLoanRepayment = 100.0
+	slips[1].Pension = 250.0
+
+	output := testutil.CaptureStdout(t, func() {
+		Payslips(slips, req, "weekly")
+	})
+
+	header := strings.Split(output, "\n")[3]
+	assert.Contains(t, header, "Week")
+	assert.Contains(t, header, "Tax Code")
+	assert.Contains(t, header, "Student Loan")
+	assert.Contains(t, header, "Pension")
+	assert.Contains(t, header, "Student Loan YTD")
+	assert.Contains(t, header, "Pension YTD")
+	assert.Contains(t, output, "1257L M1")
+	assert.NotContains(t, output, "- Tax Code")
+}
+
+func TestPayslips_YearToDateColumns(t *testing.T) {
+	slips := samplePayslips("1257L", "1257L")
+	for i := range slips {
+		slips[i].StudentLoanRepayment = 100.0
+		slips[i].StudentLoanToDate = 100.0 * float64(i+1)
+		slips[i].Pension = 250.0
+		slips[i].PensionToDate = 250.0 * float64(i+1)
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Payslips(slips, testutil.CreateSampleTaxRequest(), "monthly")
+	})
+
+	// Every row lines up with the header, though "Student Loan YTD" is wider than the amounts
+	lines := strings.Split(output, "\n")
+	assert.Equal(t, "Month            Gross          Tax           NI Student Loan      Pension          Net    Gross YTD      Tax YTD       NI YTD Student Loan YTD  Pension YTD      Net YTD", lines[3])
+	assert.Equal(t, displayWidth(lines[3]), displayWidth(lines[4]))
+	assert.Equal(t, displayWidth(lines[3]), displayWidth(lines[6]))
+	assert.Contains(t, lines[6], "      £200.00      £500.00")
+}
+
+func TestPayslips_Empty(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Payslips(nil, testutil.CreateSampleTaxRequest(), "monthly")
+	})
+	assert.Empty(t, output)
+}
+
+func TestGetFrequencyLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		frequency string
+		label     string
+		period    string
+	}{
+		{"weekly", "Weekly", "Week"},
+		{"fortnightly", "Fortnightly", "Fortnight"},
+		{"four-weekly", "Four-Weekly", "Period"},
+		{"monthly", "Monthly", "Month"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.label, getFrequencyLabel(tt.frequency))
+		assert.Equal(t, tt.period, payslipPeriodName(tt.frequency))
+	}
+}
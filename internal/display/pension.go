@@ -0,0 +1,82 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// pensionSchemeLabels are the display names of each pension scheme
+var pensionSchemeLabels = map[string]string{
+	"sacrifice":        "Salary Sacrifice",
+	"net-pay":          "Net Pay",
+	"relief-at-source": "Relief at Source",
+}
+
+// pensionSchemeLabel returns the display name of a pension scheme
+func pensionSchemeLabel(scheme string) string {
+	if label, ok := pensionSchemeLabels[scheme]; ok {
+		return label
+	}
+	return scheme
+}
+
+// printPensionScheme prints the pension contributions and the tax and NI saved
+func printPensionScheme(scheme *types.PensionScheme, divisor float64) {
+	if scheme.Scheme != "" {
+		fmt.Fprintf(stdout, "Pension (%s):\n", pensionSchemeLabel(scheme.Scheme))
+	} else {
+		fmt.Fprintln(stdout, "Pension:")
+	}
+	fmt.Fprintf(stdout, "  Your Contribution:   %15s\n", formatCurrency((scheme.Contribution-scheme.BasicRateTopUp)/divisor))
+	if scheme.BasicRateTopUp > 0 {
+		fmt.Fprintf(stdout, "  Basic Rate Top-Up:   %15s\n", formatCurrency(scheme.BasicRateTopUp/divisor))
+	}
+	if scheme.HigherRateRelief > 0 {
+		fmt.Fprintf(stdout, "  Higher Rate Relief:  %15s\n", formatCurrency(scheme.HigherRateRelief/divisor))
+	}
+	if scheme.EmployeeNISaving > 0 {
+		fmt.Fprintf(stdout, "  Your NI Saving:      %15s\n", formatCurrency(scheme.EmployeeNISaving/divisor))
+	}
+	if scheme.EmployerNISaving > 0 {
+		fmt.Fprintf(stdout, "  Employer NI Saving:  %15s\n", formatCurrency(scheme.EmployerNISaving/divisor))
+	}
+	if scheme.Employer > 0 {
+		fmt.Fprintf(stdout, "  Employer:            %15s\n", formatCurrency(scheme.Employer/divisor))
+	}
+	fmt.Fprintf(stdout, "  Total Contributions: %15s\n", formatCurrency(scheme.Total/divisor))
+	fmt.Fprintln(stdout)
+}
+
+// employerPension returns the employer's pension contribution, or 0 if there is none
+func employerPension(resp *types.TaxResponse) float64 {
+	if resp.PensionScheme == nil {
+		return 0
+	}
+	return resp.PensionScheme.Employer
+}
+
+// employeeNISaving returns the employee NI saved by salary sacrifice, or 0 if there is none
+func employeeNISaving(resp *types.TaxResponse) float64 {
+	if resp.PensionScheme == nil {
+		return 0
+	}
+	return resp.PensionScheme.EmployeeNISaving
+}
+
+// TotalCost returns the cost of employment: gross pay, employer's NI and
+// pension contributions from HMRC and the employer. The basic rate top-up
+// under relief at source is claimed from HMRC by the pension provider, so it
+// is not a cost to the employer
+func TotalCost(resp *types.TaxResponse) float64 {
+	pension := employerPension(resp)
+	if resp.PensionScheme == nil || resp.PensionScheme.Scheme != "relief-at-source" {
+		pension += resp.PensionHMRC
+	}
+	return tax.TotalCost(&types.EmployerCost{
+		GrossPay:        resp.GrossPay,
+		EmployersNI:     resp.EmployersNI,
+		EmployerPension: pension,
+	})
+}
@@ -0,0 +1,133 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func sacrificeResponse() *types.TaxResponse {
+	return testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.PensionYou = 2500.0
+		r.GrossSacrifice = 2500.0
+		r.PensionScheme = &types.PensionScheme{
+			Scheme:           "sacrifice",
+			Contribution:     2500.0,
+			EmployeeNISaving: 50.0,
+			EmployerNISaving: 345.0,
+			Employer:         2500.0,
+			Total:            5000.0,
+		}
+	})
+}
+
+func TestSummary_PensionScheme(t *testing.T) {
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.PensionScheme = "sacrifice"
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(sacrificeResponse(), "yearly", req)
+	})
+	assert.Contains(t, output, "Salary Sacrifice")
+	assert.Contains(t, output, "║ NI Saved by Sacrifice                 £50.00 ║")
+	assert.Contains(t, output, "║ Pension (Employer)                 £2,500.00 ║")
+	assert.Contains(t, output, "║ Total Cost                        £57,720.78 ║")
+
+	// No pension rows without a scheme or employer contribution
+	output = testutil.CaptureStdout(t, func() {
+		Summary(testutil.CreateSampleTaxResponse(), "yearly", testutil.CreateSampleTaxRequest())
+	})
+	assert.NotContains(t, output, "NI Saved by Sacrifice")
+	assert.NotContains(t, output, "Pension (Employer)")
+	assert.Contains(t, output, "║ Total Cost                        £55,220.78 ║")
+}
+
+func TestDetailed_PensionScheme(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Detailed(sacrificeResponse(), "yearly", testutil.CreateSampleTaxRequest())
+	})
+	assert.Contains(t, output, "Pension (Salary Sacrifice):")
+	assert.Contains(t, output, "  Your Contribution:         £2,500.00")
+	assert.Contains(t, output, "  Your NI Saving:               £50.00")
+	assert.Contains(t, output, "  Employer NI Saving:          £345.00")
+	assert.Contains(t, output, "  Total Contributions:       £5,000.00")
+	assert.Contains(t, output, "  Pension (Employer):        £2,500.00")
+	assert.NotContains(t, output, "Basic Rate Top-Up")
+}
+
+func TestPrintPensionScheme_ReliefAtSource(t *testing.T) {
+	scheme := &types.PensionScheme{
+		Scheme:           "relief-at-source",
+		Contribution:     3000.0,
+		BasicRateTopUp:   600.0,
+		HigherRateRelief: 600.0,
+		Total:            3000.0,
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		printPensionScheme(scheme, 12)
+	})
+	assert.Contains(t, output, "Pension (Relief at Source):")
+	assert.Contains(t, output, "  Your Contribution:           £200.00")
+	assert.Contains(t, output, "  Basic Rate Top-Up:            £50.00")
+	assert.Contains(t, output, "  Higher Rate Relief:           £50.00")
+}
+
+func TestTotalCost_ReliefAtSource(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.GrossPay = 50000.0
+		r.EmployersNI = 6750.0
+		r.PensionHMRC = 500.0
+		r.PensionScheme = &types.PensionScheme{Scheme: "relief-at-source", BasicRateTopUp: 500.0}
+	})
+
+	// HMRC's top-up is not a cost to the employer
+	assert.InDelta(t, 56750.0, TotalCost(resp), 0.01)
+
+	resp.PensionScheme.Scheme = "net-pay"
+	assert.InDelta(t, 57250.0, TotalCost(resp), 0.01)
+}
+
+func TestTotalCost_MatchesEmployerCost(t *testing.T) {
+	// check's total cost and employer-cost agree for the same hire
+	cost, err := tax.EmployerCost(&types.EmployerCostRequest{Year: "2025", Salary: 45000, Hires: 1, EmployerPension: "5%"})
+	require.NoError(t, err)
+
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.GrossPay = cost.GrossPay
+		r.EmployersNI = cost.EmployersNI
+		r.PensionHMRC = 0
+		r.PensionScheme = &types.PensionScheme{Employer: cost.EmployerPension}
+	})
+	assert.InDelta(t, cost.TotalCost, TotalCost(resp), 0.01)
+}
+
+func TestComparison_EmployerPension(t *testing.T) {
+	results := []types.ComparisonResult{
+		{Label: "Sacrifice", Request: testutil.CreateSampleTaxRequest(), Response: sacrificeResponse()},
+		{Label: "None", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse()},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(results, "yearly", false)
+	})
+	assert.Contains(t, output, "Pension (Employer)")
+	assert.Contains(t, output, "£57,720.78")
+
+	fields := buildComparisonFields(results, []float64{1, 1})
+	assert.InDelta(t, 2500.0, fields["pension_employer"]["Sacrifice"], 0.001)
+	assert.InDelta(t, 0.0, fields["pension_employer"]["None"], 0.001)
+	assert.InDelta(t, 50.0, fields["employee_ni_saving"]["Sacrifice"], 0.001)
+
+	// The row is left out when no option has an employer contribution
+	output = testutil.CaptureStdout(t, func() {
+		Comparison(results[1:], "yearly", false)
+	})
+	assert.NotContains(t, output, "Pension (Employer)")
+}
@@ -0,0 +1,67 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// ProRata displays part-time pay alongside the full-time-equivalent figures
+// and the difference between them
+func ProRata(resp *types.TaxResponse, period string, req *types.TaxRequest) {
+	proRata := req.ProRata
+	if resp.FullTime == nil || proRata == nil {
+		return
+	}
+
+	fullTimeDivisor := payperiod.Divisor(period, proRata.FullTimePattern)
+	divisor := payperiod.Divisor(period, req.WorkPattern)
+	fieldColWidth := 20
+	valueColWidth := 12
+
+	rows := []struct {
+		label     string
+		extractor func(*types.TaxResponse) float64
+	}{
+		{"Gross Salary", func(r *types.TaxResponse) float64 { return r.GrossPay }},
+		{"Tax Paid", func(r *types.TaxResponse) float64 { return r.TaxPaid }},
+		{"National Insurance", func(r *types.TaxResponse) float64 { return r.NationalInsurance }},
+		{"Student Loan", func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment }},
+		{"Pension (You)", func(r *types.TaxResponse) float64 { return r.PensionYou }},
+		{"Net Pay", func(r *types.TaxResponse) float64 { return r.NetPay }},
+	}
+
+	fmt.Fprintln(stdout, generateBorder(3, fieldColWidth, valueColWidth, "top"))
+	fmt.Fprintf(stdout, "║ %-*s ║ %-*s ║ %-*s ║ %-*s ║\n", fieldColWidth, "Pro Rata",
+		valueColWidth, "Full-Time", valueColWidth, proRataLabel(proRata), valueColWidth, "Difference")
+	fmt.Fprintln(stdout, generateBorder(3, fieldColWidth, valueColWidth, "middle"))
+
+	for _, row := range rows {
+		fullTime := row.extractor(resp.FullTime) / fullTimeDivisor
+		partTime := row.extractor(resp) / divisor
+		if row.label == "Student Loan" && fullTime == 0 && partTime == 0 {
+			continue
+		}
+		fmt.Fprintf(stdout, "║ %-*s ║ %*s ║ %*s ║ %*s ║\n", fieldColWidth, row.label,
+			valueColWidth, formatCurrency(fullTime), valueColWidth, formatCurrency(partTime),
+			valueColWidth, formatCurrency(partTime-fullTime))
+	}
+
+	fmt.Fprintln(stdout, generateBorder(3, fieldColWidth, valueColWidth, "bottom"))
+	fmt.Fprintln(stdout)
+}
+
+// proRataLabel returns the fraction of full-time worked in short form (e.g. 0.6 FTE)
+func proRataLabel(proRata *types.ProRata) string {
+	if proRata.Days != 0 {
+		return formatQuantity(proRata.Days) + " Days/Week"
+	}
+	return formatQuantity(proRata.FTE) + " FTE"
+}
+
+// proRataBreakdown returns how the part-time salary is worked out from the full-time salary
+func proRataBreakdown(proRata *types.ProRata) string {
+	return fmt.Sprintf("%s of %s full-time = %s",
+		proRataLabel(proRata), formatCurrency(float64(proRata.FullTimeIncome)), formatCurrency(float64(proRata.Income)))
+}
@@ -0,0 +1,43 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestProRata(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.GrossPay = 36000.0
+		r.NetPay = 29000.0
+		r.FullTime = testutil.CreateSampleTaxResponse(func(f *types.TaxResponse) {
+			f.GrossPay = 60000.0
+			f.NetPay = 45000.0
+		})
+	})
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.ProRata = &types.ProRata{FTE: 0.6, FullTimeIncome: 60000, Income: 36000}
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		ProRata(resp, "yearly", req)
+	})
+	assert.Contains(t, output, "║ Pro Rata             ║ Full-Time    ║ 0.6 FTE      ║ Difference   ║")
+	assert.Contains(t, output, "║ Gross Salary         ║   £60,000.00 ║   £36,000.00 ║  -£24,000.00 ║")
+	assert.Contains(t, output, "║ Net Pay              ║   £45,000.00 ║   £29,000.00 ║  -£16,000.00 ║")
+
+	output = testutil.CaptureStdout(t, func() {
+		Detailed(resp, "yearly", req)
+	})
+	assert.Contains(t, output, "Pro Rata: 0.6 FTE of £60,000.00 full-time = £36,000.00")
+
+	// Nothing is shown without full-time figures
+	resp.FullTime = nil
+	output = testutil.CaptureStdout(t, func() {
+		ProRata(resp, "yearly", req)
+	})
+	assert.Empty(t, output)
+}
@@ -0,0 +1,77 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// PayRise displays pay before and after a raise with the change, how much of
+// the raise reaches net pay, and warnings for any thresholds it crosses
+func PayRise(rise *types.PayRise, period string) {
+	divisor := payperiod.Divisor(period, types.WorkPattern{})
+	fieldColWidth := 20
+	valueColWidth := 12
+
+	rows := []struct {
+		label     string
+		extractor func(*types.TaxResponse) float64
+		optional  bool
+	}{
+		{"Gross Salary", func(r *types.TaxResponse) float64 { return r.GrossPay }, false},
+		{"Tax Paid", func(r *types.TaxResponse) float64 { return r.TaxPaid }, false},
+		{"National Insurance", func(r *types.TaxResponse) float64 { return r.NationalInsurance }, false},
+		{"Student Loan", func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment }, true},
+		{"Pension (You)", func(r *types.TaxResponse) float64 { return r.PensionYou }, true},
+		{"Net Pay", func(r *types.TaxResponse) float64 { return r.NetPay }, false},
+	}
+
+	fmt.Fprintf(stdout, "\nPay Rise for %d - %s\n\n", rise.TaxYear, payperiod.Label(period))
+	fmt.Fprintln(stdout, generateBorder(3, fieldColWidth, valueColWidth, "top"))
+	fmt.Fprintf(stdout, "║ %-*s", fieldColWidth, "Field")
+	for _, header := range []string{"Before", "After", "Change"} {
+		fmt.Fprintf(stdout, " ║ %-*s", valueColWidth, header)
+	}
+	fmt.Fprintln(stdout, " ║")
+	fmt.Fprintln(stdout, generateBorder(3, fieldColWidth, valueColWidth, "middle"))
+
+	for _, row := range rows {
+		before := row.extractor(rise.Before) / divisor
+		after := row.extractor(rise.After) / divisor
+		if row.optional && before == 0 && after == 0 {
+			continue
+		}
+		fmt.Fprintf(stdout, "║ %-*s ║ %*s ║ %*s ║ %*s ║\n",
+			fieldColWidth, row.label,
+			valueColWidth, formatCurrency(before),
+			valueColWidth, formatCurrency(after),
+			valueColWidth, formatCurrency(after-before))
+	}
+	fmt.Fprintln(stdout, generateBorder(3, fieldColWidth, valueColWidth, "bottom"))
+
+	fmt.Fprintf(stdout, "\nOf the %s raise, %s (%s%%) reaches net pay.\n",
+		formatCurrency(rise.Raise/divisor), formatCurrency(rise.NetIncrease/divisor), formatRate(rise.Kept))
+	fmt.Fprintf(stdout, "Effective marginal rate: %s%% (tax, NI and student loan)\n", formatRate(rise.MarginalRate))
+	fmt.Fprintf(stdout, "Extra pension to keep take-home pay unchanged: %s (%s)\n",
+		formatCurrency(rise.ExtraPension/divisor), strings.ToLower(pensionSchemeLabel(rise.PensionScheme)))
+
+	if len(rise.Thresholds) > 0 {
+		fmt.Fprintln(stdout, "\nWarnings:")
+		for _, threshold := range rise.Thresholds {
+			fmt.Fprintf(stdout, "  ⚠ %s\n", thresholdWarning(threshold))
+		}
+	}
+	fmt.Fprintln(stdout)
+}
+
+// thresholdWarning explains a threshold crossed by a raise
+func thresholdWarning(threshold types.Threshold) string {
+	warning := fmt.Sprintf("Crosses the %s at %s", threshold.Name, formatAllowance(threshold.Amount))
+	if threshold.Name == tax.ThresholdTaper {
+		warning += ": each £2 over it removes £1 of personal allowance"
+	}
+	return warning
+}
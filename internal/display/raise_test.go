@@ -0,0 +1,62 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestPayRise(t *testing.T) {
+	rise := &types.PayRise{
+		TaxYear:       2025,
+		From:          95000,
+		To:            104500,
+		Raise:         9500,
+		NetIncrease:   3990,
+		Kept:          0.42,
+		MarginalRate:  0.58,
+		PensionScheme: "sacrifice",
+		ExtraPension:  9500,
+		Thresholds:    []types.Threshold{{Name: "Personal Allowance Taper", Amount: 100000}},
+		Before: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.GrossPay = 95000
+			r.NetPay = 62000
+		}),
+		After: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.GrossPay = 104500
+			r.NetPay = 65990
+		}),
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		PayRise(rise, "monthly")
+	})
+	assert.Contains(t, output, "Pay Rise for 2025 - Monthly")
+	assert.Contains(t, output, "║ Field                ║ Before       ║ After        ║ Change       ║")
+	assert.Contains(t, output, "║ Gross Salary         ║    £7,916.67 ║    £8,708.33 ║      £791.67 ║")
+	assert.Contains(t, output, "║ Net Pay              ║    £5,166.67 ║    £5,499.17 ║      £332.50 ║")
+	assert.NotContains(t, output, "Student Loan  ")
+	assert.Contains(t, output, "Of the £791.67 raise, £332.50 (42%) reaches net pay.")
+	assert.Contains(t, output, "Effective marginal rate: 58% (tax, NI and student loan)")
+	assert.Contains(t, output, "Extra pension to keep take-home pay unchanged: £791.67 (salary sacrifice)")
+	assert.Contains(t, output, "⚠ Crosses the Personal Allowance Taper at £100,000: each £2 over it removes £1 of personal allowance")
+}
+
+func TestPayRise_NoThresholds(t *testing.T) {
+	rise := &types.PayRise{
+		TaxYear:       2025,
+		Raise:         1000,
+		PensionScheme: "net-pay",
+		Before:        testutil.CreateSampleTaxResponse(),
+		After:         testutil.CreateSampleTaxResponse(),
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		PayRise(rise, "yearly")
+	})
+	assert.Contains(t, output, "(net pay)")
+	assert.NotContains(t, output, "Warnings")
+}
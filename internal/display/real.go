@@ -0,0 +1,62 @@
+package display
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// realGrossPay returns gross pay in the base year's prices, or 0 without real terms
+func realGrossPay(resp *types.TaxResponse) float64 {
+	if resp.Real == nil {
+		return 0
+	}
+	return resp.Real.GrossPay
+}
+
+// realNetPay returns net pay in the base year's prices, or 0 without real terms
+func realNetPay(resp *types.TaxResponse) float64 {
+	if resp.Real == nil {
+		return 0
+	}
+	return resp.Real.NetPay
+}
+
+// realBaseYear returns the base year of the results' real terms figures, or 0 if there are none
+func realBaseYear(results []types.ComparisonResult) int {
+	for _, result := range results {
+		if result.Response.Real != nil {
+			return result.Response.Real.BaseYear
+		}
+	}
+	return 0
+}
+
+// printRealTermsRows prints gross and net pay in constant prices when the results have real terms figures
+func printRealTermsRows(results []types.ComparisonResult, divisors []float64, fieldColWidth, valueColWidth int) {
+	if realBaseYear(results) == 0 {
+		return
+	}
+	printComparisonRow("Real Gross Salary", results, divisors, fieldColWidth, valueColWidth, realGrossPay)
+	printComparisonRow("Real Net Pay", results, divisors, fieldColWidth, valueColWidth, realNetPay)
+}
+
+// printRealTermsNote explains the prices real terms figures are in, and any
+// year whose CPI value is missing
+func printRealTermsNote(results []types.ComparisonResult) {
+	baseYear := realBaseYear(results)
+	if baseYear == 0 {
+		return
+	}
+	fmt.Fprintf(stdout, "Real terms are in %s prices, adjusted by CPI.\n", TaxYearLabel(strconv.Itoa(baseYear)))
+
+	var notes []string
+	for _, result := range results {
+		if terms := result.Response.Real; terms != nil && terms.Note != "" && !slices.Contains(notes, terms.Note) {
+			notes = append(notes, terms.Note)
+			fmt.Fprintf(stdout, "Note: %s.\n", terms.Note)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package display
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func realTermsResults() []types.ComparisonResult {
+	return []types.ComparisonResult{
+		{
+			Label:   "Last Year",
+			Request: testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) { r.Year = "2023" }),
+			Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+				r.Real = &types.RealTerms{BaseYear: 2024, Factor: 1.026, GrossPay: 51300, NetPay: 39291.53}
+			}),
+		},
+		{
+			Label:   "This Year",
+			Request: testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) { r.Year = "2024" }),
+			Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+				r.GrossPay = 53000
+				r.NetPay = 40500
+				r.Real = &types.RealTerms{BaseYear: 2024, Factor: 1, GrossPay: 53000, NetPay: 40500}
+			}),
+		},
+	}
+}
+
+func TestComparison_RealTerms(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(realTermsResults(), "yearly", false)
+	})
+	assert.Contains(t, output, "║ Real Gross Salary    ║   £51,300.00 ║   £53,000.00 ║")
+	assert.Contains(t, output, "║ Real Net Pay         ║   £39,291.53 ║   £40,500.00 ║")
+	assert.Contains(t, output, "Real terms are in 2024/25 prices, adjusted by CPI.")
+
+	output = testutil.CaptureStdout(t, func() {
+		Comparison(realTermsResults()[:1], "monthly", false)
+	})
+	assert.Contains(t, output, "║ Real Net Pay         ║    £3,274.29 ║")
+}
+
+func TestComparison_RealTermsNote(t *testing.T) {
+	results := realTermsResults()
+	results[1].Response.Real.Note = "no CPI value for 2027, so 2026's is used"
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(results, "yearly", false)
+	})
+	assert.Contains(t, output, "Note: no CPI value for 2027, so 2026's is used.")
+}
+
+func TestComparison_NoRealTerms(t *testing.T) {
+	results := []types.ComparisonResult{
+		{Label: "Job 1", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse()},
+		{Label: "Job 2", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse()},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(results, "yearly", false)
+	})
+	assert.NotContains(t, output, "Real")
+}
+
+func TestComparisonJSON_RealTerms(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		ComparisonJSON(realTermsResults(), "yearly")
+	})
+
+	var parsed map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &parsed))
+	fields := parsed["comparison"].(map[string]interface{})
+	realNetPay := fields["real_net_pay"].(map[string]interface{})
+	assert.InDelta(t, 39291.53, realNetPay["Last Year"], 0.001)
+	assert.InDelta(t, 40500.0, realNetPay["This Year"], 0.001)
+
+	metadata := parsed["metadata"].(map[string]interface{})
+	realTerms := metadata["Last Year"].(map[string]interface{})["real"].(map[string]interface{})
+	assert.InDelta(t, 2024.0, realTerms["base_year"], 0.001)
+	assert.InDelta(t, 1.026, realTerms["factor"], 0.001)
+}
+
+func TestYears_RealTerms(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Years(realTermsResults(), "yearly")
+	})
+	assert.Contains(t, output, "║ Real Gross Salary    ║   £51,300.00 ║   £53,000.00 ║    £1,700.00 ║")
+	assert.Contains(t, output, "║ Real Net Pay         ║   £39,291.53 ║   £40,500.00 ║    £1,208.47 ║")
+	assert.Contains(t, output, "Real terms are in 2024/25 prices, adjusted by CPI.")
+}
@@ -0,0 +1,82 @@
+package display
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Reconciliation displays each payslip's actual and expected deductions,
+// marking those that differ by more than the tolerance, then the findings.
+// Deductions missing from the payslips are shown as "-"
+func Reconciliation(rec *types.Reconciliation) {
+	if len(rec.Payslips) == 0 {
+		return
+	}
+
+	hasStudentLoan := false
+	for _, payslip := range rec.Payslips {
+		hasStudentLoan = hasStudentLoan || payslip.Actual.StudentLoanRepayment != 0 || payslip.Expected.StudentLoanRepayment != 0
+	}
+
+	fmt.Fprintf(stdout, "\nPayslip Reconciliation for %d (%s) - Tax Code %s\n\n", rec.TaxYear, getFrequencyLabel(rec.Frequency), rec.TaxCode)
+
+	headers := []string{"Gross", "Tax", "Expected", "NI", "Expected"}
+	if hasStudentLoan {
+		headers = append(headers, "Student Loan", "Expected")
+	}
+
+	fmt.Fprintf(stdout, "%-9s %-10s", payslipPeriodName(rec.Frequency), "Date")
+	for _, header := range headers {
+		fmt.Fprintf(stdout, " %*s", payslipColWidth, header)
+	}
+	fmt.Fprintln(stdout, "  Status")
+	fmt.Fprintln(stdout, strings.Repeat("─", 9+11+len(headers)*(payslipColWidth+1)+8))
+
+	for _, payslip := range rec.Payslips {
+		values := []string{
+			formatCurrency(payslip.Actual.GrossPay),
+			actualDeduction(rec, tax.FieldTax, payslip.Actual.TaxPaid), formatCurrency(payslip.Expected.TaxPaid),
+			actualDeduction(rec, tax.FieldNI, payslip.Actual.NationalInsurance), formatCurrency(payslip.Expected.NationalInsurance),
+		}
+		if hasStudentLoan {
+			values = append(values, actualDeduction(rec, tax.FieldStudentLoan, payslip.Actual.StudentLoanRepayment), formatCurrency(payslip.Expected.StudentLoanRepayment))
+		}
+
+		fmt.Fprintf(stdout, "%-9d %-10s", payslip.Actual.Period, payslip.Actual.Date)
+		for _, value := range values {
+			fmt.Fprintf(stdout, " %*s", payslipColWidth, value)
+		}
+		fmt.Fprintf(stdout, "  %s\n", reconcileStatus(payslip.Differences))
+	}
+
+	fmt.Fprintf(stdout, "\nFindings (tolerance %s):\n", formatCurrency(rec.Tolerance))
+	for _, finding := range rec.Findings {
+		fmt.Fprintf(stdout, "  • %s\n", finding)
+	}
+	fmt.Fprintln(stdout)
+}
+
+// actualDeduction formats a payslip deduction, or "-" when the payslips have no column for it
+func actualDeduction(rec *types.Reconciliation, field string, amount float64) string {
+	if slices.Contains(rec.Missing, field) {
+		return "-"
+	}
+	return formatCurrency(amount)
+}
+
+// reconcileStatus returns ✓ for a matching payslip, or ✗ and the deductions that differ
+func reconcileStatus(differences []types.PayslipDifference) string {
+	if len(differences) == 0 {
+		return "✓"
+	}
+
+	fields := make([]string, len(differences))
+	for i, difference := range differences {
+		fields[i] = difference.Field
+	}
+	return "✗ " + strings.Join(fields, ", ")
+}
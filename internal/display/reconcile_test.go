@@ -0,0 +1,90 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func sampleReconciliation() *types.Reconciliation {
+	slips := samplePayslips("1257L", "1257L")
+	slips[0].Date = "2025-04-25"
+	slips[1].Date = "2025-05-23"
+
+	actual := slips[1]
+	actual.TaxPaid = 1000.0
+
+	return &types.Reconciliation{
+		TaxYear:   2025,
+		Frequency: "monthly",
+		TaxCode:   "1257L",
+		Tolerance: 1.0,
+		Payslips: []types.ReconciledPayslip{
+			{Actual: slips[0], Expected: slips[0]},
+			{Actual: actual, Expected: slips[1], Differences: []types.PayslipDifference{
+				{Field: "Tax", Actual: 1000.0, Expected: 952.67},
+			}},
+		},
+		Findings: []string{"Tax matches tax code BR rather than 1257L"},
+	}
+}
+
+func TestReconciliation(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Reconciliation(sampleReconciliation())
+	})
+
+	assert.Contains(t, output, "Payslip Reconciliation for 2025 (Monthly) - Tax Code 1257L")
+	assert.Contains(t, output, "2025-04-25")
+	assert.Contains(t, output, "£1,000.00")
+	assert.Contains(t, output, "✓")
+	assert.Contains(t, output, "✗ Tax")
+	assert.Contains(t, output, "Findings (tolerance £1.00):")
+	assert.Contains(t, output, "• Tax matches tax code BR rather than 1257L")
+
+	// The student loan columns are hidden when nothing was deducted
+	assert.NotContains(t, output, "Student Loan")
+}
+
+func TestReconciliation_MissingColumn(t *testing.T) {
+	rec := sampleReconciliation()
+	rec.Missing = []string{"NI"}
+	for i := range rec.Payslips {
+		rec.Payslips[i].Actual.NationalInsurance = 0
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Reconciliation(rec)
+	})
+
+	// A deduction with no column is shown as "-" rather than £0.00
+	assert.Regexp(t, `£952\.67 +- +£`, output)
+}
+
+func TestReconciliation_StudentLoan(t *testing.T) {
+	rec := sampleReconciliation()
+	rec.Payslips[0].Expected.StudentLoanRepayment = 123.0
+
+	output := testutil.CaptureStdout(t, func() {
+		Reconciliation(rec)
+	})
+
+	assert.Contains(t, output, "Student Loan")
+	assert.Contains(t, output, "£123.00")
+}
+
+func TestReconciliation_Empty(t *testing.T) {
+	output := testutil.CaptureStdout(t, func() {
+		Reconciliation(&types.Reconciliation{})
+	})
+
+	assert.Empty(t, output)
+}
+
+func TestReconcileStatus(t *testing.T) {
+	assert.Equal(t, "✓", reconcileStatus(nil))
+	assert.Equal(t, "✗ Tax, NI", reconcileStatus([]types.PayslipDifference{{Field: "Tax"}, {Field: "NI"}}))
+}
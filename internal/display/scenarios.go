@@ -0,0 +1,31 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// ScenarioHistory lists numbered scenarios with their gross and net pay for
+// the period, marking the last as the current one
+func ScenarioHistory(results []types.ComparisonResult, period string) {
+	divisors := comparisonDivisors(results, period)
+	labelWidth := len("Scenario")
+	for _, result := range results {
+		labelWidth = max(labelWidth, displayWidth(result.Label))
+	}
+
+	fmt.Fprintf(stdout, "\nScenarios - %s\n\n", payperiod.Label(period))
+	fmt.Fprintf(stdout, "    %-*s  %14s  %14s\n", labelWidth, "Scenario", "Gross", "Net Pay")
+	for i, result := range results {
+		marker := " "
+		if i == len(results)-1 {
+			marker = "*"
+		}
+		fmt.Fprintf(stdout, "%s%2d %s  %14s  %14s\n", marker, i+1, padRight(result.Label, labelWidth),
+			formatCurrency(result.Response.GrossPay/divisors[i]),
+			formatCurrency(result.Response.NetPay/divisors[i]))
+	}
+	fmt.Fprintln(stdout)
+}
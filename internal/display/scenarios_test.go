@@ -0,0 +1,29 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestScenarioHistory(t *testing.T) {
+	results := []types.ComparisonResult{
+		{Label: "Scenario 1", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse()},
+		{Label: "Pension 8%", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.NetPay = 36600
+		})},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		ScenarioHistory(results, "monthly")
+	})
+	assert.Contains(t, output, "Scenarios - Monthly")
+	assert.Contains(t, output, "  1 Scenario 1  ")
+	assert.Contains(t, output, "£4,166.67")
+	assert.Contains(t, output, "£3,191.32")
+	assert.Contains(t, output, "* 2 Pension 8%  ")
+	assert.Contains(t, output, "£3,050.00")
+}
@@ -0,0 +1,259 @@
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Style is how text output is drawn
+type Style string
+
+// Output styles
+const (
+	// StyleUnicode draws tables with box-drawing characters and the £ sign
+	StyleUnicode Style = "unicode"
+	// StyleASCII draws tables with ASCII borders and amounts without the £ sign
+	StyleASCII Style = "ascii"
+	// StylePlain writes "label: value" lines in ASCII without tables, for
+	// screen readers and log files
+	StylePlain Style = "plain"
+)
+
+// Styles are the style names, for help text
+var Styles = []string{string(StyleUnicode), string(StyleASCII), string(StylePlain)}
+
+// outputStyle is the style every display function draws in
+var outputStyle = StyleUnicode
+
+// stdout is where display functions write, in the output style
+var stdout = &styleWriter{}
+
+// ParseStyle parses a style name
+func ParseStyle(name string) (Style, error) {
+	for _, style := range Styles {
+		if name == style {
+			return Style(name), nil
+		}
+	}
+	return "", fmt.Errorf("style must be one of %s, got: %s", strings.Join(Styles, ", "), name)
+}
+
+// SetStyle sets the style for all output
+func SetStyle(style Style) {
+	outputStyle = style
+	stdout.reset()
+}
+
+// CurrentStyle returns the style output is drawn in
+func CurrentStyle() Style {
+	return outputStyle
+}
+
+// asciiReplacer swaps the Unicode characters used in tables and notes for
+// ASCII ones. Box-drawing characters keep their width so tables still line up
+var asciiReplacer = strings.NewReplacer(
+	"═", "=", "─", "-", "║", "|", "│", "|",
+	"╔", "+", "╗", "+", "╚", "+", "╝", "+", "╠", "+", "╣", "+", "╦", "+", "╩", "+", "╬", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "├", "+", "┤", "+",
+	"•", "*", "…", "~", "→", "->", "×", "x", "⚠", "!", "✓", "OK", "✗", "X", "£", "GBP ",
+)
+
+// tableBorderRunes are the characters a table's borders are drawn with
+const tableBorderRunes = "═─╔╗╚╝╠╣╦╩╬┌┐└┘├┤"
+
+// styleWriter writes output in the output style. Unicode output is passed
+// straight through; ASCII and plain output are rewritten a line at a time,
+// and both swap Unicode characters and the £ sign for ASCII.
+type styleWriter struct {
+	// w is where output goes, or nil for os.Stdout at the time of writing
+	w io.Writer
+
+	line    []byte
+	headers []string
+}
+
+// newStyleWriter returns a writer to w in the output style. Call flush when done
+func newStyleWriter(w io.Writer) *styleWriter {
+	return &styleWriter{w: w}
+}
+
+func (s *styleWriter) target() io.Writer {
+	if s.w == nil {
+		return os.Stdout
+	}
+	return s.w
+}
+
+func (s *styleWriter) Write(p []byte) (int, error) {
+	if outputStyle == StyleUnicode {
+		return s.target().Write(p)
+	}
+
+	s.line = append(s.line, p...)
+	for {
+		end := bytes.IndexByte(s.line, '\n')
+		if end < 0 {
+			return len(p), nil
+		}
+		line := string(s.line[:end])
+		s.line = s.line[end+1:]
+		if out, ok := s.rewrite(line); ok {
+			if _, err := io.WriteString(s.target(), out+"\n"); err != nil {
+				return 0, err
+			}
+		}
+	}
+}
+
+// flush writes any partial line
+func (s *styleWriter) flush() {
+	if len(s.line) == 0 {
+		return
+	}
+	if out, ok := s.rewrite(string(s.line)); ok {
+		_, _ = io.WriteString(s.target(), out)
+	}
+	s.reset()
+}
+
+// reset drops any partial line and table headers
+func (s *styleWriter) reset() {
+	s.line = nil
+	s.headers = nil
+}
+
+// rewrite returns a line in the output style, or false to leave it out
+func (s *styleWriter) rewrite(line string) (string, bool) {
+	if outputStyle == StyleASCII {
+		return asciiReplacer.Replace(line), true
+	}
+	out, ok := s.plain(line)
+	return asciiReplacer.Replace(out), ok
+}
+
+// plain turns a table line into "label: value" text. Borders are left out;
+// a row of one label and value becomes "label: value", and a row of a table
+// with several columns becomes "label: column value, column value". Other
+// lines have runs of spaces used for alignment collapsed.
+func (s *styleWriter) plain(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed != "" && strings.Trim(trimmed, tableBorderRunes) == "" {
+		// A top or bottom border starts a new table
+		if strings.ContainsAny(trimmed, "╔╚") {
+			s.headers = nil
+		}
+		return "", false
+	}
+
+	if !strings.HasPrefix(trimmed, "║") {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+		return indent + strings.Join(strings.Fields(line), " "), true
+	}
+
+	cells := strings.Split(strings.Trim(trimmed, "║"), "║")
+	for i, cell := range cells {
+		cells[i] = strings.Join(strings.Fields(cell), " ")
+	}
+
+	switch {
+	case len(cells) == 1:
+		label, value, found := strings.Cut(strings.TrimSpace(strings.Trim(trimmed, "║")), "  ")
+		if !found {
+			return cells[0], true
+		}
+		return label + ": " + strings.TrimSpace(value), true
+	case s.headers == nil:
+		s.headers = cells
+		return "", false
+	default:
+		return plainRecord(cells[0], s.headers[min(1, len(s.headers)):], cells[1:]), true
+	}
+}
+
+// plainRecord returns a row of a table as "label: header value, header
+// value", leaving out empty values
+func plainRecord(label string, headers, values []string) string {
+	fields := []string{}
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		if i < len(headers) && headers[i] != "" {
+			value = headers[i] + " " + value
+		}
+		fields = append(fields, value)
+	}
+	return label + ": " + strings.Join(fields, ", ")
+}
+
+// listSeparator returns what separates items listed on one line
+func listSeparator() string {
+	if outputStyle == StylePlain {
+		return ", "
+	}
+	return " • "
+}
+
+// DisplayWidth returns how many columns text takes in a terminal, leaving out
+// colour escape sequences
+func DisplayWidth(text string) int {
+	return displayWidth(text)
+}
+
+// displayWidth returns how many columns text takes in a terminal: two for
+// wide East Asian characters, none for combining marks or colour escape
+// sequences and one otherwise
+func displayWidth(text string) int {
+	columns := 0
+	escape := false
+	for _, r := range text {
+		switch {
+		case r == '\x1b':
+			escape = true
+		case escape:
+			// An escape sequence ends with a letter, e.g. \x1b[32m
+			escape = !unicode.IsLetter(r)
+		case unicode.Is(unicode.Mn, r):
+		case width.LookupRune(r).Kind() == width.EastAsianWide, width.LookupRune(r).Kind() == width.EastAsianFullwidth:
+			columns += 2
+		default:
+			columns++
+		}
+	}
+	return columns
+}
+
+// padLeft right-aligns text in width columns
+func padLeft(text string, columns int) string {
+	return strings.Repeat(" ", max(0, columns-displayWidth(text))) + text
+}
+
+// padRight left-aligns text in width columns
+func padRight(text string, columns int) string {
+	return text + strings.Repeat(" ", max(0, columns-displayWidth(text)))
+}
+
+// truncateWidth shortens text to fit width columns, marking the cut with an
+// ellipsis. Plain output has no columns, so is never shortened
+func truncateWidth(text string, columns int) string {
+	if outputStyle == StylePlain || displayWidth(text) <= columns {
+		return text
+	}
+	var cut strings.Builder
+	used := 0
+	for _, r := range text {
+		w := displayWidth(string(r))
+		if used+w > columns-1 {
+			break
+		}
+		cut.WriteRune(r)
+		used += w
+	}
+	return cut.String() + "…"
+}
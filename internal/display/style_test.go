@@ -0,0 +1,167 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// useStyle sets the output style for a test
+func useStyle(t *testing.T, style Style) {
+	t.Helper()
+	SetStyle(style)
+	t.Cleanup(func() { SetStyle(StyleUnicode) })
+}
+
+// assertASCII fails the test if output has any non-ASCII characters
+func assertASCII(t *testing.T, output string) {
+	t.Helper()
+	for _, r := range output {
+		assert.Less(t, r, rune(128), "non-ASCII %q", r)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	style, err := ParseStyle("plain")
+	require.NoError(t, err)
+	assert.Equal(t, StylePlain, style)
+
+	_, err = ParseStyle("fancy")
+	assert.EqualError(t, err, "style must be one of unicode, ascii, plain, got: fancy")
+}
+
+func TestDisplayWidth(t *testing.T) {
+	assert.Equal(t, 10, displayWidth("£50,000.00"))
+	assert.Equal(t, 3, displayWidth(" • "))
+	assert.Equal(t, 4, displayWidth("職務"))
+	assert.Equal(t, 4, displayWidth("café"))
+}
+
+func TestTruncateWidth(t *testing.T) {
+	assert.Equal(t, "Short", truncateWidth("Short", 11))
+	assert.Equal(t, "More pensi…", truncateWidth("More pension", 11))
+	assert.Equal(t, "職務オファ…", truncateWidth("職務オファー二", 11))
+	assert.Equal(t, "Ünïcödé La…", truncateWidth("Ünïcödé Label", 11))
+}
+
+func TestPadRight_WideCharacters(t *testing.T) {
+	assert.Equal(t, "職務  |", padRight("職務", 6)+"|")
+	assert.Equal(t, "  £1.00", padLeft("£1.00", 7))
+}
+
+func TestSummary_StatusLineAligned(t *testing.T) {
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Married = "y"
+		r.Blind = "y"
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(testutil.CreateSampleTaxResponse(), "yearly", req)
+	})
+
+	// Every line of the box is the same width, though "•" is three bytes
+	assert.Contains(t, output, "║  • Married • Blind Allowance                 ║\n")
+}
+
+func TestSummary_ASCIIStyle(t *testing.T) {
+	useStyle(t, StyleASCII)
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) { r.Married = "y" })
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(testutil.CreateSampleTaxResponse(), "yearly", req)
+	})
+
+	assert.Contains(t, output, "+==============================================+\n")
+	assert.Contains(t, output, "|  * Married                                   |\n")
+	assert.Contains(t, output, "| Net Pay                            38,295.84 |\n")
+	assertASCII(t, output)
+}
+
+func TestSummary_PlainStyle(t *testing.T) {
+	useStyle(t, StylePlain)
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) { r.Married = "y" })
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(testutil.CreateSampleTaxResponse(), "yearly", req)
+	})
+
+	assert.Contains(t, output, "Tax Calculation for 2024 (uk) - Yearly\nStatus: Married\nGross Salary: GBP 50,000.00\n")
+	assert.Contains(t, output, "Net Pay: GBP 38,295.84\n")
+	assertASCII(t, output)
+}
+
+func TestComparison_PlainStyle(t *testing.T) {
+	useStyle(t, StylePlain)
+	results := []types.ComparisonResult{
+		{Label: "Current Job", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse()},
+		{Label: "A Much Better Offer", Request: testutil.CreateSampleTaxRequest(), Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+			r.NetPay = 45000
+		})},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Comparison(results, "yearly", false)
+	})
+
+	// Labels are not cut short, and each row names its option
+	assert.Contains(t, output, "Net Pay: Current Job GBP 38,295.84, A Much Better Offer GBP 45,000.00\n")
+	assert.NotContains(t, output, "Field")
+	assertASCII(t, output)
+}
+
+func TestPayslips_PlainStyle(t *testing.T) {
+	useStyle(t, StylePlain)
+	slips := []types.Payslip{
+		{Period: 1, TaxCode: "1257L", GrossPay: 4166.67, TaxPaid: 623.83, NationalInsurance: 351.51, NetPay: 3191.33,
+			GrossPayToDate: 4166.67, TaxPaidToDate: 623.83, NationalInsuranceToDate: 351.51, NetPayToDate: 3191.33},
+		{Period: 2, TaxCode: "1257L", GrossPay: 4166.67, TaxPaid: 623.83, NationalInsurance: 351.51, NetPay: 3191.33,
+			GrossPayToDate: 8333.34, TaxPaidToDate: 1247.66, NationalInsuranceToDate: 703.02, NetPayToDate: 6382.66},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Payslips(slips, testutil.CreateSampleTaxRequest(), "monthly")
+	})
+
+	// Each payslip is one line naming every value
+	assert.Contains(t, output, "Payslips for 2024 (uk) - Monthly - Tax Code 1257L\n\n"+
+		"Month 1: Gross GBP 4,166.67, Tax GBP 623.83, NI GBP 351.51, Net GBP 3,191.33, "+
+		"Gross YTD GBP 4,166.67, Tax YTD GBP 623.83, NI YTD GBP 351.51, Net YTD GBP 3,191.33\n")
+	assert.Contains(t, output, "Month 2: Gross GBP 4,166.67, Tax GBP 623.83, NI GBP 351.51, Net GBP 3,191.33, "+
+		"Gross YTD GBP 8,333.34, Tax YTD GBP 1,247.66, NI YTD GBP 703.02, Net YTD GBP 6,382.66\n")
+	assertASCII(t, output)
+}
+
+func TestExplain_PlainStyle(t *testing.T) {
+	useStyle(t, StylePlain)
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.Explanation = []types.ExplanationStep{
+			{Section: "Income Tax", Name: "Higher Rate", Amount: 4000, Base: 10000, Rate: 0.40, Threshold: 37700},
+			{Section: "Income Tax", Name: "Total income tax", Amount: 11540},
+			{Section: "National Insurance", Name: "Difference", Amount: -12.5, Note: "worked out per pay period"},
+		}
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		Explain(resp, "yearly", testutil.CreateSampleTaxRequest())
+	})
+
+	// Each step gives its amount first, then how it is reached
+	assert.Contains(t, output, "Income Tax\n"+
+		"  Higher Rate: GBP 4,000.00 (GBP 10,000.00 over GBP 37,700.00 x 40%)\n"+
+		"  Total income tax: GBP 11,540.00\n")
+	assert.Contains(t, output, "  Difference: -GBP 12.50\n    worked out per pay period\n")
+	assertASCII(t, output)
+}
+
+func TestFprintSummary_PlainStyleFlushes(t *testing.T) {
+	useStyle(t, StylePlain)
+
+	var buf bytes.Buffer
+	FprintSummary(&buf, testutil.CreateSampleTaxResponse(), "monthly", testutil.CreateSampleTaxRequest())
+	assert.Contains(t, buf.String(), "Gross Salary: GBP 4,166.67\n")
+}
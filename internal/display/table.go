@@ -2,28 +2,52 @@ package display
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
 	"github.com/mheap/listentotaxman-cli/internal/types"
 )
 
-// formatCurrency formats a float as currency with the £ symbol and thousand separators
+// formatCurrency formats an amount in pounds in the display currency, with
+// its symbol, thousand separators and decimal places
 func formatCurrency(amount float64) string {
-	// Format to 2 decimal places
-	str := fmt.Sprintf("%.2f", amount)
+	return formatMoney(amount, currencyFormat.Decimals)
+}
+
+// formatMoney converts an amount in pounds to the display currency and
+// formats it to a number of decimal places
+func formatMoney(amount float64, decimals int) string {
+	// Round halves away from zero, as FormatFloat rounds them to even
+	scale := math.Pow(10, float64(decimals))
+	str := strconv.FormatFloat(math.Round(amount*currencyFormat.Rate*scale)/scale, 'f', decimals, 64)
+
+	// Negative amounts are signed before the symbol, e.g. -£291.67
+	sign := ""
+	if strings.HasPrefix(str, "-") {
+		str = str[1:]
+		if strings.Trim(str, "0.") != "" {
+			sign = "-"
+		}
+	}
 
 	// Split into integer and decimal parts
-	parts := strings.Split(str, ".")
-	intPart := parts[0]
-	decPart := parts[1]
+	intPart, decPart, found := strings.Cut(str, ".")
 
 	// Add thousand separators to integer part
 	intPart = addThousandSeparators(intPart)
 
-	return fmt.Sprintf("£%s.%s", intPart, decPart)
+	if !found {
+		return sign + currencySymbol() + intPart
+	}
+	return sign + currencySymbol() + intPart + decimalSeparator() + decPart
 }
 
-// addThousandSeparators adds commas as thousand separators to a number string
+// addThousandSeparators adds the thousands separator to a number string
 func addThousandSeparators(s string) string {
 	// Handle negative numbers
 	negative := false
@@ -32,7 +56,7 @@ func addThousandSeparators(s string) string {
 		s = s[1:]
 	}
 
-	// Add commas from right to left
+	// Add separators from right to left
 	n := len(s)
 	if n <= 3 {
 		if negative {
@@ -44,7 +68,7 @@ func addThousandSeparators(s string) string {
 	var result strings.Builder
 	for i, digit := range s {
 		if i > 0 && (n-i)%3 == 0 {
-			result.WriteRune(',')
+			result.WriteString(currencyFormat.ThousandsSeparator)
 		}
 		result.WriteRune(digit)
 	}
@@ -55,48 +79,93 @@ func addThousandSeparators(s string) string {
 	return result.String()
 }
 
-// getPeriodDivisor returns the divisor for a given period
-func getPeriodDivisor(period string) float64 {
-	switch period {
-	case "yearly":
-		return 1.0
-	case "monthly":
-		return 12.0
-	case "weekly":
-		return 52.0
-	case "daily":
-		return 365.0
-	case "hourly":
-		return 2080.0
-	default:
-		return 1.0
-	}
-}
-
-// getPeriodLabel returns human-readable label for period
-func getPeriodLabel(period string) string {
-	switch period {
-	case "yearly":
-		return "Yearly"
-	case "monthly":
-		return "Monthly"
-	case "weekly":
-		return "Weekly"
-	case "daily":
-		return "Daily"
-	case "hourly":
-		return "Hourly"
-	default:
-		return "Yearly"
-	}
+// FormatWholePounds formats an amount rounded to whole units of the display
+// currency, e.g. £45,000
+func FormatWholePounds(amount float64) string {
+	return formatMoney(amount, 0)
 }
 
 // Summary displays the tax calculation as a summary table (Option A)
 func Summary(resp *types.TaxResponse, period string, req *types.TaxRequest) {
-	divisor := getPeriodDivisor(period)
-	periodLabel := getPeriodLabel(period)
+	FprintSummary(os.Stdout, resp, period, req)
+}
+
+// FprintSummary writes the summary table to w
+func FprintSummary(w io.Writer, resp *types.TaxResponse, period string, req *types.TaxRequest) {
+	styled := newStyleWriter(w)
+	defer styled.flush()
+	w = styled
+
+	divisor := payperiod.Divisor(period, req.WorkPattern)
+	periodLabel := payperiod.Label(period)
+	statusLine := summaryStatus(req, period)
+
+	// Calculate padding for header alignment (total width = 45 chars inside borders)
+	headerText := fmt.Sprintf("Tax Calculation for %d (%s) - %s", resp.TaxYear, resp.TaxRegion, periodLabel)
+	padding := 45 - displayWidth(headerText)
 
-	// Build status line if any status flags are active
+	fmt.Fprintf(w, "\n╔══════════════════════════════════════════════╗\n")
+	fmt.Fprintf(w, "║ %s%*s║\n", headerText, padding, "")
+	if statusLine != "" {
+		statusPadding := 45 - displayWidth(statusLine)
+		fmt.Fprintf(w, "║ %s%*s║\n", statusLine, statusPadding, "")
+	}
+	if note := currencyNote(); note != "" {
+		fmt.Fprintf(w, "║ %s║\n", padRight(note, 45))
+	}
+	fmt.Fprintf(w, "╠══════════════════════════════════════════════╣\n")
+
+	// Main income and deductions - use right-aligned currency with proper width
+	fmt.Fprintf(w, "║ %-25s %18s ║\n", grossLabel(resp), formatCurrency(resp.GrossPay/divisor))
+	if resp.OtherIncome != nil {
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Other Income", formatCurrency(otherIncomeTotal(resp)/divisor))
+	}
+	fmt.Fprintf(w, "║ %-25s %18s ║\n", "Taxable Pay", formatCurrency(resp.TaxablePay/divisor))
+	printSummaryRow(w, "Tax Paid", resp.TaxPaid/divisor, roleDim)
+	printSummaryRow(w, "National Insurance", resp.NationalInsurance/divisor, roleDim)
+
+	if resp.StudentLoanRepayment > 0 {
+		printSummaryRow(w, "Student Loan", resp.StudentLoanRepayment/divisor, roleDim)
+	}
+
+	printSummaryRow(w, "Pension (You)", resp.PensionYou/divisor, roleDim)
+	if saving := employeeNISaving(resp); saving > 0 {
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "NI Saved by Sacrifice", formatCurrency(saving/divisor))
+	}
+	printSummaryRow(w, "Net Pay", resp.NetPay/divisor, roleHighlight)
+
+	fmt.Fprintf(w, "╠══════════════════════════════════════════════╣\n")
+
+	if se := resp.SelfEmployment; se != nil {
+		// Self assessment replaces employer costs for sole traders
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Class 2 NI", formatCurrency(se.Class2NI/divisor))
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Class 4 NI", formatCurrency(se.Class4NI/divisor))
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Pension (HMRC)", formatCurrency(resp.PensionHMRC/divisor))
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Self Assessment Bill", formatCurrency(se.TotalLiability/divisor))
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Payment on Account (x2)", formatCurrency(se.PaymentOnAccount/divisor))
+	} else {
+		// Employer costs
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Employer's NI", formatCurrency(resp.EmployersNI/divisor))
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Pension (HMRC)", formatCurrency(resp.PensionHMRC/divisor))
+		if employer := employerPension(resp); employer > 0 {
+			fmt.Fprintf(w, "║ %-25s %18s ║\n", "Pension (Employer)", formatCurrency(employer/divisor))
+		}
+		fmt.Fprintf(w, "║ %-25s %18s ║\n", "Total Cost", formatCurrency(TotalCost(resp)/divisor))
+	}
+
+	fmt.Fprintf(w, "╚══════════════════════════════════════════════╝\n\n")
+}
+
+// printSummaryRow prints a summary row in the colour for its role. Colour is
+// added after padding, so its escape sequences do not upset the alignment
+func printSummaryRow(w io.Writer, label string, amount float64, role colourRole) {
+	fmt.Fprintf(w, "║ %s %s ║\n", colourise(padRight(label, 25), role), colourise(padLeft(formatCurrency(amount), 18), role))
+}
+
+// summaryStatus returns the status line for any active status flags, a
+// non-standard number of pay days, any rate of pay and any part-time fraction,
+// or "" if there are none
+func summaryStatus(req *types.TaxRequest, period string) string {
 	statusParts := []string{}
 	if req.Married == "y" {
 		statusParts = append(statusParts, "Married")
@@ -107,55 +176,84 @@ func Summary(resp *types.TaxResponse, period string, req *types.TaxRequest) {
 	if req.ExNI == "y" {
 		statusParts = append(statusParts, "NI Exempt")
 	}
-	statusLine := ""
-	if len(statusParts) > 0 {
-		statusLine = " • " + strings.Join(statusParts, " • ")
+	if payDays := payperiod.PayDaysFor(period, req.WorkPattern); payDays > 0 {
+		statusParts = append(statusParts, fmt.Sprintf("%d Pay Days", payDays))
+	}
+	if req.Rate != nil {
+		statusParts = append(statusParts, rateLabel(req.Rate))
+	}
+	if req.ProRata != nil {
+		statusParts = append(statusParts, proRataLabel(req.ProRata))
+	}
+	if req.PensionScheme != "" {
+		statusParts = append(statusParts, pensionSchemeLabel(req.PensionScheme))
 	}
 
-	// Calculate padding for header alignment (total width = 45 chars inside borders)
-	headerText := fmt.Sprintf("Tax Calculation for %d (%s) - %s", resp.TaxYear, resp.TaxRegion, periodLabel)
-	padding := 45 - len(headerText)
-
-	fmt.Printf("\n╔══════════════════════════════════════════════╗\n")
-	fmt.Printf("║ %s%*s║\n", headerText, padding, "")
-	if statusLine != "" {
-		statusPadding := 45 - len(statusLine)
-		fmt.Printf("║ %s%*s║\n", statusLine, statusPadding, "")
+	if len(statusParts) == 0 {
+		return ""
 	}
-	fmt.Printf("╠══════════════════════════════════════════════╣\n")
+	if outputStyle == StylePlain {
+		return "Status: " + strings.Join(statusParts, listSeparator())
+	}
+	return " • " + strings.Join(statusParts, listSeparator())
+}
 
-	// Main income and deductions - use right-aligned currency with proper width
-	fmt.Printf("║ %-25s %18s ║\n", "Gross Salary", formatCurrency(resp.GrossPay/divisor))
-	fmt.Printf("║ %-25s %18s ║\n", "Taxable Pay", formatCurrency(resp.TaxablePay/divisor))
-	fmt.Printf("║ %-25s %18s ║\n", "Tax Paid", formatCurrency(resp.TaxPaid/divisor))
-	fmt.Printf("║ %-25s %18s ║\n", "National Insurance", formatCurrency(resp.NationalInsurance/divisor))
+// Detailed displays the tax calculation with detailed breakdown (Option B)
+func Detailed(resp *types.TaxResponse, period string, req *types.TaxRequest) {
+	divisor := payperiod.Divisor(period, req.WorkPattern)
 
-	if resp.StudentLoanRepayment > 0 {
-		fmt.Printf("║ %-25s %18s ║\n", "Student Loan", formatCurrency(resp.StudentLoanRepayment/divisor))
+	printDetailedHeader(resp, period, req)
+
+	// Income section
+	fmt.Fprintln(stdout, "Income:")
+	fmt.Fprintf(stdout, "  %-20s %15s\n", grossLabel(resp)+":", formatCurrency(resp.GrossPay/divisor))
+	if resp.AdditionalGross > 0 {
+		fmt.Fprintf(stdout, "  Additional Gross:    %15s\n", formatCurrency(resp.AdditionalGross/divisor))
 	}
+	fmt.Fprintf(stdout, "  Tax Free Allowance:  %15s\n", formatCurrency(resp.TaxFreeAllowance/divisor))
+	fmt.Fprintf(stdout, "  Taxable Pay:         %15s\n", formatCurrency(resp.TaxablePay/divisor))
+	fmt.Fprintln(stdout)
 
-	fmt.Printf("║ %-25s %18s ║\n", "Pension (You)", formatCurrency(resp.PensionYou/divisor))
-	fmt.Printf("║ %-25s %18s ║\n", "Net Pay", formatCurrency(resp.NetPay/divisor))
+	if resp.OtherIncome != nil {
+		printOtherIncome(resp.OtherIncome, divisor)
+	}
 
-	fmt.Printf("╠══════════════════════════════════════════════╣\n")
+	printTaxBreakdown(resp, divisor)
+	printDeductions(resp, divisor)
 
-	// Employer costs
-	totalCost := resp.GrossPay + resp.EmployersNI + resp.PensionHMRC
-	fmt.Printf("║ %-25s %18s ║\n", "Employer's NI", formatCurrency(resp.EmployersNI/divisor))
-	fmt.Printf("║ %-25s %18s ║\n", "Pension (HMRC)", formatCurrency(resp.PensionHMRC/divisor))
-	fmt.Printf("║ %-25s %18s ║\n", "Total Cost", formatCurrency(totalCost/divisor))
+	// Net pay
+	fmt.Fprintf(stdout, "Net Pay:               %15s\n", formatCurrency(resp.NetPay/divisor))
+	fmt.Fprintln(stdout)
 
-	fmt.Printf("╚══════════════════════════════════════════════╝\n\n")
-}
+	// Self assessment replaces employer costs for sole traders
+	if resp.SelfEmployment != nil {
+		printSelfAssessment(resp, divisor)
+		return
+	}
 
-// Detailed displays the tax calculation with detailed breakdown (Option B)
-func Detailed(resp *types.TaxResponse, period string, req *types.TaxRequest) {
-	divisor := getPeriodDivisor(period)
-	periodLabel := getPeriodLabel(period)
+	if resp.PensionScheme != nil {
+		printPensionScheme(resp.PensionScheme, divisor)
+	}
+	printEmployerCosts(resp, divisor)
+}
 
-	fmt.Printf("Tax Year: %d (%s) - %s\n", resp.TaxYear, resp.TaxRegion, periodLabel)
+// printDetailedHeader prints the tax year, period and what the calculation is
+// based on: the tax code, any rate of pay or pro rata pattern and the status flags
+func printDetailedHeader(resp *types.TaxResponse, period string, req *types.TaxRequest) {
+	fmt.Fprintf(stdout, "Tax Year: %d (%s) - %s", resp.TaxYear, resp.TaxRegion, payperiod.Label(period))
+	if payDays := payperiod.PayDaysFor(period, req.WorkPattern); payDays > 0 {
+		fmt.Fprintf(stdout, " (%d pay days)", payDays)
+	}
+	fmt.Fprintln(stdout)
+	printCurrencyNote()
 	if resp.TaxCode != "" {
-		fmt.Printf("Tax Code: %s\n", resp.TaxCode)
+		fmt.Fprintf(stdout, "Tax Code: %s\n", resp.TaxCode)
+	}
+	if req.Rate != nil {
+		fmt.Fprintf(stdout, "Rate: %s\n", rateBreakdown(req.Rate))
+	}
+	if req.ProRata != nil {
+		fmt.Fprintf(stdout, "Pro Rata: %s\n", proRataBreakdown(req.ProRata))
 	}
 
 	// Show status flags if any are active
@@ -170,61 +268,183 @@ func Detailed(resp *types.TaxResponse, period string, req *types.TaxRequest) {
 		statusParts = append(statusParts, "NI Exempt")
 	}
 	if len(statusParts) > 0 {
-		fmt.Printf("Status: %s\n", strings.Join(statusParts, " • "))
+		fmt.Fprintf(stdout, "Status: %s\n", strings.Join(statusParts, listSeparator()))
 	}
 
-	fmt.Println()
+	fmt.Fprintln(stdout)
+}
 
-	// Income section
-	fmt.Println("Income:")
-	fmt.Printf("  Gross Salary:        %15s\n", formatCurrency(resp.GrossPay/divisor))
-	if resp.AdditionalGross > 0 {
-		fmt.Printf("  Additional Gross:    %15s\n", formatCurrency(resp.AdditionalGross/divisor))
+// printTaxBreakdown prints the tax in each band and the total
+func printTaxBreakdown(resp *types.TaxResponse, divisor float64) {
+	fmt.Fprintln(stdout, "Tax Breakdown:")
+
+	// Sort tax brackets by band (0 = basic, 1 = higher, 2 = additional)
+	for _, key := range sortedBracketKeys(resp.TaxDue) {
+		bracket := resp.TaxDue[key]
+		if bracket.Amount <= 0 {
+			continue
+		}
+		label := fmt.Sprintf("%s (%s%%):", bracketLabel(key, bracket), formatRate(bracket.Rate))
+		fmt.Fprintf(stdout, "  %-20s %15s\n", label, formatCurrency(bracket.Amount/divisor))
 	}
-	fmt.Printf("  Tax Free Allowance:  %15s\n", formatCurrency(resp.TaxFreeAllowance/divisor))
-	fmt.Printf("  Taxable Pay:         %15s\n", formatCurrency(resp.TaxablePay/divisor))
-	fmt.Println()
+	fmt.Fprintf(stdout, "  Total Tax:           %15s\n", formatCurrency(resp.TaxPaid/divisor))
+	fmt.Fprintln(stdout)
+}
 
-	// Tax breakdown
-	fmt.Println("Tax Breakdown:")
+// printDeductions prints National Insurance, student loan and pension
+// deductions and their total with tax
+func printDeductions(resp *types.TaxResponse, divisor float64) {
+	fmt.Fprintln(stdout, "Deductions:")
+	if se := resp.SelfEmployment; se != nil {
+		fmt.Fprintf(stdout, "  Class 2 NI:          %15s\n", formatCurrency(se.Class2NI/divisor))
+		fmt.Fprintf(stdout, "  Class 4 NI:          %15s\n", formatCurrency(se.Class4NI/divisor))
+	} else {
+		fmt.Fprintf(stdout, "  National Insurance:  %15s\n", formatCurrency(resp.NationalInsurance/divisor))
+	}
+	if resp.StudentLoanRepayment > 0 {
+		fmt.Fprintf(stdout, "  Student Loan:        %15s\n", formatCurrency(resp.StudentLoanRepayment/divisor))
+	}
+	fmt.Fprintf(stdout, "  Pension (You):       %15s\n", formatCurrency(resp.PensionYou/divisor))
 
-	// Sort tax brackets by rate (0 = basic, 1 = higher, 2 = additional)
-	if bracket, ok := resp.TaxDue["0"]; ok && bracket.Amount > 0 {
-		ratePercent := bracket.Rate * 100
-		fmt.Printf("  Basic Rate (%.0f%%):    %15s\n", ratePercent, formatCurrency(bracket.Amount/divisor))
+	totalDeductions := resp.TaxPaid + resp.NationalInsurance + resp.StudentLoanRepayment + resp.PensionYou
+	fmt.Fprintf(stdout, "  Total Deductions:    %15s\n", formatCurrency(totalDeductions/divisor))
+	fmt.Fprintln(stdout)
+}
+
+// printSelfAssessment prints a sole trader's pension relief, tax bill and
+// payments on account
+func printSelfAssessment(resp *types.TaxResponse, divisor float64) {
+	se := resp.SelfEmployment
+	fmt.Fprintln(stdout, "Self Assessment:")
+	fmt.Fprintf(stdout, "  Pension (HMRC):      %15s\n", formatCurrency(resp.PensionHMRC/divisor))
+	if resp.PensionClaimback > 0 {
+		fmt.Fprintf(stdout, "  Pension Claimback:   %15s\n", formatCurrency(resp.PensionClaimback/divisor))
 	}
-	if bracket, ok := resp.TaxDue["1"]; ok && bracket.Amount > 0 {
-		ratePercent := bracket.Rate * 100
-		fmt.Printf("  Higher Rate (%.0f%%):   %15s\n", ratePercent, formatCurrency(bracket.Amount/divisor))
+	fmt.Fprintf(stdout, "  Total Bill:          %15s\n", formatCurrency(se.TotalLiability/divisor))
+	fmt.Fprintf(stdout, "  Payment on Account:  %15s (x2)\n", formatCurrency(se.PaymentOnAccount/divisor))
+}
+
+// printEmployerCosts prints employer's NI, pension contributions and the total cost
+func printEmployerCosts(resp *types.TaxResponse, divisor float64) {
+	fmt.Fprintln(stdout, "Employer Costs:")
+	fmt.Fprintf(stdout, "  Employer's NI:       %15s\n", formatCurrency(resp.EmployersNI/divisor))
+	fmt.Fprintf(stdout, "  Pension (HMRC):      %15s\n", formatCurrency(resp.PensionHMRC/divisor))
+	if employer := employerPension(resp); employer > 0 {
+		fmt.Fprintf(stdout, "  Pension (Employer):  %15s\n", formatCurrency(employer/divisor))
 	}
-	if bracket, ok := resp.TaxDue["2"]; ok && bracket.Amount > 0 {
-		ratePercent := bracket.Rate * 100
-		fmt.Printf("  Additional (%.0f%%):    %15s\n", ratePercent, formatCurrency(bracket.Amount/divisor))
+	fmt.Fprintf(stdout, "  Total Cost:          %15s\n", formatCurrency(TotalCost(resp)/divisor))
+}
+
+// printOtherIncome prints rental, savings and dividend income and the band each lands in
+func printOtherIncome(other *types.OtherIncome, divisor float64) {
+	fmt.Fprintln(stdout, "Other Income:")
+	if other.RentalProfit > 0 {
+		fmt.Fprintf(stdout, "  Rental Profit:       %15s\n", formatCurrency(other.RentalProfit/divisor))
+	}
+	if other.SavingsInterest > 0 {
+		fmt.Fprintf(stdout, "  Savings Interest:    %15s\n", formatCurrency(other.SavingsInterest/divisor))
 	}
-	fmt.Printf("  Total Tax:           %15s\n", formatCurrency(resp.TaxPaid/divisor))
-	fmt.Println()
+	if other.Dividends > 0 {
+		fmt.Fprintf(stdout, "  Dividends:           %15s\n", formatCurrency(other.Dividends/divisor))
+	}
+	if other.AllowanceLost > 0 {
+		fmt.Fprintf(stdout, "  Allowance Lost:      %15s\n", formatCurrency(other.AllowanceLost/divisor))
+	}
+	fmt.Fprintln(stdout)
 
-	// Deductions
-	fmt.Println("Deductions:")
-	fmt.Printf("  National Insurance:  %15s\n", formatCurrency(resp.NationalInsurance/divisor))
-	if resp.StudentLoanRepayment > 0 {
-		fmt.Printf("  Student Loan:        %15s\n", formatCurrency(resp.StudentLoanRepayment/divisor))
+	fmt.Fprintln(stdout, "Other Income by Band:")
+	for _, band := range other.Bands {
+		label := fmt.Sprintf("%s (%s%%)", band.Band, formatRate(band.Rate))
+		fmt.Fprintf(stdout, "  %-18s %-32s %12s  tax %12s\n", band.Income, label,
+			formatCurrency(band.Amount/divisor), formatCurrency(band.Tax/divisor))
 	}
-	fmt.Printf("  Pension (You):       %15s\n", formatCurrency(resp.PensionYou/divisor))
+	fmt.Fprintf(stdout, "  Total Tax:           %15s\n", formatCurrency(other.Tax/divisor))
+	fmt.Fprintln(stdout)
+}
 
-	totalDeductions := resp.TaxPaid + resp.NationalInsurance + resp.StudentLoanRepayment + resp.PensionYou
-	fmt.Printf("  Total Deductions:    %15s\n", formatCurrency(totalDeductions/divisor))
-	fmt.Println()
+// otherIncomeTotal returns the combined rental, savings and dividend income
+func otherIncomeTotal(resp *types.TaxResponse) float64 {
+	if resp.OtherIncome == nil {
+		return 0
+	}
+	return resp.OtherIncome.RentalProfit + resp.OtherIncome.SavingsInterest + resp.OtherIncome.Dividends
+}
 
-	// Net pay
-	fmt.Printf("Net Pay:               %15s\n", formatCurrency(resp.NetPay/divisor))
-	fmt.Println()
+// grossLabel returns the label for gross income, which is trading profit for sole traders
+func grossLabel(resp *types.TaxResponse) string {
+	if resp.SelfEmployment != nil {
+		return "Trading Profit"
+	}
+	return "Gross Salary"
+}
+
+// sortedBracketKeys returns the TaxDue keys in band order
+func sortedBracketKeys(taxDue map[string]types.TaxBracket) []string {
+	keys := make([]string, 0, len(taxDue))
+	for key := range taxDue {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA != nil || errB != nil {
+			return keys[i] < keys[j]
+		}
+		return a < b
+	})
+	return keys
+}
+
+// bracketLabel returns the band name, falling back to the API's band positions
+func bracketLabel(key string, bracket types.TaxBracket) string {
+	if bracket.Name != "" {
+		return bracket.Name
+	}
+	switch key {
+	case "0":
+		return "Basic Rate"
+	case "1":
+		return "Higher Rate"
+	case "2":
+		return "Additional"
+	default:
+		return "Band " + key
+	}
+}
 
-	// Employer costs
-	fmt.Println("Employer Costs:")
-	fmt.Printf("  Employer's NI:       %15s\n", formatCurrency(resp.EmployersNI/divisor))
-	fmt.Printf("  Pension (HMRC):      %15s\n", formatCurrency(resp.PensionHMRC/divisor))
+// formatRate formats a tax rate as a percentage without trailing zeros
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(math.Round(rate*10000)/100, 'f', -1, 64)
+}
 
-	totalCost := resp.GrossPay + resp.EmployersNI + resp.PensionHMRC
-	fmt.Printf("  Total Cost:          %15s\n", formatCurrency(totalCost/divisor))
+// rateLabel returns a rate of pay in short form (e.g. £45/hour)
+func rateLabel(rate *types.IncomeRate) string {
+	return formatShort(rate.Rate) + "/" + rate.Per
+}
+
+// rateBreakdown returns how a rate of pay converts to the annual salary
+func rateBreakdown(rate *types.IncomeRate) string {
+	return fmt.Sprintf("%s per %s × %s %ss = %s a year",
+		formatCurrency(rate.Rate), rate.Per, formatQuantity(rate.UnitsPerYear), rate.Per,
+		formatCurrency(float64(rate.AnnualIncome)))
+}
+
+// formatQuantity formats a count with thousand separators and up to 2 decimal places
+func formatQuantity(value float64) string {
+	str := strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+	intPart, decPart, found := strings.Cut(str, ".")
+	intPart = addThousandSeparators(intPart)
+	if found {
+		return intPart + decimalSeparator() + decPart
+	}
+	return intPart
+}
+
+// formatShort formats an amount without decimal places when it is a whole
+// number in the display currency
+func formatShort(amount float64) string {
+	if converted := math.Round(amount*currencyFormat.Rate*100) / 100; converted == math.Trunc(converted) {
+		return formatMoney(amount, 0)
+	}
+	return formatCurrency(amount)
 }
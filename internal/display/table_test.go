@@ -1,6 +1,7 @@
 package display
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,7 +22,8 @@ func TestFormatCurrency(t *testing.T) {
 		{"large amount", 1000000.00, "£1,000,000.00"},
 		{"zero", 0.00, "£0.00"},
 		{"rounding down", 123.456, "£123.46"},
-		{"negative", -500.00, "£-500.00"},
+		{"negative", -500.00, "-£500.00"},
+		{"negative rounds to zero", -0.001, "£0.00"},
 		{"rounding up", 999.999, "£1,000.00"},
 		{"no decimals", 5000.00, "£5,000.00"},
 	}
@@ -60,56 +62,6 @@ func TestAddThousandSeparators(t *testing.T) {
 	}
 }
 
-func TestGetPeriodDivisor(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name   string
-		period string
-		want   float64
-	}{
-		{"yearly", "yearly", 1.0},
-		{"monthly", "monthly", 12.0},
-		{"weekly", "weekly", 52.0},
-		{"daily", "daily", 365.0},
-		{"hourly", "hourly", 2080.0},
-		{"invalid", "invalid", 1.0},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got := getPeriodDivisor(tt.period)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
-func TestGetPeriodLabel(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name   string
-		period string
-		want   string
-	}{
-		{"yearly", "yearly", "Yearly"},
-		{"monthly", "monthly", "Monthly"},
-		{"weekly", "weekly", "Weekly"},
-		{"daily", "daily", "Daily"},
-		{"hourly", "hourly", "Hourly"},
-		{"invalid", "invalid", "Yearly"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got := getPeriodLabel(tt.period)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestSummary_BasicOutput(t *testing.T) {
 	resp := testutil.CreateSampleTaxResponse()
 	req := testutil.CreateSampleTaxRequest()
@@ -161,6 +113,56 @@ func TestSummary_WithAllStatus(t *testing.T) {
 	assert.Contains(t, output, "NI Exempt")
 }
 
+func TestSummary_PayDays(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.GrossPay = 53000.0
+	})
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.WorkPattern.PayDays = 53
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(resp, "weekly", req)
+	})
+
+	assert.Contains(t, output, "Tax Calculation for 2024 (uk) - Weekly")
+	assert.Contains(t, output, "53 Pay Days")
+	assert.Contains(t, output, "£1,000.00")
+
+	// Pay days do not apply to monthly figures
+	output = testutil.CaptureStdout(t, func() {
+		Summary(resp, "monthly", req)
+	})
+	assert.NotContains(t, output, "Pay Days")
+}
+
+func TestSummary_FourWeekly(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.GrossPay = 52000.0
+	})
+	req := testutil.CreateSampleTaxRequest()
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(resp, "four-weekly", req)
+	})
+
+	assert.Contains(t, output, "Tax Calculation for 2024 (uk) - Four-Weekly")
+	assert.Contains(t, output, "£4,000.00")
+}
+
+func TestDetailed_PayDays(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse()
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.WorkPattern.PayDays = 27
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		Detailed(resp, "fortnightly", req)
+	})
+
+	assert.Contains(t, output, "- Fortnightly (27 pay days)")
+}
+
 func TestSummary_NoStatus(t *testing.T) {
 	resp := testutil.CreateSampleTaxResponse()
 	req := testutil.CreateSampleTaxRequest()
@@ -188,3 +190,128 @@ func TestDetailed_StatusLine(t *testing.T) {
 
 	assert.Contains(t, output, "Status: Married • Blind Allowance")
 }
+
+func TestSummary_SelfEmployed(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.EmployersNI = 0
+		r.SelfEmployment = &types.SelfEmployment{
+			Profit:           50000.0,
+			Class4NI:         2262.0,
+			TotalLiability:   9748.0,
+			PaymentOnAccount: 4874.0,
+		}
+	})
+	req := testutil.CreateSampleTaxRequest()
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(resp, "yearly", req)
+	})
+
+	assert.Contains(t, output, "Trading Profit")
+	assert.Contains(t, output, "Class 4 NI")
+	assert.Contains(t, output, "£4,874.00")
+	assert.NotContains(t, output, "Employer's NI")
+	assert.NotContains(t, output, "Total Cost")
+}
+
+func TestDetailed_SelfEmployed(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.EmployersNI = 0
+		r.SelfEmployment = &types.SelfEmployment{
+			Profit:           50000.0,
+			Class2NI:         179.40,
+			Class4NI:         2262.0,
+			TotalLiability:   9927.40,
+			PaymentOnAccount: 4874.0,
+		}
+	})
+	req := testutil.CreateSampleTaxRequest()
+
+	output := testutil.CaptureStdout(t, func() {
+		Detailed(resp, "yearly", req)
+	})
+
+	assert.Contains(t, output, "Trading Profit:")
+	assert.Contains(t, output, "Class 2 NI:")
+	assert.Contains(t, output, "Self Assessment:")
+	assert.Contains(t, output, "Payment on Account:")
+	assert.NotContains(t, output, "Employer Costs:")
+}
+
+func TestDetailed_NamedBands(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.TaxDue = map[string]types.TaxBracket{
+			"0":  {Name: "Starter Rate", Rate: 0.19, Amount: 537.13},
+			"1":  {Name: "Basic Rate", Rate: 0.20, Amount: 2418.80},
+			"2":  {Name: "Intermediate", Rate: 0.21, Amount: 3395.22},
+			"3":  {Name: "Higher Rate", Rate: 0.42, Amount: 100.0},
+			"10": {Rate: 0.50, Amount: 1.0},
+		}
+	})
+	req := testutil.CreateSampleTaxRequest()
+
+	output := testutil.CaptureStdout(t, func() {
+		Detailed(resp, "yearly", req)
+	})
+
+	assert.Contains(t, output, "Starter Rate (19%):")
+	assert.Contains(t, output, "Intermediate (21%):")
+	assert.Contains(t, output, "Higher Rate (42%):")
+	assert.Contains(t, output, "Band 10 (50%):")
+	assert.Less(t, strings.Index(output, "Higher Rate"), strings.Index(output, "Band 10"))
+}
+
+func TestSummaryAndDetailed_OtherIncome(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.OtherIncome = &types.OtherIncome{
+			RentalProfit:    6000.0,
+			SavingsInterest: 1500.0,
+			Dividends:       2500.0,
+			AllowanceLost:   1000.0,
+			Tax:             1500.0,
+			Bands: []types.IncomeBand{
+				{Income: "Rental Profit", Band: "Basic Rate", Rate: 0.20, Amount: 6000.0, Tax: 1200.0},
+				{Income: "Dividends", Band: "Higher Rate", Rate: 0.3375, Amount: 2000.0, Tax: 675.0},
+			},
+		}
+	})
+	req := testutil.CreateSampleTaxRequest()
+
+	summary := testutil.CaptureStdout(t, func() {
+		Summary(resp, "yearly", req)
+	})
+	assert.Contains(t, summary, "Other Income")
+	assert.Contains(t, summary, "£10,000.00")
+
+	detailed := testutil.CaptureStdout(t, func() {
+		Detailed(resp, "yearly", req)
+	})
+	assert.Contains(t, detailed, "Rental Profit:")
+	assert.Contains(t, detailed, "Allowance Lost:")
+	assert.Contains(t, detailed, "Higher Rate (33.75%)")
+}
+
+func TestSummary_Rate(t *testing.T) {
+	resp := testutil.CreateSampleTaxResponse()
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Rate = &types.IncomeRate{Rate: 45, Per: "hour", UnitsPerYear: 2080, AnnualIncome: 93600}
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		Summary(resp, "yearly", req)
+	})
+	assert.Contains(t, output, "£45/hour")
+
+	output = testutil.CaptureStdout(t, func() {
+		Detailed(resp, "yearly", req)
+	})
+	assert.Contains(t, output, "Rate: £45.00 per hour × 2,080 hours = £93,600.00 a year")
+}
+
+func TestFormatQuantity(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "2,080", formatQuantity(2080))
+	assert.Equal(t, "1,762.5", formatQuantity(1762.5))
+	assert.Equal(t, "47.14", formatQuantity(47.142857))
+}
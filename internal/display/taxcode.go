@@ -0,0 +1,75 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+)
+
+// suffixMeanings describes the letter after the allowance in a tax code
+var suffixMeanings = map[string]string{
+	"L": "Entitled to the standard tax-free Personal Allowance",
+	"M": "Receiving Marriage Allowance transferred from a partner",
+	"N": "Transferring Marriage Allowance to a partner",
+	"T": "Other items HMRC needs to review in the tax code",
+}
+
+// TaxCode explains what each part of a tax code means. The flat band is the
+// band charged on all pay for BR and D codes.
+func TaxCode(code *tax.TaxCode, flat tax.Band, year int) {
+	fmt.Fprintf(stdout, "Tax Code: %s (%d)\n\n", code.String(), year)
+
+	for _, row := range taxCodeRows(code, flat) {
+		fmt.Fprintf(stdout, "  %-12s %s\n", row[0]+":", row[1])
+	}
+	fmt.Fprintln(stdout)
+}
+
+// taxCodeRows returns the label and explanation for each part of a tax code
+func taxCodeRows(code *tax.TaxCode, flat tax.Band) [][2]string {
+	rows := [][2]string{{"Region", taxCodeRegion(code.Region)}}
+
+	switch {
+	case code.NoTax:
+		rows = append(rows, [2]string{"Allowance", "Not needed: no tax is deducted from this pay"})
+	case code.FlatRate != "":
+		rows = append(rows, [2]string{"Allowance", fmt.Sprintf("None: all pay is taxed at the %s (%s%%)", flat.Name, formatRate(flat.Rate))})
+	case code.IsKCode():
+		rows = append(rows, [2]string{"Allowance", fmt.Sprintf("None: %s is added to taxable pay for the year", formatCurrency(-code.Allowance))})
+		rows = append(rows, [2]string{"K Code", "Tax deducted is capped at 50% of pay each period"})
+	case code.Allowance == 0:
+		rows = append(rows, [2]string{"Allowance", "None: all pay is taxed, starting from the lowest band"})
+	default:
+		rows = append(rows, [2]string{"Allowance", formatCurrency(code.Allowance) + " tax-free pay for the year"})
+	}
+
+	if meaning, ok := suffixMeanings[code.Suffix]; ok && code.Allowance > 0 {
+		rows = append(rows, [2]string{"Suffix " + code.Suffix, meaning})
+	}
+
+	return append(rows, [2]string{"Basis", taxCodeBasis(code.Basis)})
+}
+
+// taxCodeRegion explains the region prefix of a tax code
+func taxCodeRegion(region string) string {
+	switch region {
+	case "scotland":
+		return "Scotland (S prefix): Scottish income tax rates apply"
+	case "wales":
+		return "Wales (C prefix): Welsh income tax rates apply"
+	default:
+		return "England or Northern Ireland (no prefix): UK income tax rates apply"
+	}
+}
+
+// taxCodeBasis explains whether tax is worked out cumulatively or per period
+func taxCodeBasis(basis string) string {
+	switch basis {
+	case "":
+		return "Cumulative: tax is worked out on pay to date, so earlier over- or underpayments are corrected"
+	case tax.BasisX:
+		return "Emergency (X): each period is taxed on its own, and earlier over- or underpayments are not corrected"
+	default:
+		return fmt.Sprintf("Emergency (%s): each period is taxed on its own, and earlier over- or underpayments are not corrected", basis)
+	}
+}
@@ -0,0 +1,66 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+)
+
+func TestTaxCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		flat     tax.Band
+		expected []string
+		missing  []string
+	}{
+		{
+			code:     "1257L",
+			expected: []string{"Tax Code: 1257L (2025)", "England or Northern Ireland", "£12,570.00 tax-free pay", "Suffix L:", "Cumulative"},
+		},
+		{
+			code:     "C1383M W1",
+			expected: []string{"Wales (C prefix)", "£13,830.00", "Receiving Marriage Allowance", "Emergency (W1)"},
+		},
+		{
+			code:     "SK100 X",
+			expected: []string{"Scotland (S prefix)", "£1,000.00 is added", "K Code:", "Emergency (X)"},
+		},
+		{
+			code:     "BR",
+			flat:     tax.Band{Name: "Basic Rate", Rate: 0.20},
+			expected: []string{"taxed at the Basic Rate (20%)"},
+			missing:  []string{"Suffix"},
+		},
+		{
+			code:     "0T",
+			expected: []string{"starting from the lowest band"},
+			missing:  []string{"Suffix"},
+		},
+		{
+			code:     "NT",
+			expected: []string{"no tax is deducted"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			code, err := tax.ParseTaxCode(tt.code)
+			require.NoError(t, err)
+
+			output := testutil.CaptureStdout(t, func() {
+				TaxCode(code, tt.flat, 2025)
+			})
+
+			for _, expected := range tt.expected {
+				assert.Contains(t, output, expected)
+			}
+			for _, missing := range tt.missing {
+				assert.NotContains(t, output, missing)
+			}
+		})
+	}
+}
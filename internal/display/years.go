@@ -0,0 +1,141 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/tax"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// YearOnYear displays the same salary in the previous tax year alongside this
+// one, from the response's Previous figures
+func YearOnYear(resp *types.TaxResponse, period string, req *types.TaxRequest) {
+	if resp.Previous == nil {
+		return
+	}
+
+	previousReq := *req
+	previousReq.Year = previousYear(req.Year)
+	Years([]types.ComparisonResult{
+		{Label: TaxYearLabel(previousReq.Year), Request: &previousReq, Response: resp.Previous},
+		{Label: TaxYearLabel(req.Year), Request: req, Response: resp},
+	}, period)
+}
+
+// Years displays the same salary across tax years, with the change from the
+// first year to the last and the allowances, thresholds and rates that changed
+// between each year and the one before
+func Years(results []types.ComparisonResult, period string) {
+	if len(results) == 0 {
+		return
+	}
+
+	divisors := comparisonDivisors(results, period)
+	fieldColWidth := 20
+	valueColWidth := 12
+	columns := len(results) + 1
+
+	rows := []struct {
+		label     string
+		extractor func(*types.TaxResponse) float64
+	}{
+		{"Gross Salary", func(r *types.TaxResponse) float64 { return r.GrossPay }},
+		{"Tax Paid", func(r *types.TaxResponse) float64 { return r.TaxPaid }},
+		{"National Insurance", func(r *types.TaxResponse) float64 { return r.NationalInsurance }},
+		{"Student Loan", func(r *types.TaxResponse) float64 { return r.StudentLoanRepayment }},
+		{"Pension (You)", func(r *types.TaxResponse) float64 { return r.PensionYou }},
+		{"Net Pay", func(r *types.TaxResponse) float64 { return r.NetPay }},
+		{"Real Gross Salary", realGrossPay},
+		{"Real Net Pay", realNetPay},
+		{"Employer's NI", func(r *types.TaxResponse) float64 { return r.EmployersNI }},
+		{"Total Cost", TotalCost},
+	}
+
+	fmt.Fprintf(stdout, "\nTax Years - %s\n\n", payperiod.Label(period))
+	printCurrencyNote()
+	fmt.Fprintln(stdout, generateBorder(columns, fieldColWidth, valueColWidth, "top"))
+	fmt.Fprintf(stdout, "║ %-*s", fieldColWidth, "Field")
+	for _, result := range results {
+		fmt.Fprintf(stdout, " ║ %-*s", valueColWidth, result.Label)
+	}
+	fmt.Fprintf(stdout, " ║ %-*s ║\n", valueColWidth, "Change")
+	fmt.Fprintln(stdout, generateBorder(columns, fieldColWidth, valueColWidth, "middle"))
+
+	last := len(results) - 1
+	for _, row := range rows {
+		values := make([]float64, len(results))
+		hasValue := false
+		for i, result := range results {
+			values[i] = row.extractor(result.Response) / divisors[i]
+			hasValue = hasValue || values[i] != 0
+		}
+		if (row.label == "Student Loan" || row.label == "Real Gross Salary" || row.label == "Real Net Pay") && !hasValue {
+			continue
+		}
+
+		fmt.Fprintf(stdout, "║ %-*s", fieldColWidth, row.label)
+		for _, value := range values {
+			fmt.Fprintf(stdout, " ║ %*s", valueColWidth, formatCurrency(value))
+		}
+		fmt.Fprintf(stdout, " ║ %*s ║\n", valueColWidth, formatCurrency(values[last]-values[0]))
+	}
+
+	fmt.Fprintln(stdout, generateBorder(columns, fieldColWidth, valueColWidth, "bottom"))
+	printRealTermsNote(results)
+
+	printRateChanges(results)
+	fmt.Fprintln(stdout)
+}
+
+// printRateChanges explains which allowances, thresholds and rates changed
+// from each year to the next, and which thresholds were frozen
+func printRateChanges(results []types.ComparisonResult) {
+	for i := 1; i < len(results); i++ {
+		changes := results[i].Response.RateChanges
+		if len(changes) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(stdout, "\n%s → %s:\n", results[i-1].Label, results[i].Label)
+		frozen := []string{}
+		for _, change := range changes {
+			switch {
+			case change.From == change.To:
+				frozen = append(frozen, change.Name)
+			case change.Kind == tax.ChangeRate:
+				fmt.Fprintf(stdout, "  %s: %s%% → %s%%\n", change.Name, formatRate(change.From), formatRate(change.To))
+			default:
+				fmt.Fprintf(stdout, "  %s: %s → %s\n", change.Name, formatAllowance(change.From), formatAllowance(change.To))
+			}
+		}
+		if len(frozen) > 0 {
+			fmt.Fprintf(stdout, "  Frozen: %s\n", strings.Join(frozen, ", "))
+		}
+	}
+}
+
+// formatAllowance formats an allowance or threshold in whole pounds
+func formatAllowance(amount float64) string {
+	return strings.TrimSuffix(formatCurrency(amount), ".00")
+}
+
+// TaxYearLabel returns a tax year in the form 2025/26
+func TaxYearLabel(year string) string {
+	start, err := strconv.Atoi(year)
+	if err != nil {
+		return year
+	}
+	return fmt.Sprintf("%d/%02d", start, (start+1)%100)
+}
+
+// previousYear returns the tax year before the given one
+func previousYear(year string) string {
+	start, err := strconv.Atoi(year)
+	if err != nil {
+		return year
+	}
+	return strconv.Itoa(start - 1)
+}
@@ -0,0 +1,81 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestYears(t *testing.T) {
+	results := []types.ComparisonResult{
+		{
+			Label:   "2023/24",
+			Request: testutil.CreateSampleTaxRequest(),
+			Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+				r.NationalInsurance = 4304.45
+				r.NetPay = 38208.75
+			}),
+		},
+		{
+			Label:   "2024/25",
+			Request: testutil.CreateSampleTaxRequest(),
+			Response: testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+				r.NationalInsurance = 2994.40
+				r.NetPay = 39518.80
+				r.RateChanges = []types.RateChange{
+					{Name: "Personal Allowance", Kind: "threshold", From: 12570, To: 12570},
+					{Name: "Employee NI Rate", Kind: "rate", From: 0.10, To: 0.08},
+					{Name: "NI Secondary Threshold", Kind: "threshold", From: 9100, To: 5000},
+				}
+			}),
+		},
+	}
+
+	output := testutil.CaptureStdout(t, func() {
+		Years(results, "yearly")
+	})
+	assert.Contains(t, output, "Tax Years - Yearly")
+	assert.Contains(t, output, "║ Field                ║ 2023/24      ║ 2024/25      ║ Change       ║")
+	assert.Contains(t, output, "║ National Insurance   ║    £4,304.45 ║    £2,994.40 ║   -£1,310.05 ║")
+	assert.Contains(t, output, "║ Net Pay              ║   £38,208.75 ║   £39,518.80 ║    £1,310.05 ║")
+	assert.NotContains(t, output, "Student Loan")
+
+	assert.Contains(t, output, "2023/24 → 2024/25:")
+	assert.Contains(t, output, "  Employee NI Rate: 10% → 8%")
+	assert.Contains(t, output, "  NI Secondary Threshold: £9,100 → £5,000")
+	assert.Contains(t, output, "  Frozen: Personal Allowance")
+}
+
+func TestYearOnYear(t *testing.T) {
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Year = "2025"
+	})
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.Previous = testutil.CreateSampleTaxResponse(func(p *types.TaxResponse) {
+			p.NetPay = 36000.0
+		})
+	})
+
+	output := testutil.CaptureStdout(t, func() {
+		YearOnYear(resp, "monthly", req)
+	})
+	assert.Contains(t, output, "Tax Years - Monthly")
+	assert.Contains(t, output, "║ Field                ║ 2024/25      ║ 2025/26      ║ Change       ║")
+	assert.Contains(t, output, "║ Net Pay              ║    £3,000.00 ║    £3,191.32 ║      £191.32 ║")
+
+	// Nothing is shown without the previous year
+	resp.Previous = nil
+	output = testutil.CaptureStdout(t, func() {
+		YearOnYear(resp, "monthly", req)
+	})
+	assert.Empty(t, output)
+}
+
+func TestTaxYearLabel(t *testing.T) {
+	assert.Equal(t, "2025/26", TaxYearLabel("2025"))
+	assert.Equal(t, "1999/00", TaxYearLabel("1999"))
+	assert.Equal(t, "next", TaxYearLabel("next"))
+}
@@ -0,0 +1,135 @@
+// Package inflation converts amounts between tax years' prices with a
+// Consumer Prices Index, so figures from different years can be compared in
+// real terms.
+package inflation
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// embeddedCPI is the ONS CPI index (series D7BT, 2015=100), averaged over
+// each calendar year. It covers every tax year with local rates; the latest
+// year is a forecast until the year's figures are published
+//
+//go:embed cpi.csv
+var embeddedCPI string
+
+// Index maps a year to its CPI value. A tax year uses the value for the
+// calendar year it starts in.
+type Index map[int]float64
+
+// Embedded returns the CPI index built into the CLI
+func Embedded() Index {
+	index, err := ReadCSV(strings.NewReader(embeddedCPI))
+	if err != nil {
+		panic(fmt.Sprintf("embedded CPI index is invalid: %v", err))
+	}
+	return index
+}
+
+// ReadCSV reads a CPI index from CSV with a header row and year and cpi columns
+func ReadCSV(r io.Reader) (Index, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header row: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"year", "cpi"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+
+	index := Index{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		year, err := strconv.Atoi(strings.TrimSpace(record[columns["year"]]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid year: %s", line, record[columns["year"]])
+		}
+		cpi, err := strconv.ParseFloat(strings.TrimSpace(record[columns["cpi"]]), 64)
+		if err != nil || cpi <= 0 {
+			return nil, fmt.Errorf("line %d: invalid cpi: %s", line, record[columns["cpi"]])
+		}
+		index[year] = cpi
+	}
+
+	if len(index) == 0 {
+		return nil, fmt.Errorf("no CPI values found")
+	}
+	return index, nil
+}
+
+// Factor returns what to multiply an amount in one year's prices by to
+// express it in the base year's prices. A year after the index ends uses the
+// latest value, and the note says so
+func (i Index) Factor(year, baseYear int) (float64, string, error) {
+	cpi, note, err := i.value(year)
+	if err != nil {
+		return 0, "", err
+	}
+	baseCPI, baseNote, err := i.value(baseYear)
+	if err != nil {
+		return 0, "", err
+	}
+	if note == "" || baseNote == note {
+		note = baseNote
+	} else if baseNote != "" {
+		note += "; " + baseNote
+	}
+	return baseCPI / cpi, note, nil
+}
+
+// value returns a year's CPI value, or the latest value for a year after the
+// index ends with a note saying so
+func (i Index) value(year int) (float64, string, error) {
+	if cpi, ok := i[year]; ok {
+		return cpi, "", nil
+	}
+	latest := i.latest()
+	if year < latest {
+		return 0, "", fmt.Errorf("no CPI value for %d (the index covers %s)", year, i.coverage())
+	}
+	return i[latest], fmt.Sprintf("no CPI value for %d, so %d's is used", year, latest), nil
+}
+
+// latest returns the latest year in the index
+func (i Index) latest() int {
+	latest := 0
+	for year := range i {
+		latest = max(latest, year)
+	}
+	return latest
+}
+
+// coverage describes the years the index covers (e.g. 2010-2024)
+func (i Index) coverage() string {
+	years := make([]int, 0, len(i))
+	for year := range i {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	if len(years) == 1 {
+		return strconv.Itoa(years[0])
+	}
+	return fmt.Sprintf("%d-%d", years[0], years[len(years)-1])
+}
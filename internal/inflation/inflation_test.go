@@ -0,0 +1,83 @@
+package inflation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedded(t *testing.T) {
+	t.Parallel()
+
+	index := Embedded()
+	assert.InDelta(t, 100.0, index[2015], 0.001)
+	assert.InDelta(t, 133.9, index[2024], 0.001)
+	assert.Equal(t, "2010-2026", index.coverage())
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Parallel()
+
+	index, err := ReadCSV(strings.NewReader("CPI, Year\n120.5, 2025\n125.0, 2026\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Index{2025: 120.5, 2026: 125.0}, index)
+}
+
+func TestReadCSV_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		csv  string
+		want string
+	}{
+		{"empty", "", "missing header row"},
+		{"no year column", "date,cpi\n2024,133.9\n", "missing year column"},
+		{"no cpi column", "year,rpi\n2024,133.9\n", "missing cpi column"},
+		{"invalid year", "year,cpi\n24/25,133.9\n", "line 2: invalid year: 24/25"},
+		{"invalid cpi", "year,cpi\n2024,high\n", "line 2: invalid cpi: high"},
+		{"zero cpi", "year,cpi\n2023,130.5\n2024,0\n", "line 3: invalid cpi: 0"},
+		{"no rows", "year,cpi\n", "no CPI values found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ReadCSV(strings.NewReader(tt.csv))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestFactor(t *testing.T) {
+	t.Parallel()
+
+	index := Index{2023: 130.5, 2024: 133.9}
+
+	factor, note, err := index.Factor(2023, 2024)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.02605, factor, 0.00001)
+	assert.Empty(t, note)
+
+	factor, _, err = index.Factor(2024, 2024)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, factor, 0.00001)
+
+	// A year after the index ends uses the latest value
+	factor, note, err = index.Factor(2023, 2025)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.02605, factor, 0.00001)
+	assert.Equal(t, "no CPI value for 2025, so 2024's is used", note)
+
+	_, note, err = index.Factor(2025, 2026)
+	require.NoError(t, err)
+	assert.Equal(t, "no CPI value for 2025, so 2024's is used; no CPI value for 2026, so 2024's is used", note)
+
+	// A year before it starts cannot be adjusted
+	_, _, err = index.Factor(2022, 2024)
+	assert.EqualError(t, err, "no CPI value for 2022 (the index covers 2023-2024)")
+}
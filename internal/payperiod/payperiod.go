@@ -0,0 +1,227 @@
+// Package payperiod converts annual amounts into display periods.
+package payperiod
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Display periods
+const (
+	Yearly      = "yearly"
+	Quarterly   = "quarterly"
+	Monthly     = "monthly"
+	FourWeekly  = "four-weekly"
+	Fortnightly = "fortnightly"
+	Weekly      = "weekly"
+	Daily       = "daily"
+	Hourly      = "hourly"
+)
+
+// Defaults used when the working pattern is not set
+const (
+	DefaultHoursPerWeek = 40.0
+	DefaultDaysPerWeek  = 5.0
+	weeksPerYear        = 52.0
+	daysPerYear         = 365.0
+)
+
+// Units for an hourly, daily, weekly or monthly rate of pay
+const (
+	PerHour  = "hour"
+	PerDay   = "day"
+	PerWeek  = "week"
+	PerMonth = "month"
+)
+
+// RateUnits lists the valid units for a rate of pay
+var RateUnits = []string{PerHour, PerDay, PerWeek, PerMonth}
+
+// Names lists the valid display periods
+var Names = []string{Yearly, Quarterly, Monthly, FourWeekly, Fortnightly, Weekly, Daily, Hourly}
+
+// Validate checks that a display period is supported
+func Validate(period string) error {
+	for _, name := range Names {
+		if period == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid period: %s (must be one of: %s)", period, strings.Join(Names, ", "))
+}
+
+// Divisor returns the number of periods in a year. Weekly, fortnightly and
+// four-weekly figures use the pattern's pay days when set (e.g. 53 in a
+// week-53 year), and daily and hourly figures use the working pattern. Invalid
+// periods are treated as yearly.
+func Divisor(period string, pattern types.WorkPattern) float64 {
+	if payDays := PayDaysFor(period, pattern); payDays > 0 {
+		return float64(payDays)
+	}
+
+	switch period {
+	case Quarterly:
+		return 4.0
+	case Monthly:
+		return 12.0
+	case FourWeekly:
+		return weeksPerYear / 4
+	case Fortnightly:
+		return weeksPerYear / 2
+	case Weekly:
+		return weeksPerYear
+	case Daily:
+		return WorkingDays(pattern)
+	case Hourly:
+		return WorkingHours(pattern)
+	default:
+		return 1.0
+	}
+}
+
+// Label returns a human-readable label for a display period
+func Label(period string) string {
+	switch period {
+	case Quarterly:
+		return "Quarterly"
+	case Monthly:
+		return "Monthly"
+	case FourWeekly:
+		return "Four-Weekly"
+	case Fortnightly:
+		return "Fortnightly"
+	case Weekly:
+		return "Weekly"
+	case Daily:
+		return "Daily"
+	case Hourly:
+		return "Hourly"
+	default:
+		return "Yearly"
+	}
+}
+
+// PayDayInterval returns the days between pay days for weekly, fortnightly
+// and four-weekly periods, or 0 for other periods
+func PayDayInterval(period string) int {
+	switch period {
+	case Weekly:
+		return 7
+	case Fortnightly:
+		return 14
+	case FourWeekly:
+		return 28
+	default:
+		return 0
+	}
+}
+
+// PayDaysFor returns the pattern's pay days if they apply to the period, or 0
+func PayDaysFor(period string, pattern types.WorkPattern) int {
+	if PayDayInterval(period) == 0 {
+		return 0
+	}
+	return pattern.PayDays
+}
+
+// PayDays returns the number of pay days in the tax year starting 6 April of
+// taxYear, for pay every interval days on the same cycle as payday. A tax year
+// is 365 or 366 days, so it has 53 weekly, 27 fortnightly or 14 four-weekly
+// pay days when the first falls early enough in April.
+func PayDays(taxYear, interval int, payday time.Time) int {
+	start := time.Date(taxYear, time.April, 6, 0, 0, 0, 0, time.UTC)
+	end := time.Date(taxYear+1, time.April, 5, 0, 0, 0, 0, time.UTC)
+	payday = time.Date(payday.Year(), payday.Month(), payday.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Move to the first pay day on or after the start of the tax year
+	offset := daysBetween(start, payday) % interval
+	if offset < 0 {
+		offset += interval
+	}
+	first := start.AddDate(0, 0, offset)
+
+	return daysBetween(first, end)/interval + 1
+}
+
+// daysBetween returns the whole days from one date to another
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// UnitsPerYear returns the hours, days, weeks or months worked in a year, for
+// converting a rate of pay into an annual salary. Days and weeks are always
+// working days and weeks from the working pattern, less holiday.
+func UnitsPerYear(per string, pattern types.WorkPattern) (float64, error) {
+	switch per {
+	case PerHour:
+		return WorkingHours(pattern), nil
+	case PerDay:
+		return workingDays(pattern), nil
+	case PerWeek:
+		return weeksPerYear - pattern.HolidayDays/daysPerWeek(pattern), nil
+	case PerMonth:
+		return 12.0, nil
+	default:
+		return 0, fmt.Errorf("invalid rate unit: %s (must be one of: %s)", per, strings.Join(RateUnits, ", "))
+	}
+}
+
+// WorkingDays returns the days worked in a year: days per week for 52 weeks,
+// less holiday. Without a working pattern every calendar day is counted.
+func WorkingDays(pattern types.WorkPattern) float64 {
+	if pattern.DaysPerWeek == 0 && pattern.HolidayDays == 0 {
+		return daysPerYear
+	}
+	return workingDays(pattern)
+}
+
+// workingDays returns days per week for 52 weeks, less holiday
+func workingDays(pattern types.WorkPattern) float64 {
+	return daysPerWeek(pattern)*weeksPerYear - pattern.HolidayDays
+}
+
+// CountWorkingDays returns the pattern with daily figures counting working
+// days rather than every calendar day, so a rate of pay converted with
+// UnitsPerYear divides back into the same daily rate
+func CountWorkingDays(pattern types.WorkPattern) types.WorkPattern {
+	if pattern.DaysPerWeek == 0 {
+		pattern.DaysPerWeek = DefaultDaysPerWeek
+	}
+	return pattern
+}
+
+// WorkingHours returns the hours worked in a year: hours per week for 52
+// weeks, less the weeks taken as holiday
+func WorkingHours(pattern types.WorkPattern) float64 {
+	hours := pattern.HoursPerWeek
+	if hours == 0 {
+		hours = DefaultHoursPerWeek
+	}
+	return hours * (weeksPerYear - pattern.HolidayDays/daysPerWeek(pattern))
+}
+
+// ProRata returns the working pattern for a fraction of the full-time
+// pattern: fewer hours and, when working days are counted, fewer days and
+// holiday, so part-time daily and hourly figures match full-time ones
+func ProRata(pattern types.WorkPattern, fte float64) types.WorkPattern {
+	if pattern.HoursPerWeek == 0 {
+		pattern.HoursPerWeek = DefaultHoursPerWeek
+	}
+	pattern.HoursPerWeek *= fte
+	if pattern.DaysPerWeek != 0 || pattern.HolidayDays != 0 {
+		pattern.DaysPerWeek = daysPerWeek(pattern) * fte
+		pattern.HolidayDays *= fte
+	}
+	return pattern
+}
+
+// daysPerWeek returns the days worked each week, or the default five
+func daysPerWeek(pattern types.WorkPattern) float64 {
+	if pattern.DaysPerWeek == 0 {
+		return DefaultDaysPerWeek
+	}
+	return pattern.DaysPerWeek
+}
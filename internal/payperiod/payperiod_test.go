@@ -0,0 +1,244 @@
+package payperiod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestDivisor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		period string
+		want   float64
+	}{
+		{"yearly", "yearly", 1.0},
+		{"quarterly", "quarterly", 4.0},
+		{"monthly", "monthly", 12.0},
+		{"four-weekly", "four-weekly", 13.0},
+		{"fortnightly", "fortnightly", 26.0},
+		{"weekly", "weekly", 52.0},
+		{"daily", "daily", 365.0},
+		{"hourly", "hourly", 2080.0},
+		{"invalid defaults to yearly", "invalid", 1.0},
+		{"empty defaults to yearly", "", 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Divisor(tt.period, types.WorkPattern{})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDivisor_WorkPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pattern types.WorkPattern
+		days    float64
+		hours   float64
+	}{
+		{"full time", types.WorkPattern{HoursPerWeek: 37.5, DaysPerWeek: 5}, 260.0, 1950.0},
+		{"four day week", types.WorkPattern{HoursPerWeek: 32, DaysPerWeek: 4}, 208.0, 1664.0},
+		{"compressed hours", types.WorkPattern{HoursPerWeek: 40, DaysPerWeek: 4}, 208.0, 2080.0},
+		{"holiday only", types.WorkPattern{HolidayDays: 33}, 227.0, 1816.0},
+		{"four days with holiday", types.WorkPattern{HoursPerWeek: 30, DaysPerWeek: 4, HolidayDays: 24}, 184.0, 1380.0},
+		{"hours only", types.WorkPattern{HoursPerWeek: 20}, 365.0, 1040.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.days, Divisor(Daily, tt.pattern), 0.001)
+			assert.InDelta(t, tt.hours, Divisor(Hourly, tt.pattern), 0.001)
+
+			// Other periods do not depend on the working pattern
+			assert.Equal(t, 12.0, Divisor(Monthly, tt.pattern))
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		period string
+		want   string
+	}{
+		{"yearly", "yearly", "Yearly"},
+		{"quarterly", "quarterly", "Quarterly"},
+		{"monthly", "monthly", "Monthly"},
+		{"four-weekly", "four-weekly", "Four-Weekly"},
+		{"fortnightly", "fortnightly", "Fortnightly"},
+		{"weekly", "weekly", "Weekly"},
+		{"daily", "daily", "Daily"},
+		{"hourly", "hourly", "Hourly"},
+		{"invalid", "invalid", "Yearly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Label(tt.period)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, period := range Names {
+		assert.NoError(t, Validate(period), period)
+	}
+
+	err := Validate("biweekly")
+	assert.EqualError(t, err, "invalid period: biweekly (must be one of: yearly, quarterly, monthly, four-weekly, fortnightly, weekly, daily, hourly)")
+}
+
+func TestPayDays(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		taxYear  int
+		interval int
+		payday   string
+		want     int
+	}{
+		{"weekly on 6 April", 2025, 7, "2025-04-06", 53},
+		{"weekly on 5 April next year", 2025, 7, "2026-04-05", 53},
+		{"weekly on a Friday", 2025, 7, "2025-04-11", 52},
+		{"weekly from an earlier year", 2025, 7, "2024-04-12", 52},
+		{"weekly on 7 April in a leap year", 2027, 7, "2027-04-07", 53},
+		{"weekly on 8 April in a leap year", 2027, 7, "2027-04-08", 52},
+		{"fortnightly on 6 April", 2025, 14, "2025-04-06", 27},
+		{"fortnightly a week later", 2025, 14, "2025-04-13", 26},
+		{"four-weekly on 6 April", 2025, 28, "2025-04-06", 14},
+		{"four-weekly later in April", 2025, 28, "2025-04-25", 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			payday, err := time.Parse("2006-01-02", tt.payday)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, PayDays(tt.taxYear, tt.interval, payday))
+		})
+	}
+}
+
+func TestDivisor_PayDays(t *testing.T) {
+	t.Parallel()
+
+	pattern := types.WorkPattern{PayDays: 53}
+	assert.Equal(t, 53.0, Divisor(Weekly, pattern))
+	assert.Equal(t, 53, PayDaysFor(Weekly, pattern))
+
+	// Pay days only apply to weekly, fortnightly and four-weekly periods
+	assert.Equal(t, 12.0, Divisor(Monthly, pattern))
+	assert.Zero(t, PayDaysFor(Monthly, pattern))
+	assert.Zero(t, PayDayInterval(Quarterly))
+	assert.Equal(t, 14, PayDayInterval(Fortnightly))
+}
+
+func TestUnitsPerYear(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		per      string
+		pattern  types.WorkPattern
+		want     float64
+		errorMsg string
+	}{
+		{"hours", PerHour, types.WorkPattern{}, 2080.0, ""},
+		{"hours with holiday", PerHour, types.WorkPattern{HoursPerWeek: 37.5, HolidayDays: 25}, 1762.5, ""},
+		{"days", PerDay, types.WorkPattern{}, 260.0, ""},
+		{"days with holiday", PerDay, types.WorkPattern{DaysPerWeek: 4, HolidayDays: 20}, 188.0, ""},
+		{"weeks", PerWeek, types.WorkPattern{}, 52.0, ""},
+		{"weeks with holiday", PerWeek, types.WorkPattern{HolidayDays: 25}, 47.0, ""},
+		{"months", PerMonth, types.WorkPattern{HolidayDays: 25}, 12.0, ""},
+		{"invalid", "fortnight", types.WorkPattern{}, 0, "invalid rate unit: fortnight (must be one of: hour, day, week, month)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := UnitsPerYear(tt.per, tt.pattern)
+			if tt.errorMsg != "" {
+				assert.EqualError(t, err, tt.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 0.001)
+		})
+	}
+}
+
+func TestCountWorkingDays_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// A day rate converted to a salary divides back into the same day rate
+	for _, pattern := range []types.WorkPattern{
+		{},
+		{HolidayDays: 25},
+		{HoursPerWeek: 30, DaysPerWeek: 4, HolidayDays: 20},
+	} {
+		pattern = CountWorkingDays(pattern)
+		units, err := UnitsPerYear(PerDay, pattern)
+		require.NoError(t, err)
+		assert.InDelta(t, 200.0, 200*units/Divisor(Daily, pattern), 0.001)
+
+		units, err = UnitsPerYear(PerHour, pattern)
+		require.NoError(t, err)
+		assert.InDelta(t, 25.0, 25*units/Divisor(Hourly, pattern), 0.001)
+	}
+
+	// A set number of days per week is kept
+	assert.Equal(t, 3.0, CountWorkingDays(types.WorkPattern{DaysPerWeek: 3}).DaysPerWeek)
+}
+
+func TestProRata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pattern types.WorkPattern
+		fte     float64
+		want    types.WorkPattern
+	}{
+		{"default hours", types.WorkPattern{}, 0.5, types.WorkPattern{HoursPerWeek: 20}},
+		{"four day week", types.WorkPattern{HoursPerWeek: 37.5, DaysPerWeek: 5, HolidayDays: 25}, 0.8, types.WorkPattern{HoursPerWeek: 30, DaysPerWeek: 4, HolidayDays: 20}},
+		{"holiday only", types.WorkPattern{HolidayDays: 30}, 0.6, types.WorkPattern{HoursPerWeek: 24, DaysPerWeek: 3, HolidayDays: 18}},
+		{"keeps pay days", types.WorkPattern{PayDays: 53}, 1, types.WorkPattern{HoursPerWeek: 40, PayDays: 53}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ProRata(tt.pattern, tt.fte)
+			assert.InDelta(t, tt.want.HoursPerWeek, got.HoursPerWeek, 0.001)
+			assert.InDelta(t, tt.want.DaysPerWeek, got.DaysPerWeek, 0.001)
+			assert.InDelta(t, tt.want.HolidayDays, got.HolidayDays, 0.001)
+			assert.Equal(t, tt.want.PayDays, got.PayDays)
+
+			// Part-time hourly and daily figures match full-time ones
+			assert.InDelta(t, Divisor(Hourly, tt.pattern)*tt.fte, Divisor(Hourly, got), 0.001)
+		})
+	}
+}
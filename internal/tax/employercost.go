@@ -0,0 +1,116 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Apprenticeship levy: 0.5% of the annual pay bill, less an allowance of
+// £15,000, so only employers with a pay bill over £3 million pay it
+const (
+	apprenticeshipLevyRate      = 0.005
+	apprenticeshipLevyAllowance = 15000
+)
+
+// EmployerCost calculates the cost to the business of one or more hires on the
+// same salary: employer's NI less any Employment Allowance, the apprenticeship
+// levy on top of the existing pay bill, and the employer pension contribution.
+// With a budget instead of a salary, it finds the highest whole-pound salary
+// whose total cost fits the budget.
+func EmployerCost(req *types.EmployerCostRequest) (*types.EmployerCost, error) {
+	rates, err := RatesFor(req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Hires < 1 {
+		return nil, fmt.Errorf("hires must be at least 1, got: %d", req.Hires)
+	}
+	if req.PayBill < 0 {
+		return nil, fmt.Errorf("pay bill cannot be negative")
+	}
+	if _, err := ParsePension(req.EmployerPension, 0); err != nil {
+		return nil, fmt.Errorf("employer pension: %w", err)
+	}
+
+	if req.Budget == 0 {
+		if req.Salary <= 0 {
+			return nil, fmt.Errorf("salary must be greater than 0")
+		}
+		return rates.employerCost(req, float64(req.Salary))
+	}
+
+	// Total cost rises with salary, so search for the highest salary within budget
+	budget := float64(req.Budget)
+	low, high := 0, req.Budget/req.Hires
+	for low < high {
+		mid := (low + high + 1) / 2
+		cost, err := rates.employerCost(req, float64(mid))
+		if err != nil {
+			return nil, err
+		}
+		if cost.TotalCost <= budget {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	if low == 0 {
+		return nil, fmt.Errorf("budget of %d does not cover any salary for %d hires", req.Budget, req.Hires)
+	}
+
+	cost, err := rates.employerCost(req, float64(low))
+	if err != nil {
+		return nil, err
+	}
+	cost.Budget = budget
+	return cost, nil
+}
+
+// employerCost works out the employer's costs for the hires on a salary
+func (r *Rates) employerCost(req *types.EmployerCostRequest, salary float64) (*types.EmployerCost, error) {
+	hires := float64(req.Hires)
+	pension, err := ParsePension(req.EmployerPension, salary)
+	if err != nil {
+		return nil, fmt.Errorf("employer pension: %w", err)
+	}
+
+	grossPay := salary * hires
+	employersNI := r.EmployerNI(salary) * hires
+
+	allowance := 0.0
+	if req.EmploymentAllowance {
+		allowance = math.Min(r.EmploymentAllowance, employersNI)
+	}
+
+	// The levy on these hires is the extra levy on the pay bill they add to
+	payBill := float64(req.PayBill)
+	levy := apprenticeshipLevy(payBill+grossPay) - apprenticeshipLevy(payBill)
+
+	cost := &types.EmployerCost{
+		TaxYear:             r.Year,
+		Hires:               req.Hires,
+		Salary:              salary,
+		GrossPay:            grossPay,
+		EmployersNI:         employersNI,
+		EmploymentAllowance: allowance,
+		ApprenticeshipLevy:  levy,
+		EmployerPension:     pension * hires,
+	}
+	cost.TotalCost = TotalCost(cost)
+	return cost, nil
+}
+
+// TotalCost returns the cost of employment: gross pay, employer's NI less any
+// Employment Allowance, the apprenticeship levy and pension contributions.
+// Both check's total cost and employer-cost are worked out with it.
+func TotalCost(cost *types.EmployerCost) float64 {
+	return cost.GrossPay + cost.EmployersNI - cost.EmploymentAllowance + cost.ApprenticeshipLevy + cost.EmployerPension
+}
+
+// apprenticeshipLevy returns the levy due on an annual pay bill
+func apprenticeshipLevy(payBill float64) float64 {
+	return math.Max(0, payBill*apprenticeshipLevyRate-apprenticeshipLevyAllowance)
+}
@@ -0,0 +1,89 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestEmployerCost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		req       types.EmployerCostRequest
+		ni        float64
+		allowance float64
+		levy      float64
+		pension   float64
+		total     float64
+	}{
+		{"one hire", types.EmployerCostRequest{Year: "2025", Salary: 45000, Hires: 1}, 6000, 0, 0, 0, 51000},
+		{"employer pension", types.EmployerCostRequest{Year: "2025", Salary: 45000, Hires: 1, EmployerPension: "5%"}, 6000, 0, 0, 2250, 53250},
+		{"employment allowance", types.EmployerCostRequest{Year: "2025", Salary: 30000, Hires: 3, EmploymentAllowance: true}, 11250, 10500, 0, 0, 90750},
+		{"allowance capped at NI", types.EmployerCostRequest{Year: "2024", Salary: 20000, Hires: 1, EmploymentAllowance: true}, 1504.2, 1504.2, 0, 0, 20000},
+		{"levy over pay bill", types.EmployerCostRequest{Year: "2025", Salary: 60000, Hires: 10, PayBill: 3000000}, 82500, 0, 3000, 0, 685500},
+		{"levy crossing threshold", types.EmployerCostRequest{Year: "2025", Salary: 60000, Hires: 10, PayBill: 2500000}, 82500, 0, 500, 0, 683000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cost, err := EmployerCost(&tt.req)
+			require.NoError(t, err)
+			assert.InDelta(t, float64(tt.req.Salary*tt.req.Hires), cost.GrossPay, 0.01)
+			assert.InDelta(t, tt.ni, cost.EmployersNI, 0.01)
+			assert.InDelta(t, tt.allowance, cost.EmploymentAllowance, 0.01)
+			assert.InDelta(t, tt.levy, cost.ApprenticeshipLevy, 0.01)
+			assert.InDelta(t, tt.pension, cost.EmployerPension, 0.01)
+			assert.InDelta(t, tt.total, cost.TotalCost, 0.01)
+		})
+	}
+}
+
+func TestEmployerCost_Budget(t *testing.T) {
+	t.Parallel()
+
+	req := &types.EmployerCostRequest{Year: "2025", Budget: 150000, Hires: 2, EmployerPension: "3%"}
+	cost, err := EmployerCost(req)
+	require.NoError(t, err)
+
+	// The highest whole-pound salary that fits the budget
+	assert.InDelta(t, 64194, cost.Salary, 0.01)
+	assert.InDelta(t, 149997.84, cost.TotalCost, 0.01)
+	assert.InDelta(t, 150000, cost.Budget, 0.01)
+
+	req.Budget = 3000
+	req.EmployerPension = "2000"
+	_, err = EmployerCost(req)
+	assert.EqualError(t, err, "budget of 3000 does not cover any salary for 2 hires")
+}
+
+func TestEmployerCost_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		req      types.EmployerCostRequest
+		errorMsg string
+	}{
+		{"unsupported year", types.EmployerCostRequest{Year: "2010", Salary: 30000, Hires: 1}, "no local tax rates for 2010"},
+		{"no hires", types.EmployerCostRequest{Year: "2025", Salary: 30000}, "hires must be at least 1, got: 0"},
+		{"no salary", types.EmployerCostRequest{Year: "2025", Hires: 1}, "salary must be greater than 0"},
+		{"negative pay bill", types.EmployerCostRequest{Year: "2025", Salary: 30000, Hires: 1, PayBill: -1}, "pay bill cannot be negative"},
+		{"invalid pension", types.EmployerCostRequest{Year: "2025", Salary: 30000, Hires: 1, EmployerPension: "lots"}, "employer pension: invalid pension amount: lots"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := EmployerCost(&tt.req)
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+}
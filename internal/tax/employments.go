@@ -0,0 +1,145 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// MultipleEmployments calculates the PAYE deducted by each employer under its
+// own tax code, then reconciles the total against the year-end liability on
+// combined income. Each employer runs National Insurance and student loan
+// deductions separately, and pension contributions are taken before tax.
+// Extra income, or deductions when negative, is taxed in the year-end
+// liability rather than by any employer, and employees at or over State
+// Pension age pay no National Insurance.
+func MultipleEmployments(req *types.TaxRequest) (*types.TaxResponse, error) {
+	rates, err := RatesFor(req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Jobs) == 0 {
+		return nil, fmt.Errorf("at least one job is required")
+	}
+
+	jobs := make([]types.EmploymentResult, 0, len(req.Jobs))
+	codes := make([]string, 0, len(req.Jobs))
+	totals := types.EmploymentResult{}
+
+	for _, job := range req.Jobs {
+		result, err := rates.employment(req, job)
+		if err != nil {
+			return nil, fmt.Errorf("job '%s': %w", job.Label, err)
+		}
+
+		jobs = append(jobs, *result)
+		codes = append(codes, result.TaxCode)
+		totals.GrossPay += result.GrossPay
+		totals.Pension += result.Pension
+		totals.TaxPaid += result.TaxPaid
+		totals.NationalInsurance += result.NationalInsurance
+		totals.StudentLoanRepayment += result.StudentLoanRepayment
+		totals.EmployersNI += result.EmployersNI
+	}
+
+	// Year-end liability on combined income after pension contributions
+	income := totals.GrossPay - totals.Pension
+	allowance := rates.AllowanceFor(income + float64(req.Extra))
+	if req.Blind == "y" {
+		allowance += rates.BlindAllowance
+	}
+	taxable := math.Max(0, income+float64(req.Extra)-allowance)
+
+	liability, taxDue := IncomeTax(taxable, rates.BandsFor(req.TaxRegion), 0)
+	marriageAllowance := rates.marriageAllowanceFor(req, taxDue)
+	liability = math.Max(0, liability-marriageAllowance*basicRateRelief)
+
+	return &types.TaxResponse{
+		TaxYear:                  rates.Year,
+		TaxablePay:               taxable,
+		GrossPay:                 totals.GrossPay,
+		TaxFreeAllowance:         allowance,
+		TaxPaid:                  liability,
+		TaxDue:                   taxDue,
+		NationalInsurance:        totals.NationalInsurance,
+		NetPay:                   income - liability - totals.NationalInsurance - totals.StudentLoanRepayment,
+		StudentLoanRepayment:     totals.StudentLoanRepayment,
+		PensionYou:               totals.Pension,
+		EmployersNI:              totals.EmployersNI,
+		TaxRegion:                req.TaxRegion,
+		TaxCode:                  strings.Join(codes, "/"),
+		TaxFreeMarriageAllowance: marriageAllowance,
+		Employments: &types.Employments{
+			Jobs:         jobs,
+			PAYETax:      totals.TaxPaid,
+			Liability:    liability,
+			Underpayment: liability - totals.TaxPaid,
+		},
+	}, nil
+}
+
+// employment calculates the deductions a single employer makes from pay
+func (r *Rates) employment(req *types.TaxRequest, job types.Employment) (*types.EmploymentResult, error) {
+	gross := float64(job.Income)
+	if gross <= 0 {
+		return nil, fmt.Errorf("income must be greater than 0")
+	}
+
+	code, err := ParseTaxCode(job.TaxCode)
+	if err != nil {
+		return nil, err
+	}
+
+	pension, err := ParsePension(job.Pension, gross)
+	if err != nil {
+		return nil, err
+	}
+
+	// An S or C prefix sets the bands the employer uses
+	region := code.Region
+	if region == "" {
+		region = req.TaxRegion
+	}
+	tax, err := r.PAYETax(gross-pension, code, r.BandsFor(region))
+	if err != nil {
+		return nil, err
+	}
+
+	ni := 0.0
+	if req.ExNI != "y" && !overStatePensionAge(req.Age) {
+		ni = r.EmployeeNI(gross)
+	}
+	studentLoan := r.StudentLoanRepayment(req.Plan, gross)
+
+	return &types.EmploymentResult{
+		Label:                job.Label,
+		TaxCode:              code.Code,
+		GrossPay:             gross,
+		Pension:              pension,
+		TaxPaid:              tax,
+		NationalInsurance:    ni,
+		StudentLoanRepayment: studentLoan,
+		EmployersNI:          r.EmployerNI(gross),
+		NetPay:               gross - pension - tax - ni - studentLoan,
+	}, nil
+}
+
+// EmployeeNI returns the Class 1 National Insurance an employee pays on earnings
+func (r *Rates) EmployeeNI(earnings float64) float64 {
+	ni := 0.0
+	if earnings > r.PrimaryThreshold {
+		ni = (math.Min(earnings, r.UpperEarningsLimit) - r.PrimaryThreshold) * r.EmployeeMainRate
+	}
+	if earnings > r.UpperEarningsLimit {
+		ni += (earnings - r.UpperEarningsLimit) * r.EmployeeUpperRate
+	}
+	return ni
+}
+
+// EmployerNI returns the secondary Class 1 National Insurance an employer pays on earnings
+func (r *Rates) EmployerNI(earnings float64) float64 {
+	return math.Max(0, earnings-r.SecondaryThreshold) * r.EmployerRate
+}
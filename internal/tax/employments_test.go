@@ -0,0 +1,202 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func jobsRequest(jobs ...types.Employment) *types.TaxRequest {
+	return testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Year = "2025"
+		r.GrossWage = 0
+		r.Jobs = jobs
+	})
+}
+
+func TestMultipleEmployments_SecondJobOnBR(t *testing.T) {
+	t.Parallel()
+
+	resp, err := MultipleEmployments(jobsRequest(
+		types.Employment{Label: "Main", Income: 60000, TaxCode: "1257L"},
+		types.Employment{Label: "Side", Income: 8000, TaxCode: "BR"},
+	))
+	require.NoError(t, err)
+
+	require.NotNil(t, resp.Employments)
+	require.Len(t, resp.Employments.Jobs, 2)
+
+	main := resp.Employments.Jobs[0]
+	assert.Equal(t, "Main", main.Label)
+	assert.InDelta(t, 11432, main.TaxPaid, 0.01)
+	assert.InDelta(t, 3210.60, main.NationalInsurance, 0.01)
+	assert.InDelta(t, 8250, main.EmployersNI, 0.01)
+
+	// NI is charged per employer, so the side job is below the threshold
+	side := resp.Employments.Jobs[1]
+	assert.InDelta(t, 1600, side.TaxPaid, 0.01)
+	assert.Zero(t, side.NationalInsurance)
+	assert.InDelta(t, 450, side.EmployersNI, 0.01)
+
+	// Combined income pushes the side job into the higher rate band
+	assert.InDelta(t, 68000, resp.GrossPay, 0.01)
+	assert.InDelta(t, 14632, resp.TaxPaid, 0.01)
+	assert.InDelta(t, 13032, resp.Employments.PAYETax, 0.01)
+	assert.InDelta(t, 1600, resp.Employments.Underpayment, 0.01)
+	assert.InDelta(t, 68000-14632-3210.60, resp.NetPay, 0.01)
+	assert.Equal(t, "1257L/BR", resp.TaxCode)
+}
+
+func TestMultipleEmployments_Overpayment(t *testing.T) {
+	t.Parallel()
+
+	// The first job doesn't use the whole allowance, but BR gives none to the second
+	resp, err := MultipleEmployments(jobsRequest(
+		types.Employment{Label: "A", Income: 10000, TaxCode: "1257L"},
+		types.Employment{Label: "B", Income: 10000, TaxCode: "BR"},
+	))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 2000, resp.Employments.PAYETax, 0.01)
+	assert.InDelta(t, (20000-12570)*0.20, resp.Employments.Liability, 0.01)
+	assert.InDelta(t, -514, resp.Employments.Underpayment, 0.01)
+}
+
+func TestMultipleEmployments_PensionPerJob(t *testing.T) {
+	t.Parallel()
+
+	resp, err := MultipleEmployments(jobsRequest(
+		types.Employment{Label: "Main", Income: 40000, TaxCode: "1257L", Pension: "5%"},
+		types.Employment{Label: "Side", Income: 5000, TaxCode: "BR", Pension: "500"},
+	))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 2000, resp.Employments.Jobs[0].Pension, 0.01)
+	assert.InDelta(t, 500, resp.Employments.Jobs[1].Pension, 0.01)
+	assert.InDelta(t, 2500, resp.PensionYou, 0.01)
+
+	// Pension is taken before tax but not before NI
+	assert.InDelta(t, (38000-12570)*0.20, resp.Employments.Jobs[0].TaxPaid, 0.01)
+	assert.InDelta(t, (40000-12570)*0.08, resp.Employments.Jobs[0].NationalInsurance, 0.01)
+	assert.InDelta(t, 42500-12570, resp.TaxablePay, 0.01)
+}
+
+func TestMultipleEmployments_NIExemptAndStudentLoan(t *testing.T) {
+	t.Parallel()
+
+	req := jobsRequest(
+		types.Employment{Label: "A", Income: 30000, TaxCode: "1257L"},
+		types.Employment{Label: "B", Income: 20000, TaxCode: "BR"},
+	)
+	req.ExNI = "y"
+	req.Plan = "plan2"
+
+	resp, err := MultipleEmployments(req)
+	require.NoError(t, err)
+
+	assert.Zero(t, resp.NationalInsurance)
+
+	// Each employer only deducts on pay above the threshold
+	assert.InDelta(t, (30000-28470)*0.09, resp.Employments.Jobs[0].StudentLoanRepayment, 0.01)
+	assert.Zero(t, resp.Employments.Jobs[1].StudentLoanRepayment)
+}
+
+func TestMultipleEmployments_ExtraIncome(t *testing.T) {
+	t.Parallel()
+
+	req := jobsRequest(
+		types.Employment{Label: "Main", Income: 40000, TaxCode: "1257L"},
+		types.Employment{Label: "Side", Income: 5000, TaxCode: "BR"},
+	)
+	req.Extra = 10000
+
+	resp, err := MultipleEmployments(req)
+	require.NoError(t, err)
+
+	// No employer deducts tax on the extra income, so it all adds to the underpayment
+	assert.InDelta(t, (40000-12570)*0.20+1000, resp.Employments.PAYETax, 0.01)
+	assert.InDelta(t, 55000-12570, resp.TaxablePay, 0.01)
+	assert.InDelta(t, (50270-12570)*0.20+(55000-50270)*0.40, resp.Employments.Liability, 0.01)
+	assert.InDelta(t, 45000, resp.GrossPay, 0.01)
+	assert.InDelta(t, 45000-resp.TaxPaid-resp.NationalInsurance, resp.NetPay, 0.01)
+}
+
+func TestMultipleEmployments_OverStatePensionAge(t *testing.T) {
+	t.Parallel()
+
+	req := jobsRequest(
+		types.Employment{Label: "A", Income: 30000, TaxCode: "1257L"},
+		types.Employment{Label: "B", Income: 20000, TaxCode: "BR"},
+	)
+	req.Age = "66"
+
+	resp, err := MultipleEmployments(req)
+	require.NoError(t, err)
+
+	assert.Zero(t, resp.NationalInsurance)
+	assert.Zero(t, resp.Employments.Jobs[0].NationalInsurance)
+	// Employers still pay NI
+	assert.Positive(t, resp.EmployersNI)
+}
+
+func TestMultipleEmployments_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     *types.TaxRequest
+		wantErr string
+	}{
+		{
+			name:    "no jobs",
+			req:     jobsRequest(),
+			wantErr: "at least one job is required",
+		},
+		{
+			name:    "unsupported year",
+			req:     testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) { r.Year = "2019" }),
+			wantErr: "no local tax rates for 2019",
+		},
+		{
+			name:    "invalid tax code",
+			req:     jobsRequest(types.Employment{Label: "Main", Income: 30000, TaxCode: "XYZ"}),
+			wantErr: "job 'Main': invalid tax code: XYZ",
+		},
+		{
+			name:    "zero income",
+			req:     jobsRequest(types.Employment{Label: "Main", Income: 0, TaxCode: "1257L"}),
+			wantErr: "job 'Main': income must be greater than 0",
+		},
+		{
+			name:    "invalid pension",
+			req:     jobsRequest(types.Employment{Label: "Main", Income: 30000, TaxCode: "1257L", Pension: "abc"}),
+			wantErr: "job 'Main': invalid pension amount: abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := MultipleEmployments(tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestEmployeeAndEmployerNI(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	assert.Zero(t, rates.EmployeeNI(12000))
+	assert.InDelta(t, (50270-12570)*0.08+(60000-50270)*0.02, rates.EmployeeNI(60000), 0.01)
+	assert.Zero(t, rates.EmployerNI(4000))
+	assert.InDelta(t, (30000-5000)*0.15, rates.EmployerNI(30000), 0.01)
+}
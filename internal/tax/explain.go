@@ -0,0 +1,275 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Sections of an explanation, in the order they are worked out
+const (
+	SectionPay         = "Pay"
+	SectionPension     = "Pension"
+	SectionAllowance   = "Tax-Free Allowance"
+	SectionIncomeTax   = "Income Tax"
+	SectionNI          = "National Insurance"
+	SectionStudentLoan = "Student Loan"
+	SectionNetPay      = "Net Pay"
+)
+
+// taperRate is the allowance lost for each pound of adjusted net income over
+// the taper threshold
+const taperRate = 0.5
+
+// Explain traces how a calculation reaches its figures: how pension relief is
+// given, the personal allowance and any taper, how taxable pay fills each band
+// in the tax due, the National Insurance thresholds, the student loan threshold
+// and the net pay left. The thresholds and rates come from the local rates for
+// the tax year; the amounts are the calculation's own.
+func Explain(req *types.TaxRequest, resp *types.TaxResponse) ([]types.ExplanationStep, error) {
+	if resp.Employments != nil {
+		return nil, fmt.Errorf("explain does not cover multiple employments")
+	}
+
+	rates, err := RatesFor(strconv.Itoa(resp.TaxYear))
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []types.ExplanationStep{{Section: SectionPay, Name: grossName(resp), Amount: resp.GrossPay}}
+	steps = append(steps, explainPension(resp)...)
+	steps = append(steps, rates.explainAllowance(req, resp)...)
+	steps = append(steps, rates.explainIncomeTax(req, resp)...)
+	steps = append(steps, rates.explainNI(req, resp)...)
+	steps = append(steps, rates.explainStudentLoan(req, resp)...)
+	steps = append(steps, types.ExplanationStep{
+		Section: SectionNetPay,
+		Name:    "Net pay",
+		Amount:  resp.NetPay,
+		Note:    "gross pay less income tax, National Insurance, student loan and your pension contribution",
+	})
+	return steps, nil
+}
+
+// grossName returns what the gross figure is called
+func grossName(resp *types.TaxResponse) string {
+	if resp.SelfEmployment != nil {
+		return "Trading profit"
+	}
+	return "Gross pay"
+}
+
+// grossPension returns the total paid into the pension, including tax relief
+func grossPension(resp *types.TaxResponse) float64 {
+	return resp.PensionYou + resp.PensionHMRC
+}
+
+// explainPension returns how the pension contribution is paid and relieved
+func explainPension(resp *types.TaxResponse) []types.ExplanationStep {
+	scheme := ""
+	if resp.PensionScheme != nil {
+		scheme = resp.PensionScheme.Scheme
+	}
+
+	switch {
+	case scheme == SchemeSacrifice:
+		return []types.ExplanationStep{
+			{Section: SectionPension, Name: "Salary sacrificed", Amount: resp.PensionYou,
+				Note: "taken before tax, National Insurance and student loan"},
+			{Section: SectionPension, Name: "NI saved by sacrifice", Amount: resp.PensionScheme.EmployeeNISaving},
+		}
+	case scheme == SchemeNetPay:
+		return []types.ExplanationStep{
+			{Section: SectionPension, Name: "Net pay contribution", Amount: resp.PensionYou,
+				Note: "taken before tax, so relief is given at your highest rate"},
+		}
+	case grossPension(resp) == 0:
+		return nil
+	}
+
+	// Relief at source, and contributions the calculation relieved itself
+	steps := []types.ExplanationStep{
+		{Section: SectionPension, Name: "Your contribution", Amount: resp.PensionYou, Note: "paid from net pay"},
+	}
+	if resp.PensionHMRC > 0 {
+		steps = append(steps, types.ExplanationStep{
+			Section: SectionPension, Name: "Basic rate top-up", Amount: resp.PensionHMRC,
+			Base: grossPension(resp), Rate: basicRateRelief, Note: "added to your pension by HMRC",
+		})
+	}
+	if resp.PensionClaimback > 0 {
+		steps = append(steps, types.ExplanationStep{
+			Section: SectionPension, Name: "Higher rate relief", Amount: resp.PensionClaimback,
+			Note: "claimed back through self assessment, as the bands are extended by the gross contribution",
+		})
+	}
+	return steps
+}
+
+// explainAllowance returns the personal allowance, any taper and blind
+// person's allowance, and the tax-free allowance they add up to. An allowance
+// from a tax code replaces them
+func (r *Rates) explainAllowance(req *types.TaxRequest, resp *types.TaxResponse) []types.ExplanationStep {
+	if req.TaxCode != "" {
+		return []types.ExplanationStep{{
+			Section: SectionAllowance, Name: "Tax-free allowance", Amount: resp.TaxFreeAllowance,
+			Note: "from tax code " + req.TaxCode,
+		}}
+	}
+
+	steps := []types.ExplanationStep{{Section: SectionAllowance, Name: "Personal allowance", Amount: r.PersonalAllowance}}
+
+	// Adjusted net income is pay less the gross pension contribution
+	adjustedNetIncome := resp.GrossPay - grossPension(resp)
+	if adjustedNetIncome > r.TaperThreshold {
+		over := adjustedNetIncome - r.TaperThreshold
+		steps = append(steps, types.ExplanationStep{
+			Section: SectionAllowance, Name: "Taper", Amount: -math.Min(r.PersonalAllowance, math.Floor(over*taperRate)),
+			Base: over, Rate: taperRate, Threshold: r.TaperThreshold,
+			Note: "£1 of allowance is lost for every £2 of adjusted net income over the threshold",
+		})
+	}
+	if req.Blind == "y" {
+		steps = append(steps, types.ExplanationStep{Section: SectionAllowance, Name: "Blind person's allowance", Amount: r.BlindAllowance})
+	}
+
+	return append(steps, types.ExplanationStep{Section: SectionAllowance, Name: "Tax-free allowance", Amount: resp.TaxFreeAllowance})
+}
+
+// explainIncomeTax returns taxable pay, the portion of it in each band of the
+// tax due with the taxable pay the band starts from, any marriage allowance
+// and tax on other income, and the total income tax
+func (r *Rates) explainIncomeTax(req *types.TaxRequest, resp *types.TaxResponse) []types.ExplanationStep {
+	steps := []types.ExplanationStep{{
+		Section: SectionIncomeTax, Name: "Taxable pay", Amount: resp.TaxablePay,
+		Note: "pay after any pension taken before tax, less the tax-free allowance",
+	}}
+
+	// Self-employed pension contributions extend the bands
+	bands := r.BandsFor(req.TaxRegion)
+	extension := 0.0
+	if resp.SelfEmployment != nil {
+		extension = grossPension(resp)
+	}
+	limits := bandLimits(bands, extension)
+
+	keys := make([]string, 0, len(resp.TaxDue))
+	for key := range resp.TaxDue {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, _ := strconv.Atoi(keys[i])
+		b, _ := strconv.Atoi(keys[j])
+		return a < b
+	})
+
+	lower := 0.0
+	for _, key := range keys {
+		bracket := resp.TaxDue[key]
+		index, _ := strconv.Atoi(key)
+		name := bracket.Name
+		if index < len(bands) {
+			if name == "" {
+				name = bands[index].Name
+			}
+			if index > 0 {
+				lower = limits[index-1]
+			}
+		}
+		if bracket.Amount == 0 || bracket.Rate == 0 {
+			continue
+		}
+		steps = append(steps, types.ExplanationStep{
+			Section: SectionIncomeTax, Name: name, Amount: bracket.Amount,
+			Base: bracket.Amount / bracket.Rate, Rate: bracket.Rate, Threshold: lower,
+		})
+	}
+
+	if resp.TaxFreeMarriageAllowance > 0 {
+		steps = append(steps, types.ExplanationStep{
+			Section: SectionIncomeTax, Name: "Marriage allowance", Amount: -resp.TaxFreeMarriageAllowance * basicRateRelief,
+			Base: resp.TaxFreeMarriageAllowance, Rate: basicRateRelief, Note: "transferred from your partner",
+		})
+	}
+	if resp.OtherIncome != nil && resp.OtherIncome.Tax > 0 {
+		steps = append(steps, types.ExplanationStep{
+			Section: SectionIncomeTax, Name: "Tax on other income", Amount: resp.OtherIncome.Tax,
+			Note: "rental profit, savings interest and dividends, on top of pay",
+		})
+	}
+
+	return append(steps, types.ExplanationStep{Section: SectionIncomeTax, Name: "Total income tax", Amount: resp.TaxPaid})
+}
+
+// explainNI returns the National Insurance charged between each threshold:
+// Class 1 for employees, on pay after any salary sacrifice, or Class 2 and
+// Class 4 for the self-employed. When the calculation's total differs from the
+// bands, such as an API figure worked out per pay period, a step shows the
+// difference so the steps still add up to the total.
+func (r *Rates) explainNI(req *types.TaxRequest, resp *types.TaxResponse) []types.ExplanationStep {
+	if req.ExNI == "y" {
+		return []types.ExplanationStep{{Section: SectionNI, Name: "Total National Insurance", Amount: 0, Note: "exempt"}}
+	}
+	if resp.SelfEmployment == nil && overStatePensionAge(req.Age) {
+		return []types.ExplanationStep{{Section: SectionNI, Name: "Total National Insurance", Amount: resp.NationalInsurance,
+			Note: "no employee National Insurance at or over State Pension age"}}
+	}
+
+	var steps []types.ExplanationStep
+	if se := resp.SelfEmployment; se != nil {
+		if se.Class2NI > 0 {
+			steps = append(steps, types.ExplanationStep{
+				Section: SectionNI, Name: "Class 2", Amount: se.Class2NI, Threshold: r.Class2Threshold,
+				Note: "a flat weekly rate for 52 weeks, once profit is over the threshold",
+			})
+		}
+		steps = append(steps, niBands(se.Profit, "Class 4 main rate", r.Class4Lower, r.Class4Upper, r.Class4MainRate,
+			"Class 4 upper rate", r.Class4UpperRate)...)
+	} else {
+		steps = niBands(resp.GrossPay-resp.GrossSacrifice, "Main rate", r.PrimaryThreshold, r.UpperEarningsLimit, r.EmployeeMainRate,
+			"Upper rate", r.EmployeeUpperRate)
+	}
+
+	banded := 0.0
+	for _, step := range steps {
+		banded += step.Amount
+	}
+	if difference := resp.NationalInsurance - banded; math.Round(difference*100) != 0 {
+		steps = append(steps, types.ExplanationStep{
+			Section: SectionNI, Name: "Difference", Amount: difference,
+			Note: "the total is the calculation's own and doesn't come from the bands above, e.g. when it is worked out per pay period",
+		})
+	}
+
+	return append(steps, types.ExplanationStep{Section: SectionNI, Name: "Total National Insurance", Amount: resp.NationalInsurance})
+}
+
+// niBands returns the National Insurance charged at the main rate between the
+// lower and upper thresholds, and at the upper rate above the upper threshold
+func niBands(pay float64, mainName string, lower, upper, mainRate float64, upperName string, upperRate float64) []types.ExplanationStep {
+	main := math.Max(0, math.Min(pay, upper)-lower)
+	steps := []types.ExplanationStep{{
+		Section: SectionNI, Name: mainName, Amount: main * mainRate, Base: main, Rate: mainRate, Threshold: lower,
+	}}
+	if above := pay - upper; above > 0 {
+		steps = append(steps, types.ExplanationStep{
+			Section: SectionNI, Name: upperName, Amount: above * upperRate, Base: above, Rate: upperRate, Threshold: upper,
+		})
+	}
+	return steps
+}
+
+// explainStudentLoan returns the repayment on pay over the plan's threshold
+func (r *Rates) explainStudentLoan(req *types.TaxRequest, resp *types.TaxResponse) []types.ExplanationStep {
+	plan, ok := r.StudentLoan(req.Plan)
+	if !ok {
+		return nil
+	}
+	return []types.ExplanationStep{{
+		Section: SectionStudentLoan, Name: "Repayment (" + req.Plan + ")", Amount: resp.StudentLoanRepayment,
+		Base: math.Max(0, resp.GrossPay-resp.GrossSacrifice-plan.Threshold), Rate: plan.Rate, Threshold: plan.Threshold,
+	}}
+}
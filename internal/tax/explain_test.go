@@ -0,0 +1,160 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// findStep returns the step with a name, failing the test if there is none
+func findStep(t *testing.T, steps []types.ExplanationStep, name string) types.ExplanationStep {
+	t.Helper()
+	for _, step := range steps {
+		if step.Name == name {
+			return step
+		}
+	}
+	require.Failf(t, "step not found", "no %q step in %v", name, steps)
+	return types.ExplanationStep{}
+}
+
+func TestExplain_SelfEmployedTaper(t *testing.T) {
+	req := &types.TaxRequest{Year: "2024", TaxRegion: "uk", SelfEmployed: true, Profit: 120000, Pension: "5000", Plan: "plan2"}
+	resp, err := SelfEmployed(req)
+	require.NoError(t, err)
+
+	steps, err := Explain(req, resp)
+	require.NoError(t, err)
+
+	// £115,000 of adjusted net income is £15,000 over the taper threshold
+	taper := findStep(t, steps, "Taper")
+	assert.Equal(t, types.ExplanationStep{
+		Section: SectionAllowance, Name: "Taper", Amount: -7500, Base: 15000, Rate: 0.5, Threshold: 100000,
+		Note: "£1 of allowance is lost for every £2 of adjusted net income over the threshold",
+	}, taper)
+	assert.Equal(t, 5070.0, findStep(t, steps, "Tax-free allowance").Amount)
+
+	// The pension contribution extends the basic rate band
+	higher := findStep(t, steps, "Higher Rate")
+	assert.Equal(t, 42700.0, higher.Threshold)
+	assert.InDelta(t, resp.TaxablePay-42700, higher.Base, 0.01)
+
+	assert.Equal(t, 12570.0, findStep(t, steps, "Class 4 main rate").Threshold)
+	assert.Equal(t, 50270.0, findStep(t, steps, "Class 4 upper rate").Threshold)
+
+	loan := findStep(t, steps, "Repayment (plan2)")
+	assert.Equal(t, 27295.0, loan.Threshold)
+	assert.InDelta(t, resp.StudentLoanRepayment, loan.Base*loan.Rate, 0.01)
+
+	assert.Equal(t, SectionPay, steps[0].Section)
+	assert.Equal(t, types.ExplanationStep{Section: SectionNetPay, Name: "Net pay", Amount: resp.NetPay,
+		Note: "gross pay less income tax, National Insurance, student loan and your pension contribution"}, steps[len(steps)-1])
+}
+
+func TestExplain_SalarySacrifice(t *testing.T) {
+	req := &types.TaxRequest{Year: "2024", TaxRegion: "uk", GrossWage: 60000, Pension: "10%", PensionScheme: SchemeSacrifice}
+	resp, err := PensionScheme(req)
+	require.NoError(t, err)
+
+	steps, err := Explain(req, resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6000.0, findStep(t, steps, "Salary sacrificed").Amount)
+	assert.Equal(t, resp.PensionScheme.EmployeeNISaving, findStep(t, steps, "NI saved by sacrifice").Amount)
+
+	// NI is charged on pay after the sacrifice
+	main := findStep(t, steps, "Main rate")
+	assert.Equal(t, 50270.0-12570.0, main.Base)
+	upper := findStep(t, steps, "Upper rate")
+	assert.Equal(t, 54000.0-50270.0, upper.Base)
+	assert.InDelta(t, resp.NationalInsurance, main.Amount+upper.Amount, 0.01)
+}
+
+func TestExplain_TaxCodeAllowance(t *testing.T) {
+	req := &types.TaxRequest{Year: "2024", TaxRegion: "uk", TaxCode: "1100L", ExNI: "y"}
+	resp := &types.TaxResponse{TaxYear: 2024, GrossPay: 30000, TaxFreeAllowance: 11000}
+
+	steps, err := Explain(req, resp)
+	require.NoError(t, err)
+
+	allowance := findStep(t, steps, "Tax-free allowance")
+	assert.Equal(t, "from tax code 1100L", allowance.Note)
+	assert.Equal(t, 11000.0, allowance.Amount)
+	assert.Equal(t, "exempt", findStep(t, steps, "Total National Insurance").Note)
+}
+
+func TestExplain_NITotal(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *types.TaxRequest
+		resp       func(*types.TaxRequest) *types.TaxResponse
+		difference float64
+		note       string
+	}{
+		{
+			name: "over State Pension age",
+			req:  &types.TaxRequest{Year: "2024", TaxRegion: "uk", GrossWage: 40000, Age: "70", PensionScheme: SchemeNetPay},
+			resp: func(req *types.TaxRequest) *types.TaxResponse {
+				resp, err := PensionScheme(req)
+				require.NoError(t, err)
+				return resp
+			},
+			note: "no employee National Insurance at or over State Pension age",
+		},
+		{
+			name: "API figure worked out per period",
+			req:  &types.TaxRequest{Year: "2024", TaxRegion: "uk"},
+			resp: func(*types.TaxRequest) *types.TaxResponse {
+				return &types.TaxResponse{TaxYear: 2024, GrossPay: 40000, NationalInsurance: 2200}
+			},
+			difference: 2200 - (40000-12570)*0.08,
+		},
+		{
+			name: "M1 tax code",
+			req:  &types.TaxRequest{Year: "2024", TaxRegion: "uk", GrossWage: 40000, TaxCode: "1257L M1", PensionScheme: SchemeNetPay},
+			resp: func(req *types.TaxRequest) *types.TaxResponse {
+				resp, err := PensionScheme(req)
+				require.NoError(t, err)
+				return resp
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := tt.resp(tt.req)
+			steps, err := Explain(tt.req, resp)
+			require.NoError(t, err)
+
+			// The NI steps always add up to the total
+			total := findStep(t, steps, "Total National Insurance")
+			assert.Equal(t, resp.NationalInsurance, total.Amount)
+			if tt.note != "" {
+				assert.Equal(t, tt.note, total.Note)
+			}
+			sum := 0.0
+			difference := 0.0
+			for _, step := range steps {
+				if step.Section == SectionNI && step.Name != total.Name {
+					sum += step.Amount
+				}
+				if step.Section == SectionNI && step.Name == "Difference" {
+					difference = step.Amount
+				}
+			}
+			assert.InDelta(t, resp.NationalInsurance, sum, 0.01)
+			assert.InDelta(t, tt.difference, difference, 0.01)
+		})
+	}
+}
+
+func TestExplain_Errors(t *testing.T) {
+	_, err := Explain(&types.TaxRequest{}, &types.TaxResponse{TaxYear: 2015})
+	assert.EqualError(t, err, "no local tax rates for 2015 (supported: 2022-2026)")
+
+	_, err = Explain(&types.TaxRequest{}, &types.TaxResponse{TaxYear: 2024, Employments: &types.Employments{}})
+	assert.EqualError(t, err, "explain does not cover multiple employments")
+}
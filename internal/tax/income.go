@@ -0,0 +1,111 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// AllowanceFor returns the personal allowance after the £1 for £2 taper on
+// adjusted net income above the taper threshold
+func (r *Rates) AllowanceFor(adjustedNetIncome float64) float64 {
+	allowance := r.PersonalAllowance
+	if adjustedNetIncome > r.TaperThreshold {
+		allowance -= (adjustedNetIncome - r.TaperThreshold) / 2
+	}
+	return math.Max(0, math.Floor(allowance))
+}
+
+// IncomeTax calculates tax on taxable income across the bands. The extension
+// widens every bounded band from the basic rate upwards, as happens with relief
+// at source pension contributions. The breakdown is keyed by band index,
+// matching the API's TaxDue.
+func IncomeTax(taxable float64, bands []Band, extension float64) (float64, map[string]types.TaxBracket) {
+	total := 0.0
+	due := make(map[string]types.TaxBracket)
+
+	for i, portion := range fillBands(0, taxable, bands, bandLimits(bands, extension), "") {
+		total += portion.Tax
+		due[strconv.Itoa(i)] = types.TaxBracket{
+			Name:   portion.Band,
+			Rate:   portion.Rate,
+			Amount: portion.Tax,
+		}
+	}
+
+	return total, due
+}
+
+// bandLimits returns the upper limit of each band, widening bounded bands from
+// the basic rate upwards by the extension. The last band is unbounded.
+func bandLimits(bands []Band, extension float64) []float64 {
+	limits := make([]float64, len(bands))
+	for i, band := range bands {
+		switch {
+		case band.Upper == 0:
+			limits[i] = math.Inf(1)
+		case band.Rate >= basicRateRelief:
+			limits[i] = band.Upper + extension
+		default:
+			limits[i] = band.Upper
+		}
+	}
+	return limits
+}
+
+// fillBands places an amount of income in the bands, starting at position
+// (the taxable income already in the bands), and returns the portion and tax
+// falling in each band
+func fillBands(position, amount float64, bands []Band, limits []float64, income string) []types.IncomeBand {
+	portions := make([]types.IncomeBand, len(bands))
+	lower := 0.0
+	end := position + amount
+
+	for i, band := range bands {
+		inBand := math.Max(0, math.Min(end, limits[i])-math.Max(position, lower))
+		portions[i] = types.IncomeBand{
+			Income: income,
+			Band:   band.Name,
+			Rate:   band.Rate,
+			Amount: inBand,
+			Tax:    inBand * band.Rate,
+		}
+		lower = limits[i]
+	}
+
+	return portions
+}
+
+// StudentLoanRepayment calculates the annual repayment for a plan
+func (r *Rates) StudentLoanRepayment(plan string, income float64) float64 {
+	p, ok := r.StudentLoan(plan)
+	if !ok || income <= p.Threshold {
+		return 0
+	}
+	return (income - p.Threshold) * p.Rate
+}
+
+// ParsePension converts a pension value ("5%" or "3000") into an annual amount
+func ParsePension(pension string, gross float64) (float64, error) {
+	pension = strings.TrimSpace(pension)
+	if pension == "" {
+		return 0, nil
+	}
+
+	if strings.HasSuffix(pension, "%") {
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(pension, "%"), 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return 0, fmt.Errorf("invalid pension percentage: %s", pension)
+		}
+		return gross * pct / 100, nil
+	}
+
+	amount, err := strconv.ParseFloat(pension, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid pension amount: %s", pension)
+	}
+	return amount, nil
+}
@@ -0,0 +1,138 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowanceFor(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		income float64
+		want   float64
+	}{
+		{"below taper", 50000, 12570},
+		{"at taper threshold", 100000, 12570},
+		{"partially tapered", 110000, 7570},
+		{"fully tapered", 125140, 0},
+		{"above taper", 200000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, rates.AllowanceFor(tt.income))
+		})
+	}
+}
+
+func TestIncomeTax(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		taxable   float64
+		extension float64
+		want      float64
+		wantBands map[string]float64
+	}{
+		{"zero", 0, 0, 0, map[string]float64{"0": 0, "1": 0, "2": 0}},
+		{"basic only", 10000, 0, 2000, map[string]float64{"0": 2000, "1": 0, "2": 0}},
+		{"higher", 47430, 0, 11432, map[string]float64{"0": 7540, "1": 3892, "2": 0}},
+		{"additional", 135140, 0, 47016, map[string]float64{"0": 7540, "1": 34976, "2": 4500}},
+		{"extended basic band", 47430, 5000, 10432, map[string]float64{"0": 8540, "1": 1892, "2": 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			total, due := IncomeTax(tt.taxable, rates.UKBands, tt.extension)
+			assert.InDelta(t, tt.want, total, 0.01)
+			for key, amount := range tt.wantBands {
+				assert.InDelta(t, amount, due[key].Amount, 0.01, "band %s", key)
+			}
+		})
+	}
+}
+
+func TestIncomeTax_ScottishStarterNotExtended(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	_, due := IncomeTax(10000, rates.ScottishBands, 5000)
+	assert.InDelta(t, 2827*0.19, due["0"].Amount, 0.01)
+	assert.Equal(t, "Starter Rate", due["0"].Name)
+}
+
+func TestStudentLoanRepayment(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		plan   string
+		income float64
+		want   float64
+	}{
+		{"no plan", "", 50000, 0},
+		{"below threshold", "plan2", 20000, 0},
+		{"plan2", "plan2", 38470, 900},
+		{"postgraduate", "postgraduate", 31000, 600},
+		{"scottish alias", "scottish", 42745, 900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, rates.StudentLoanRepayment(tt.plan, tt.income), 0.01)
+		})
+	}
+}
+
+func TestParsePension(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pension string
+		gross   float64
+		want    float64
+		wantErr bool
+	}{
+		{"empty", "", 50000, 0, false},
+		{"percentage", "5%", 50000, 2500, false},
+		{"fixed amount", "3000", 50000, 3000, false},
+		{"decimal percentage", "2.5%", 40000, 1000, false},
+		{"invalid percentage", "abc%", 50000, 0, true},
+		{"over 100 percent", "150%", 50000, 0, true},
+		{"negative amount", "-100", 50000, 0, true},
+		{"invalid amount", "lots", 50000, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParsePension(tt.pension, tt.gross)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 0.01)
+		})
+	}
+}
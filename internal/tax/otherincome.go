@@ -0,0 +1,171 @@
+package tax
+
+import (
+	"math"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Income types used in the band breakdown
+const (
+	IncomeEmployment = "Employment"
+	IncomeRental     = "Rental Profit"
+	IncomeSavings    = "Savings Interest"
+	IncomeDividends  = "Dividends"
+)
+
+// ApplyOtherIncome taxes rental profit, savings interest and dividends on top of
+// an employment or self-employment result, updating the response in place.
+// Allowances are used against rental profit first, then savings, then
+// dividends, and each type of income fills the bands after the ones before it.
+// Savings and dividends always use the UK bands, including for Scottish taxpayers.
+func ApplyOtherIncome(req *types.TaxRequest, resp *types.TaxResponse) error {
+	if req.RentalProfit == 0 && req.SavingsInterest == 0 && req.Dividends == 0 {
+		return nil
+	}
+
+	rates, err := RatesFor(req.Year)
+	if err != nil {
+		return err
+	}
+
+	// Relief at source pension contributions extend the bands
+	extension := 0.0
+	switch {
+	case req.SelfEmployed:
+		extension, err = ParsePension(req.Pension, float64(req.Profit))
+	case req.PensionScheme == SchemeReliefAtSource:
+		extension, err = ParsePension(req.Pension, float64(req.GrossWage))
+	}
+	if err != nil {
+		return err
+	}
+
+	rental := float64(req.RentalProfit)
+	savings := float64(req.SavingsInterest)
+	dividends := float64(req.Dividends)
+	employment := resp.TaxablePay + resp.TaxFreeAllowance
+
+	// The extra income can taper away more of the personal allowance
+	adjustedNetIncome := employment - extension
+	lost := rates.AllowanceFor(adjustedNetIncome) - rates.AllowanceFor(adjustedNetIncome+rental+savings+dividends)
+	allowance := math.Max(0, resp.TaxFreeAllowance-lost)
+	lost = resp.TaxFreeAllowance - allowance
+
+	// Use any allowance left over from employment against each type in turn
+	employmentTaxable := math.Max(0, employment-allowance)
+	remaining := math.Max(0, allowance-employment)
+	rentalTaxable, remaining := useAllowance(rental, remaining)
+	savingsTaxable, remaining := useAllowance(savings, remaining)
+	dividendsTaxable, _ := useAllowance(dividends, remaining)
+
+	breakdown := []types.IncomeBand{}
+	totalTax := 0.0
+
+	// Employment tax rises if the allowance is tapered further
+	bands := rates.BandsFor(req.TaxRegion)
+	if lost > 0 {
+		before, _ := IncomeTax(resp.TaxablePay, bands, extension)
+		after, _ := IncomeTax(employmentTaxable, bands, extension)
+		breakdown = append(breakdown, types.IncomeBand{
+			Income: IncomeEmployment,
+			Band:   "Allowance Taper",
+			Rate:   (after - before) / lost,
+			Amount: lost,
+			Tax:    after - before,
+		})
+		totalTax += after - before
+	}
+
+	// Rental profit is non-savings income and uses the regional bands
+	portions := fillBands(employmentTaxable, rentalTaxable, bands, bandLimits(bands, extension), IncomeRental)
+	breakdown, totalTax = appendPortions(breakdown, totalTax, portions)
+
+	// Savings and dividends sit on top, in the UK bands
+	limits := bandLimits(rates.UKBands, extension)
+	position := employmentTaxable + rentalTaxable
+
+	startingRate := math.Min(savingsTaxable, math.Max(0, rates.StartingRateForSavings-position))
+	savingsAllowance := math.Min(savingsTaxable-startingRate, rates.savingsAllowance(position+savingsTaxable+dividendsTaxable, limits))
+	breakdown = appendZeroRated(breakdown, IncomeSavings, "Starting Rate for Savings", startingRate)
+	breakdown = appendZeroRated(breakdown, IncomeSavings, "Personal Savings Allowance", savingsAllowance)
+	position += startingRate + savingsAllowance
+
+	taxedSavings := savingsTaxable - startingRate - savingsAllowance
+	portions = fillBands(position, taxedSavings, rates.UKBands, limits, IncomeSavings)
+	breakdown, totalTax = appendPortions(breakdown, totalTax, portions)
+	position += taxedSavings
+
+	dividendAllowance := math.Min(dividendsTaxable, rates.DividendAllowance)
+	breakdown = appendZeroRated(breakdown, IncomeDividends, "Dividend Allowance", dividendAllowance)
+	position += dividendAllowance
+
+	portions = fillBands(position, dividendsTaxable-dividendAllowance, rates.dividendBands(), limits, IncomeDividends)
+	breakdown, totalTax = appendPortions(breakdown, totalTax, portions)
+
+	resp.TaxPaid += totalTax
+	resp.NetPay += rental + savings + dividends - totalTax
+
+	// Sole traders pay tax on other income through self assessment too
+	if se := resp.SelfEmployment; se != nil {
+		se.TotalLiability += totalTax
+		se.PaymentOnAccount = paymentOnAccount(resp.TaxPaid + se.Class4NI)
+	}
+
+	resp.OtherIncome = &types.OtherIncome{
+		RentalProfit:    rental,
+		SavingsInterest: savings,
+		Dividends:       dividends,
+		AllowanceLost:   lost,
+		Tax:             totalTax,
+		Bands:           breakdown,
+	}
+
+	return nil
+}
+
+// useAllowance returns the taxable part of income and the allowance left over
+func useAllowance(income, allowance float64) (taxable, remaining float64) {
+	return math.Max(0, income-allowance), math.Max(0, allowance-income)
+}
+
+// savingsAllowance returns the personal savings allowance, which depends on
+// the highest band total taxable income reaches
+func (r *Rates) savingsAllowance(totalTaxable float64, limits []float64) float64 {
+	switch {
+	case totalTaxable <= limits[0]:
+		return r.SavingsAllowanceBasic
+	case totalTaxable <= limits[1]:
+		return r.SavingsAllowanceHigher
+	default:
+		return 0
+	}
+}
+
+// dividendBands returns the UK bands with dividend rates
+func (r *Rates) dividendBands() []Band {
+	bands := make([]Band, len(r.UKBands))
+	for i, band := range r.UKBands {
+		bands[i] = Band{Name: band.Name, Rate: r.DividendRates[i], Upper: band.Upper}
+	}
+	return bands
+}
+
+// appendPortions adds the non-empty band portions to the breakdown and total tax
+func appendPortions(breakdown []types.IncomeBand, total float64, portions []types.IncomeBand) ([]types.IncomeBand, float64) {
+	for _, portion := range portions {
+		if portion.Amount > 0 {
+			breakdown = append(breakdown, portion)
+			total += portion.Tax
+		}
+	}
+	return breakdown, total
+}
+
+// appendZeroRated adds income covered by a 0% allowance to the breakdown
+func appendZeroRated(breakdown []types.IncomeBand, income, band string, amount float64) []types.IncomeBand {
+	if amount <= 0 {
+		return breakdown
+	}
+	return append(breakdown, types.IncomeBand{Income: income, Band: band, Amount: amount})
+}
@@ -0,0 +1,186 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// employedResponse returns an API-style response for a 2025 salary
+func employedResponse(gross, allowance float64) *types.TaxResponse {
+	return testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.TaxYear = 2025
+		r.GrossPay = gross
+		r.TaxFreeAllowance = allowance
+		r.TaxablePay = gross - allowance
+		r.NetPay = 40000
+	})
+}
+
+func findBand(t *testing.T, other *types.OtherIncome, income, band string) types.IncomeBand {
+	t.Helper()
+	for _, b := range other.Bands {
+		if b.Income == income && b.Band == band {
+			return b
+		}
+	}
+	t.Fatalf("no %s portion in %s", income, band)
+	return types.IncomeBand{}
+}
+
+func TestApplyOtherIncome_NoOtherIncome(t *testing.T) {
+	t.Parallel()
+
+	req := testutil.CreateSampleTaxRequest()
+	resp := employedResponse(50000, 12570)
+
+	require.NoError(t, ApplyOtherIncome(req, resp))
+	assert.Nil(t, resp.OtherIncome)
+	assert.InDelta(t, 7486.0, resp.TaxPaid, 0.01)
+}
+
+func TestApplyOtherIncome_DividendsFillBasicThenHigher(t *testing.T) {
+	t.Parallel()
+
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Year = "2025"
+		r.Dividends = 10000
+	})
+	resp := employedResponse(45000, 12570)
+
+	require.NoError(t, ApplyOtherIncome(req, resp))
+	require.NotNil(t, resp.OtherIncome)
+
+	// Employment fills the bands to £32,430; £500 allowance takes it to £32,930
+	assert.InDelta(t, 500, findBand(t, resp.OtherIncome, IncomeDividends, "Dividend Allowance").Amount, 0.01)
+	basic := findBand(t, resp.OtherIncome, IncomeDividends, "Basic Rate")
+	assert.InDelta(t, 4770, basic.Amount, 0.01)
+	assert.Equal(t, 0.0875, basic.Rate)
+	higher := findBand(t, resp.OtherIncome, IncomeDividends, "Higher Rate")
+	assert.InDelta(t, 4730, higher.Amount, 0.01)
+
+	wantTax := 4770*0.0875 + 4730*0.3375
+	assert.InDelta(t, wantTax, resp.OtherIncome.Tax, 0.01)
+	assert.InDelta(t, 7486.0+wantTax, resp.TaxPaid, 0.01)
+	assert.InDelta(t, 40000+10000-wantTax, resp.NetPay, 0.01)
+}
+
+func TestApplyOtherIncome_SavingsAllowances(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		gross         float64
+		savings       int
+		wantStarting  float64
+		wantAllowance float64
+		wantTax       float64
+	}{
+		{"starting rate for savings", 14570, 4000, 3000, 1000, 0},
+		{"basic rate allowance", 30000, 3000, 0, 1000, 400},
+		{"higher rate allowance", 60000, 3000, 0, 500, 1000},
+		{"no allowance for additional rate", 130000, 3000, 0, 0, 1350},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			allowance := 12570.0
+			if tt.gross > 125140 {
+				allowance = 0
+			}
+			req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+				r.Year = "2025"
+				r.SavingsInterest = tt.savings
+			})
+			resp := employedResponse(tt.gross, allowance)
+
+			require.NoError(t, ApplyOtherIncome(req, resp))
+
+			starting, allowanceUsed := 0.0, 0.0
+			for _, b := range resp.OtherIncome.Bands {
+				switch b.Band {
+				case "Starting Rate for Savings":
+					starting = b.Amount
+				case "Personal Savings Allowance":
+					allowanceUsed = b.Amount
+				}
+			}
+			assert.InDelta(t, tt.wantStarting, starting, 0.01)
+			assert.InDelta(t, tt.wantAllowance, allowanceUsed, 0.01)
+			assert.InDelta(t, tt.wantTax, resp.OtherIncome.Tax, 0.01)
+		})
+	}
+}
+
+func TestApplyOtherIncome_RentalUsesRegionalBands(t *testing.T) {
+	t.Parallel()
+
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Year = "2025"
+		r.TaxRegion = "scotland"
+		r.RentalProfit = 5000
+	})
+	resp := employedResponse(30000, 12570)
+
+	require.NoError(t, ApplyOtherIncome(req, resp))
+
+	// Taxable employment of £17,430 sits in the intermediate band
+	portion := findBand(t, resp.OtherIncome, IncomeRental, "Intermediate")
+	assert.InDelta(t, 5000, portion.Amount, 0.01)
+	assert.InDelta(t, 1050, resp.OtherIncome.Tax, 0.01)
+}
+
+func TestApplyOtherIncome_AllowanceTaper(t *testing.T) {
+	t.Parallel()
+
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Year = "2025"
+		r.RentalProfit = 10000
+	})
+	resp := employedResponse(100000, 12570)
+
+	require.NoError(t, ApplyOtherIncome(req, resp))
+
+	assert.InDelta(t, 5000, resp.OtherIncome.AllowanceLost, 0.01)
+	taper := findBand(t, resp.OtherIncome, IncomeEmployment, "Allowance Taper")
+	assert.InDelta(t, 2000, taper.Tax, 0.01)
+	assert.InDelta(t, 2000+4000, resp.OtherIncome.Tax, 0.01)
+}
+
+func TestApplyOtherIncome_SelfEmployedBill(t *testing.T) {
+	t.Parallel()
+
+	req := selfEmployedRequest(func(r *types.TaxRequest) {
+		r.Dividends = 5000
+	})
+	resp, err := SelfEmployed(req)
+	require.NoError(t, err)
+	before := resp.SelfEmployment.TotalLiability
+
+	require.NoError(t, ApplyOtherIncome(req, resp))
+
+	assert.InDelta(t, before+resp.OtherIncome.Tax, resp.SelfEmployment.TotalLiability, 0.01)
+	assert.InDelta(t, (resp.TaxPaid+resp.SelfEmployment.Class4NI)/2, resp.SelfEmployment.PaymentOnAccount, 0.01)
+}
+
+func TestApplyOtherIncome_Errors(t *testing.T) {
+	t.Parallel()
+
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Year = "1999"
+		r.Dividends = 1000
+	})
+	assert.Error(t, ApplyOtherIncome(req, employedResponse(50000, 12570)))
+
+	req = selfEmployedRequest(func(r *types.TaxRequest) {
+		r.Dividends = 1000
+		r.Pension = "bad"
+	})
+	assert.Error(t, ApplyOtherIncome(req, employedResponse(50000, 12570)))
+}
@@ -0,0 +1,249 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Pay frequencies for payslip simulation
+const (
+	FrequencyWeekly      = "weekly"
+	FrequencyFortnightly = "fortnightly"
+	FrequencyFourWeekly  = "four-weekly"
+	FrequencyMonthly     = "monthly"
+)
+
+// PeriodsPerYear returns the number of pay periods in a tax year for a pay frequency
+func PeriodsPerYear(frequency string) (int, error) {
+	switch frequency {
+	case FrequencyWeekly:
+		return 52, nil
+	case FrequencyFortnightly:
+		return 26, nil
+	case FrequencyFourWeekly:
+		return 13, nil
+	case FrequencyMonthly:
+		return 12, nil
+	default:
+		return 0, fmt.Errorf("invalid frequency: %s (must be one of: weekly, fortnightly, four-weekly, monthly)", frequency)
+	}
+}
+
+// DefaultTaxCode returns the emergency tax code for the year, e.g. 1257L
+func (r *Rates) DefaultTaxCode() string {
+	return fmt.Sprintf("%dL", int(r.PersonalAllowance/10))
+}
+
+// PeriodPay is the gross pay, pension and tax code for a single pay period
+type PeriodPay struct {
+	Period  int
+	Date    string
+	Gross   float64
+	Pension float64
+	TaxCode *TaxCode
+}
+
+// Payslips simulates the payslips for every period of the tax year, spreading
+// the salary evenly and applying changes from the period they start. When the
+// request's pay days include an extra weekly, fortnightly or four-weekly pay
+// day (week 53), it is paid at the same rate as the others.
+func Payslips(req *types.TaxRequest, frequency string, changes []types.SalaryChange) ([]types.Payslip, error) {
+	rates, err := RatesFor(req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := PeriodsPerYear(frequency)
+	if err != nil {
+		return nil, err
+	}
+	payDays := periods
+	if frequency != FrequencyMonthly {
+		payDays = max(periods, req.WorkPattern.PayDays)
+	}
+
+	changes, err = sortChanges(changes, payDays, frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := ParseTaxCode(rates.taxCodeOrDefault(req.TaxCode))
+	if err != nil {
+		return nil, err
+	}
+
+	income := float64(req.GrossWage)
+	pay := make([]PeriodPay, 0, payDays)
+	for period := 1; period <= payDays; period++ {
+		// Apply any changes that start this period
+		for len(changes) > 0 && changes[0].Period == period {
+			if income, code, err = applyChange(changes[0], income, code); err != nil {
+				return nil, err
+			}
+			changes = changes[1:]
+		}
+
+		gross := income / float64(periods)
+		pension, err := periodPension(req.Pension, gross, periods)
+		if err != nil {
+			return nil, err
+		}
+		pay = append(pay, PeriodPay{Period: period, Gross: gross, Pension: pension, TaxCode: code})
+	}
+
+	return rates.SimulatePayslips(req, periods, pay)
+}
+
+// SimulatePayslips works out the deductions for each period's pay. Tax on a
+// cumulative code is worked out on pay to date against the allowance and bands
+// to date, so it catches up after a change. W1, M1 and X codes tax each period
+// on its own. National Insurance and student loan deductions are always worked
+// out per period, and pension contributions are taken before tax. Pay must be
+// in period order; periods with no pay can be left out. A period after the
+// usual number, such as week 53, is taxed on a week 1 basis, as payroll does.
+func (r *Rates) SimulatePayslips(req *types.TaxRequest, periods int, pay []PeriodPay) ([]types.Payslip, error) {
+	slips := make([]types.Payslip, 0, len(pay))
+	taxablePayToDate := 0.0
+	previous := types.Payslip{}
+
+	for _, p := range pay {
+		taxable := p.Gross - p.Pension
+		taxablePayToDate += taxable
+
+		region := p.TaxCode.Region
+		if region == "" {
+			region = req.TaxRegion
+		}
+		code := p.TaxCode
+		if p.Period > periods && code.Cumulative() {
+			week1 := *code
+			week1.Basis = BasisWeek1
+			code = &week1
+		}
+		tax, err := payslipTax(code, r.BandsFor(region), taxable, taxablePayToDate, previous.TaxPaidToDate,
+			float64(p.Period)/float64(periods), 1/float64(periods))
+		if err != nil {
+			return nil, err
+		}
+
+		// NI and student loan thresholds apply to each period on its own
+		ni := 0.0
+		if req.ExNI != "y" {
+			ni = r.EmployeeNI(p.Gross*float64(periods)) / float64(periods)
+		}
+		studentLoan := r.StudentLoanRepayment(req.Plan, p.Gross*float64(periods)) / float64(periods)
+		net := p.Gross - p.Pension - tax - ni - studentLoan
+
+		slip := types.Payslip{
+			Period:                  p.Period,
+			Date:                    p.Date,
+			TaxCode:                 p.TaxCode.String(),
+			GrossPay:                p.Gross,
+			TaxPaid:                 tax,
+			NationalInsurance:       ni,
+			StudentLoanRepayment:    studentLoan,
+			Pension:                 p.Pension,
+			NetPay:                  net,
+			GrossPayToDate:          previous.GrossPayToDate + p.Gross,
+			TaxPaidToDate:           previous.TaxPaidToDate + tax,
+			NationalInsuranceToDate: previous.NationalInsuranceToDate + ni,
+			StudentLoanToDate:       previous.StudentLoanToDate + studentLoan,
+			PensionToDate:           previous.PensionToDate + p.Pension,
+			NetPayToDate:            previous.NetPayToDate + net,
+		}
+		slips = append(slips, slip)
+		previous = slip
+	}
+
+	return slips, nil
+}
+
+// taxCodeOrDefault returns the tax code, or the standard code for the year if none is set
+func (r *Rates) taxCodeOrDefault(code string) string {
+	if code == "" {
+		return r.DefaultTaxCode()
+	}
+	return code
+}
+
+// sortChanges returns the changes in period order, checking each falls in the year
+func sortChanges(changes []types.SalaryChange, periods int, frequency string) ([]types.SalaryChange, error) {
+	sorted := append([]types.SalaryChange(nil), changes...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Period < sorted[j].Period })
+
+	for _, change := range sorted {
+		if change.Period < 1 || change.Period > periods {
+			return nil, fmt.Errorf("change period %d is outside 1-%d for %s pay", change.Period, periods, frequency)
+		}
+	}
+	return sorted, nil
+}
+
+// applyChange returns the salary and tax code after a change
+func applyChange(change types.SalaryChange, income float64, code *TaxCode) (float64, *TaxCode, error) {
+	if change.Income > 0 {
+		income = float64(change.Income)
+	}
+	if change.TaxCode != "" {
+		parsed, err := ParseTaxCode(change.TaxCode)
+		if err != nil {
+			return 0, nil, fmt.Errorf("change in period %d: %w", change.Period, err)
+		}
+		code = parsed
+	}
+	return income, code, nil
+}
+
+// payslipTax returns the tax deducted in one period. Cumulative codes work out
+// tax due on pay to date (toDate is the fraction of the year so far) less tax
+// already paid; W1, M1 and X codes use one period's share of the allowance and
+// bands (period is the fraction of the year one period covers).
+func payslipTax(code *TaxCode, bands []Band, pay, payToDate, taxPaidToDate, toDate, period float64) (float64, error) {
+	var tax float64
+	switch {
+	case code.NoTax:
+		return 0, nil
+	case code.FlatRate != "":
+		band, err := flatRateBand(code, bands)
+		if err != nil {
+			return 0, err
+		}
+		return pay * band.Rate, nil
+	case code.Cumulative():
+		tax = periodTax(payToDate, code.Allowance*toDate, bands, toDate) - taxPaidToDate
+	default:
+		tax = periodTax(pay, code.Allowance*period, bands, period)
+	}
+
+	// K code deductions are capped at half of each period's pay
+	if code.IsKCode() {
+		tax = math.Min(tax, pay*kCodeCap)
+	}
+	return tax, nil
+}
+
+// periodTax returns the tax on pay after the allowance, using the bands scaled
+// to the fraction of the year the pay covers
+func periodTax(pay, allowance float64, bands []Band, fraction float64) float64 {
+	scaled := make([]Band, len(bands))
+	for i, band := range bands {
+		scaled[i] = Band{Name: band.Name, Rate: band.Rate, Upper: band.Upper * fraction}
+	}
+
+	tax, _ := IncomeTax(math.Max(0, pay-allowance), scaled, 0)
+	return tax
+}
+
+// periodPension returns the pension contribution for one period: a percentage
+// of the period's pay, or an annual amount spread evenly across the year
+func periodPension(pension string, gross float64, periods int) (float64, error) {
+	amount, err := ParsePension(pension, gross)
+	if err != nil || strings.HasSuffix(strings.TrimSpace(pension), "%") {
+		return amount, err
+	}
+	return amount / float64(periods), nil
+}
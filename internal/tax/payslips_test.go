@@ -0,0 +1,191 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func payslipsRequest(overrides ...func(*types.TaxRequest)) *types.TaxRequest {
+	return testutil.CreateSampleTaxRequest(append([]func(*types.TaxRequest){
+		func(r *types.TaxRequest) {
+			r.Year = "2025"
+			r.GrossWage = 60000
+		},
+	}, overrides...)...)
+}
+
+func TestPeriodsPerYear(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]int{
+		FrequencyWeekly:      52,
+		FrequencyFortnightly: 26,
+		FrequencyFourWeekly:  13,
+		FrequencyMonthly:     12,
+	}
+
+	for frequency, want := range tests {
+		got, err := PeriodsPerYear(frequency)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, frequency)
+	}
+
+	_, err := PeriodsPerYear("daily")
+	assert.ErrorContains(t, err, "invalid frequency: daily")
+}
+
+func TestPayslips_LevelPayMatchesAnnual(t *testing.T) {
+	t.Parallel()
+
+	for _, frequency := range []string{FrequencyWeekly, FrequencyFortnightly, FrequencyFourWeekly, FrequencyMonthly} {
+		t.Run(frequency, func(t *testing.T) {
+			t.Parallel()
+
+			slips, err := Payslips(payslipsRequest(), frequency, nil)
+			require.NoError(t, err)
+
+			last := slips[len(slips)-1]
+			assert.InDelta(t, 60000, last.GrossPayToDate, 0.01)
+			assert.InDelta(t, 11432, last.TaxPaidToDate, 0.01)
+			assert.InDelta(t, 3210.60, last.NationalInsuranceToDate, 0.01)
+			assert.InDelta(t, 45357.40, last.NetPayToDate, 0.01)
+			assert.Equal(t, "1257L", last.TaxCode)
+		})
+	}
+}
+
+func TestPayslips_Week53(t *testing.T) {
+	t.Parallel()
+
+	req := payslipsRequest(func(r *types.TaxRequest) { r.WorkPattern.PayDays = 53 })
+	slips, err := Payslips(req, FrequencyWeekly, nil)
+	require.NoError(t, err)
+	require.Len(t, slips, 53)
+
+	// Week 53 is an extra week's pay, taxed on a week 1 basis
+	week53 := slips[52]
+	assert.Equal(t, 53, week53.Period)
+	assert.InDelta(t, 60000.0/52, week53.GrossPay, 0.01)
+	assert.InDelta(t, 11432.0/52, week53.TaxPaid, 0.01)
+	assert.Equal(t, "1257L", week53.TaxCode)
+	assert.InDelta(t, 60000.0*53/52, week53.GrossPayToDate, 0.01)
+	assert.InDelta(t, 11432.0*53/52, week53.TaxPaidToDate, 0.01)
+
+	// A fortnightly year has fortnight 27, and monthly pay never has an extra month
+	req.WorkPattern.PayDays = 27
+	slips, err = Payslips(req, FrequencyFortnightly, []types.SalaryChange{{Period: 27, Income: 78000}})
+	require.NoError(t, err)
+	require.Len(t, slips, 27)
+	assert.InDelta(t, 3000, slips[26].GrossPay, 0.01)
+
+	slips, err = Payslips(req, FrequencyMonthly, nil)
+	require.NoError(t, err)
+	assert.Len(t, slips, 12)
+}
+
+func TestPayslips_CumulativeVersusMonth1(t *testing.T) {
+	t.Parallel()
+
+	// Low pay for six months leaves allowance unused until the pay rise
+	changes := []types.SalaryChange{{Period: 7, Income: 60000}}
+
+	cumulative, err := Payslips(payslipsRequest(func(r *types.TaxRequest) { r.GrossWage = 6000 }), FrequencyMonthly, changes)
+	require.NoError(t, err)
+	assert.Zero(t, cumulative[5].TaxPaidToDate)
+	assert.InDelta(t, (33000-12570)*0.20, cumulative[11].TaxPaidToDate, 0.01)
+
+	// On M1 the unused allowance from earlier months is lost
+	month1, err := Payslips(payslipsRequest(func(r *types.TaxRequest) {
+		r.GrossWage = 6000
+		r.TaxCode = "1257L M1"
+	}), FrequencyMonthly, changes)
+	require.NoError(t, err)
+	monthlyTax := 37700.0/12*0.20 + (5000-12570.0/12-37700.0/12)*0.40
+	assert.InDelta(t, monthlyTax, month1[6].TaxPaid, 0.01)
+	assert.InDelta(t, monthlyTax*6, month1[11].TaxPaidToDate, 0.01)
+	assert.Equal(t, "1257L M1", month1[11].TaxCode)
+}
+
+func TestPayslips_CodeChangeCatchesUp(t *testing.T) {
+	t.Parallel()
+
+	// An emergency BR code is replaced by 1257L, which refunds the overpaid tax
+	slips, err := Payslips(payslipsRequest(func(r *types.TaxRequest) {
+		r.GrossWage = 24000
+		r.TaxCode = "BR"
+	}), FrequencyMonthly, []types.SalaryChange{{Period: 4, TaxCode: "1257L"}})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 400, slips[0].TaxPaid, 0.01)
+	assert.Equal(t, "1257L", slips[3].TaxCode)
+	assert.InDelta(t, (8000-4190)*0.20-1200, slips[3].TaxPaid, 0.01)
+	assert.InDelta(t, (24000-12570)*0.20, slips[11].TaxPaidToDate, 0.01)
+}
+
+func TestPayslips_Deductions(t *testing.T) {
+	t.Parallel()
+
+	slips, err := Payslips(payslipsRequest(func(r *types.TaxRequest) {
+		r.Pension = "1200"
+		r.Plan = "plan2"
+		r.ExNI = "y"
+	}), FrequencyMonthly, nil)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 100, slips[0].Pension, 0.01)
+	assert.Zero(t, slips[0].NationalInsurance)
+	assert.InDelta(t, (5000-28470.0/12)*0.09, slips[0].StudentLoanRepayment, 0.01)
+	assert.InDelta(t, 37700*0.20+(58800-12570-37700)*0.40, slips[11].TaxPaidToDate, 0.01)
+
+	// Student loan and pension deductions add up through the year
+	assert.InDelta(t, 1200, slips[11].PensionToDate, 0.01)
+	assert.InDelta(t, (60000-28470.0)*0.09, slips[11].StudentLoanToDate, 0.01)
+	assert.InDelta(t, 2*slips[0].StudentLoanRepayment, slips[1].StudentLoanToDate, 0.01)
+}
+
+func TestPayslips_KCodeCap(t *testing.T) {
+	t.Parallel()
+
+	slips, err := Payslips(payslipsRequest(func(r *types.TaxRequest) {
+		r.GrossWage = 12000
+		r.TaxCode = "K5000"
+	}), FrequencyMonthly, nil)
+	require.NoError(t, err)
+
+	for _, slip := range slips {
+		assert.InDelta(t, 500, slip.TaxPaid, 0.01)
+	}
+}
+
+func TestPayslips_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		req       *types.TaxRequest
+		frequency string
+		changes   []types.SalaryChange
+		wantErr   string
+	}{
+		{"unsupported year", payslipsRequest(func(r *types.TaxRequest) { r.Year = "2019" }), FrequencyMonthly, nil, "no local tax rates"},
+		{"invalid frequency", payslipsRequest(), "daily", nil, "invalid frequency"},
+		{"invalid tax code", payslipsRequest(func(r *types.TaxRequest) { r.TaxCode = "ABC" }), FrequencyMonthly, nil, "invalid tax code"},
+		{"change outside year", payslipsRequest(), FrequencyMonthly, []types.SalaryChange{{Period: 13, Income: 1}}, "change period 13 is outside 1-12"},
+		{"invalid change code", payslipsRequest(), FrequencyMonthly, []types.SalaryChange{{Period: 2, TaxCode: "ABC"}}, "change in period 2: invalid tax code"},
+		{"invalid pension", payslipsRequest(func(r *types.TaxRequest) { r.Pension = "x%" }), FrequencyMonthly, nil, "invalid pension percentage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Payslips(tt.req, tt.frequency, tt.changes)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
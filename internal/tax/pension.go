@@ -0,0 +1,193 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Pension schemes, which differ in when the contribution is taken from pay
+const (
+	SchemeSacrifice      = "sacrifice"
+	SchemeNetPay         = "net-pay"
+	SchemeReliefAtSource = "relief-at-source"
+)
+
+// StatePensionAge is the age from which employees pay no National Insurance
+const StatePensionAge = 66
+
+// PensionSchemes lists the valid pension schemes
+var PensionSchemes = []string{SchemeSacrifice, SchemeNetPay, SchemeReliefAtSource}
+
+// ValidatePensionScheme checks that a pension scheme is supported
+func ValidatePensionScheme(scheme string) error {
+	for _, valid := range PensionSchemes {
+		if scheme == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid pension scheme: %s (must be one of: %s)", scheme, strings.Join(PensionSchemes, ", "))
+}
+
+// PensionScheme calculates an employee's pay with the pension contribution
+// taken the way the scheme takes it:
+//   - sacrifice: salary is reduced by the contribution before tax and National
+//     Insurance, saving employee and employer NI
+//   - net-pay: the contribution is taken from pay before tax, but after NI
+//   - relief-at-source: 80% of the contribution is paid from net pay and the
+//     provider claims the 20% basic rate top-up; higher rate relief is
+//     claimed back through self assessment by extending the bands
+//
+// Extra income, or deductions when negative, is added to pay subject to tax
+// but not NI. Employees at or over State Pension age pay no National
+// Insurance.
+// The pension value is the gross contribution into the pension, as a
+// percentage of salary or an annual amount.
+func PensionScheme(req *types.TaxRequest) (*types.TaxResponse, error) {
+	if err := ValidatePensionScheme(req.PensionScheme); err != nil {
+		return nil, err
+	}
+
+	rates, err := RatesFor(req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	gross := float64(req.GrossWage)
+	if gross <= 0 {
+		return nil, fmt.Errorf("income must be greater than 0")
+	}
+
+	contribution, err := ParsePension(req.Pension, gross)
+	if err != nil {
+		return nil, err
+	}
+	if contribution > gross {
+		return nil, fmt.Errorf("pension contribution %.2f is more than income %.2f", contribution, gross)
+	}
+
+	// Pay subject to NI, and pay subject to tax before the allowance
+	extra := float64(req.Extra)
+	niPay, taxablePay := gross, gross+extra
+	switch req.PensionScheme {
+	case SchemeSacrifice:
+		niPay, taxablePay = gross-contribution, gross-contribution+extra
+	case SchemeNetPay:
+		taxablePay = gross - contribution + extra
+	}
+
+	allowance, bands, err := rates.schemeAllowance(req, gross-contribution+extra)
+	if err != nil {
+		return nil, err
+	}
+	taxable := math.Max(0, taxablePay-allowance)
+
+	incomeTax, taxDue := IncomeTax(taxable, bands, 0)
+	marriageAllowance := 0.0
+	if req.TaxCode == "" {
+		marriageAllowance = rates.marriageAllowanceFor(req, taxDue)
+	}
+	taxPaid := math.Max(0, incomeTax-marriageAllowance*basicRateRelief)
+
+	scheme := &types.PensionScheme{Scheme: req.PensionScheme, Contribution: contribution, Total: contribution}
+	pensionYou, pensionHMRC, claimback := contribution, 0.0, 0.0
+	if req.PensionScheme == SchemeReliefAtSource {
+		pensionHMRC = contribution * basicRateRelief
+		pensionYou = contribution - pensionHMRC
+		taxWithRelief, _ := IncomeTax(taxable, bands, contribution)
+		claimback = incomeTax - taxWithRelief
+		scheme.BasicRateTopUp = pensionHMRC
+		scheme.HigherRateRelief = claimback
+	}
+
+	ni, fullNI := 0.0, 0.0
+	if req.ExNI != "y" && !overStatePensionAge(req.Age) {
+		ni, fullNI = rates.EmployeeNI(niPay), rates.EmployeeNI(gross)
+	}
+	scheme.EmployeeNISaving = fullNI - ni
+	scheme.EmployerNISaving = rates.EmployerNI(gross) - rates.EmployerNI(niPay)
+
+	studentLoan := rates.StudentLoanRepayment(req.Plan, niPay)
+
+	return &types.TaxResponse{
+		TaxYear:                  rates.Year,
+		TaxablePay:               taxable,
+		GrossPay:                 gross,
+		TaxFreeAllowance:         allowance,
+		TaxPaid:                  taxPaid,
+		TaxDue:                   taxDue,
+		NationalInsurance:        ni,
+		NetPay:                   gross - pensionYou - taxPaid - ni - studentLoan,
+		StudentLoanRepayment:     studentLoan,
+		PensionHMRC:              pensionHMRC,
+		PensionYou:               pensionYou,
+		PensionClaimback:         claimback,
+		EmployersNI:              rates.EmployerNI(niPay),
+		TaxRegion:                req.TaxRegion,
+		TaxCode:                  rates.taxCodeOrDefault(req.TaxCode),
+		TaxFreeMarriageAllowance: marriageAllowance,
+		GrossSacrifice:           gross - niPay,
+		PensionScheme:            scheme,
+	}, nil
+}
+
+// overStatePensionAge reports whether an age is at or over State Pension age,
+// from which employees pay no National Insurance
+func overStatePensionAge(age string) bool {
+	years, err := strconv.Atoi(age)
+	return err == nil && years >= StatePensionAge
+}
+
+// schemeAllowance returns the tax-free allowance and bands: from the tax code
+// if there is one, otherwise the personal allowance tapered on adjusted net
+// income, plus any blind person's allowance
+func (r *Rates) schemeAllowance(req *types.TaxRequest, adjustedNetIncome float64) (float64, []Band, error) {
+	if req.TaxCode == "" {
+		allowance := r.AllowanceFor(adjustedNetIncome)
+		if req.Blind == "y" {
+			allowance += r.BlindAllowance
+		}
+		return allowance, r.BandsFor(req.TaxRegion), nil
+	}
+
+	code, err := ParseTaxCode(req.TaxCode)
+	if err != nil {
+		return 0, nil, err
+	}
+	if code.NoTax || code.FlatRate != "" {
+		return 0, nil, fmt.Errorf("--pension-scheme needs a tax code with an allowance, got: %s", code.Code)
+	}
+
+	region := code.Region
+	if region == "" {
+		region = req.TaxRegion
+	}
+	return code.Allowance, r.BandsFor(region), nil
+}
+
+// ApplyEmployerPension adds the employer's pension contribution, worked out
+// on salary before any sacrifice, and the total paid into the pension
+func ApplyEmployerPension(req *types.TaxRequest, resp *types.TaxResponse) error {
+	if req.EmployerPension == "" {
+		return nil
+	}
+
+	employer, err := ParsePension(req.EmployerPension, resp.GrossPay)
+	if err != nil {
+		return fmt.Errorf("employer pension: %w", err)
+	}
+
+	if resp.PensionScheme == nil {
+		resp.PensionScheme = &types.PensionScheme{
+			Contribution:     resp.PensionYou + resp.PensionHMRC,
+			BasicRateTopUp:   resp.PensionHMRC,
+			HigherRateRelief: resp.PensionClaimback,
+		}
+	}
+	resp.PensionScheme.Employer = employer
+	resp.PensionScheme.Total = resp.PensionScheme.Contribution + employer
+	return nil
+}
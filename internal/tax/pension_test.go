@@ -0,0 +1,165 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func schemeRequest(scheme string) *types.TaxRequest {
+	return testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.Year = "2024"
+		r.GrossWage = 60000
+		r.Pension = "5%"
+		r.PensionScheme = scheme
+	})
+}
+
+func TestPensionScheme_Sacrifice(t *testing.T) {
+	t.Parallel()
+
+	resp, err := PensionScheme(schemeRequest(SchemeSacrifice))
+	require.NoError(t, err)
+
+	// Tax, NI and employer NI are all on the reduced salary of £57,000
+	assert.InDelta(t, 60000, resp.GrossPay, 0.01)
+	assert.InDelta(t, 3000, resp.GrossSacrifice, 0.01)
+	assert.InDelta(t, 10232, resp.TaxPaid, 0.01)
+	assert.InDelta(t, 3150.60, resp.NationalInsurance, 0.01)
+	assert.InDelta(t, 6610.20, resp.EmployersNI, 0.01)
+	assert.InDelta(t, 3000, resp.PensionYou, 0.01)
+	assert.Zero(t, resp.PensionHMRC)
+	assert.InDelta(t, 60000-3000-10232-3150.60, resp.NetPay, 0.01)
+
+	require.NotNil(t, resp.PensionScheme)
+	assert.Equal(t, SchemeSacrifice, resp.PensionScheme.Scheme)
+	assert.InDelta(t, 60, resp.PensionScheme.EmployeeNISaving, 0.01)
+	assert.InDelta(t, 414, resp.PensionScheme.EmployerNISaving, 0.01)
+	assert.InDelta(t, 3000, resp.PensionScheme.Total, 0.01)
+}
+
+func TestPensionScheme_NetPay(t *testing.T) {
+	t.Parallel()
+
+	resp, err := PensionScheme(schemeRequest(SchemeNetPay))
+	require.NoError(t, err)
+
+	// Tax is on pay after the contribution, but NI is on the full salary
+	assert.InDelta(t, 10232, resp.TaxPaid, 0.01)
+	assert.InDelta(t, 3210.60, resp.NationalInsurance, 0.01)
+	assert.InDelta(t, 7024.20, resp.EmployersNI, 0.01)
+	assert.Zero(t, resp.GrossSacrifice)
+	assert.InDelta(t, 60000-3000-10232-3210.60, resp.NetPay, 0.01)
+	assert.Zero(t, resp.PensionScheme.EmployeeNISaving)
+}
+
+func TestPensionScheme_ReliefAtSource(t *testing.T) {
+	t.Parallel()
+
+	resp, err := PensionScheme(schemeRequest(SchemeReliefAtSource))
+	require.NoError(t, err)
+
+	// PAYE is on the full salary, and 80% of the contribution comes from net pay
+	assert.InDelta(t, 11432, resp.TaxPaid, 0.01)
+	assert.InDelta(t, 3210.60, resp.NationalInsurance, 0.01)
+	assert.InDelta(t, 2400, resp.PensionYou, 0.01)
+	assert.InDelta(t, 600, resp.PensionHMRC, 0.01)
+	assert.InDelta(t, 60000-2400-11432-3210.60, resp.NetPay, 0.01)
+
+	// Higher rate relief on the £3,000 gross contribution is claimed back
+	assert.InDelta(t, 600, resp.PensionClaimback, 0.01)
+	assert.InDelta(t, 600, resp.PensionScheme.BasicRateTopUp, 0.01)
+	assert.InDelta(t, 600, resp.PensionScheme.HigherRateRelief, 0.01)
+}
+
+func TestPensionScheme_Extra(t *testing.T) {
+	t.Parallel()
+
+	// Extra income is taxed, but not charged NI
+	req := schemeRequest(SchemeNetPay)
+	req.Extra = 5000
+	resp, err := PensionScheme(req)
+	require.NoError(t, err)
+	assert.InDelta(t, 62000-12570, resp.TaxablePay, 0.01)
+	assert.InDelta(t, 12232, resp.TaxPaid, 0.01)
+	assert.InDelta(t, 3210.60, resp.NationalInsurance, 0.01)
+}
+
+func TestPensionScheme_StatePensionAge(t *testing.T) {
+	t.Parallel()
+
+	// No employee NI from State Pension age, but the employer still pays
+	req := schemeRequest(SchemeNetPay)
+	req.Age = "70"
+	resp, err := PensionScheme(req)
+	require.NoError(t, err)
+	assert.Zero(t, resp.NationalInsurance)
+	assert.InDelta(t, 7024.20, resp.EmployersNI, 0.01)
+	assert.InDelta(t, 60000-3000-10232, resp.NetPay, 0.01)
+
+	req.Age = "65"
+	resp, err = PensionScheme(req)
+	require.NoError(t, err)
+	assert.InDelta(t, 3210.60, resp.NationalInsurance, 0.01)
+}
+
+func TestPensionScheme_TaxCode(t *testing.T) {
+	t.Parallel()
+
+	req := schemeRequest(SchemeNetPay)
+	req.TaxCode = "1000L"
+	resp, err := PensionScheme(req)
+	require.NoError(t, err)
+	assert.InDelta(t, 10000, resp.TaxFreeAllowance, 0.01)
+	assert.Equal(t, "1000L", resp.TaxCode)
+
+	req.TaxCode = "BR"
+	_, err = PensionScheme(req)
+	assert.EqualError(t, err, "--pension-scheme needs a tax code with an allowance, got: BR")
+}
+
+func TestPensionScheme_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := PensionScheme(schemeRequest("stakeholder"))
+	assert.EqualError(t, err, "invalid pension scheme: stakeholder (must be one of: sacrifice, net-pay, relief-at-source)")
+
+	req := schemeRequest(SchemeSacrifice)
+	req.Pension = "70000"
+	_, err = PensionScheme(req)
+	assert.EqualError(t, err, "pension contribution 70000.00 is more than income 60000.00")
+}
+
+func TestApplyEmployerPension(t *testing.T) {
+	t.Parallel()
+
+	// From the API, the contribution is what you and HMRC pay in
+	req := testutil.CreateSampleTaxRequest(func(r *types.TaxRequest) {
+		r.EmployerPension = "5%"
+	})
+	resp := testutil.CreateSampleTaxResponse(func(r *types.TaxResponse) {
+		r.GrossPay = 60000
+		r.PensionYou = 2400
+		r.PensionHMRC = 600
+	})
+	require.NoError(t, ApplyEmployerPension(req, resp))
+	assert.InDelta(t, 3000, resp.PensionScheme.Employer, 0.01)
+	assert.InDelta(t, 600, resp.PensionScheme.BasicRateTopUp, 0.01)
+	assert.InDelta(t, 6000, resp.PensionScheme.Total, 0.01)
+
+	// Under a scheme, the employer adds to the scheme's contributions
+	schemeReq := schemeRequest(SchemeSacrifice)
+	schemeReq.EmployerPension = "4000"
+	schemeResp, err := PensionScheme(schemeReq)
+	require.NoError(t, err)
+	require.NoError(t, ApplyEmployerPension(schemeReq, schemeResp))
+	assert.InDelta(t, 4000, schemeResp.PensionScheme.Employer, 0.01)
+	assert.InDelta(t, 7000, schemeResp.PensionScheme.Total, 0.01)
+
+	req.EmployerPension = "lots"
+	assert.EqualError(t, ApplyEmployerPension(req, resp), "employer pension: invalid pension amount: lots")
+}
@@ -0,0 +1,186 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Threshold names that need more than the amount to explain
+const (
+	ThresholdTaper = "Personal Allowance Taper"
+)
+
+// PayRise analyses a raise from the before and after calculations: the share
+// of the raise reaching net pay, the marginal rate of tax, NI and student loan
+// across the gap, and the thresholds crossed. The extra pension contribution
+// that keeps take-home pay unchanged is solved locally under the request's
+// pension scheme, or salary sacrifice if it has none. req is the request for
+// the salary after the raise.
+func PayRise(req *types.TaxRequest, before, after *types.TaxResponse) (*types.PayRise, error) {
+	rates, err := RatesFor(req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	raise := after.GrossPay - before.GrossPay
+	if raise <= 0 {
+		return nil, fmt.Errorf("salary after the raise must be more than before")
+	}
+
+	deductions := func(r *types.TaxResponse) float64 {
+		return r.TaxPaid + r.NationalInsurance + r.StudentLoanRepayment
+	}
+	netIncrease := after.NetPay - before.NetPay
+
+	scheme := req.PensionScheme
+	if scheme == "" {
+		scheme = SchemeSacrifice
+	}
+	extra, err := ExtraPension(req, scheme, netIncrease)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PayRise{
+		TaxYear:       rates.Year,
+		From:          before.GrossPay,
+		To:            after.GrossPay,
+		Raise:         raise,
+		NetIncrease:   netIncrease,
+		Kept:          netIncrease / raise,
+		MarginalRate:  (deductions(after) - deductions(before)) / raise,
+		PensionScheme: scheme,
+		ExtraPension:  extra,
+		Thresholds:    rates.ThresholdsBetween(req.TaxRegion, req.Plan, before, after),
+		Before:        before,
+		After:         after,
+	}, nil
+}
+
+// ExtraPension finds the extra annual pension contribution, on top of the
+// request's pension, that reduces net pay by the given amount under a pension
+// scheme. Net pay is worked out locally, so the answer holds even if the API's
+// figures differ slightly from the local ones.
+func ExtraPension(req *types.TaxRequest, scheme string, netReduction float64) (float64, error) {
+	if err := ValidatePensionScheme(scheme); err != nil {
+		return 0, err
+	}
+	if netReduction <= 0 {
+		return 0, nil
+	}
+
+	gross := float64(req.GrossWage)
+	base, err := ParsePension(req.Pension, gross)
+	if err != nil {
+		return 0, err
+	}
+
+	netPay := func(extra float64) (float64, error) {
+		withPension := *req
+		withPension.PensionScheme = scheme
+		withPension.Pension = strconv.FormatFloat(base+extra, 'f', 2, 64)
+		resp, err := PensionScheme(&withPension)
+		if err != nil {
+			return 0, err
+		}
+		return resp.NetPay, nil
+	}
+
+	current, err := netPay(0)
+	if err != nil {
+		return 0, err
+	}
+	target := current - netReduction
+
+	// Net pay falls as the contribution rises, so search to the nearest penny
+	low, high := 0.0, gross-base
+	for high-low > 0.005 {
+		mid := (low + high) / 2
+		net, err := netPay(mid)
+		if err != nil {
+			return 0, err
+		}
+		if net > target {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return math.Round(high*100) / 100, nil
+}
+
+// ThresholdsBetween returns the thresholds crossed between two calculations:
+// the personal allowance, the start of each higher income tax band, the
+// personal allowance taper, the NI primary threshold and upper earnings limit,
+// and the student loan threshold for any plan. Tax thresholds apply to pay
+// after pension contributions under a pension scheme; NI and student loan
+// thresholds to pay after salary sacrifice.
+func (r *Rates) ThresholdsBetween(region, plan string, before, after *types.TaxResponse) []types.Threshold {
+	taxThresholds := []types.Threshold{{Name: "Personal Allowance", Amount: r.PersonalAllowance}}
+
+	bands := r.BandsFor(region)
+	for i := 1; i < len(bands); i++ {
+		if bands[i-1].Upper == 0 {
+			continue
+		}
+		name := bands[i].Name
+		if !strings.HasSuffix(name, "Rate") {
+			name += " Rate"
+		}
+		taxThresholds = append(taxThresholds, types.Threshold{Name: name + " Band", Amount: r.salaryForTaxable(bands[i-1].Upper)})
+	}
+	taxThresholds = append(taxThresholds, types.Threshold{Name: ThresholdTaper, Amount: r.TaperThreshold})
+
+	niThresholds := []types.Threshold{
+		{Name: "NI Primary Threshold", Amount: r.PrimaryThreshold},
+		{Name: "NI Upper Earnings Limit", Amount: r.UpperEarningsLimit},
+	}
+	if p, ok := r.StudentLoan(plan); ok {
+		niThresholds = append(niThresholds, types.Threshold{Name: "Student Loan Threshold", Amount: p.Threshold})
+	}
+
+	crossed := []types.Threshold{}
+	crossedBetween := func(thresholds []types.Threshold, from, to float64) {
+		for _, threshold := range thresholds {
+			if from < threshold.Amount && threshold.Amount <= to {
+				crossed = append(crossed, threshold)
+			}
+		}
+	}
+	crossedBetween(taxThresholds, taxablePay(before), taxablePay(after))
+	crossedBetween(niThresholds, before.GrossPay-before.GrossSacrifice, after.GrossPay-after.GrossSacrifice)
+	return crossed
+}
+
+// taxablePay returns pay less any contribution under a pension scheme, which
+// is the pay that tax thresholds apply to
+func taxablePay(resp *types.TaxResponse) float64 {
+	if resp.PensionScheme == nil || resp.PensionScheme.Scheme == "" {
+		return resp.GrossPay
+	}
+	return resp.GrossPay - resp.PensionScheme.Contribution
+}
+
+// taperEnd returns the salary at which the personal allowance is fully withdrawn
+func (r *Rates) taperEnd() float64 {
+	return r.TaperThreshold + 2*r.PersonalAllowance
+}
+
+// salaryForTaxable returns the salary at which taxable income reaches an
+// amount, allowing for the personal allowance taper
+func (r *Rates) salaryForTaxable(taxable float64) float64 {
+	salary := taxable + r.PersonalAllowance
+	if salary <= r.TaperThreshold {
+		return salary
+	}
+	// Within the taper, each £1 over the threshold removes 50p of allowance
+	salary = (taxable + r.PersonalAllowance + r.TaperThreshold/2) / 1.5
+	if salary >= r.taperEnd() {
+		return taxable
+	}
+	return salary
+}
@@ -0,0 +1,138 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// raiseResponses calculates pay before and after a raise under a pension scheme
+func raiseResponses(t *testing.T, from, to int, pension, scheme string) (*types.TaxRequest, *types.TaxResponse, *types.TaxResponse) {
+	t.Helper()
+
+	req := &types.TaxRequest{Year: "2025", TaxRegion: "uk", Plan: "plan2", Pension: pension, PensionScheme: scheme, GrossWage: from}
+	before, err := PensionScheme(req)
+	require.NoError(t, err)
+
+	raised := *req
+	raised.GrossWage = to
+	after, err := PensionScheme(&raised)
+	require.NoError(t, err)
+	return &raised, before, after
+}
+
+func TestPayRise(t *testing.T) {
+	t.Parallel()
+
+	req, before, after := raiseResponses(t, 48000, 52000, "0", SchemeSacrifice)
+	rise, err := PayRise(req, before, after)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2025, rise.TaxYear)
+	assert.InDelta(t, 4000.0, rise.Raise, 0.001)
+	assert.InDelta(t, 2277.80, rise.NetIncrease, 0.01)
+	assert.InDelta(t, 0.56945, rise.Kept, 0.00001)
+	assert.InDelta(t, 0.43055, rise.MarginalRate, 0.00001)
+	assert.Equal(t, SchemeSacrifice, rise.PensionScheme)
+
+	// Sacrificing the whole raise keeps take-home pay unchanged
+	assert.InDelta(t, 4000.0, rise.ExtraPension, 0.01)
+
+	assert.Equal(t, []types.Threshold{
+		{Name: "Higher Rate Band", Amount: 50270},
+		{Name: "NI Upper Earnings Limit", Amount: 50270},
+	}, rise.Thresholds)
+}
+
+func TestPayRise_NotARaise(t *testing.T) {
+	t.Parallel()
+
+	req, before, _ := raiseResponses(t, 48000, 52000, "0", SchemeSacrifice)
+	_, err := PayRise(req, before, before)
+	assert.EqualError(t, err, "salary after the raise must be more than before")
+}
+
+func TestExtraPension(t *testing.T) {
+	t.Parallel()
+
+	req := &types.TaxRequest{Year: "2025", TaxRegion: "uk", Plan: "plan2", Pension: "5%", GrossWage: 104500}
+
+	// A net pay contribution is only relieved of income tax, not student loan
+	extra, err := ExtraPension(req, SchemeNetPay, 4370)
+	require.NoError(t, err)
+	assert.InDelta(t, 7283.34, extra, 0.01)
+
+	extra, err = ExtraPension(req, SchemeNetPay, 0)
+	require.NoError(t, err)
+	assert.Zero(t, extra)
+
+	_, err = ExtraPension(req, "stakeholder", 100)
+	assert.ErrorContains(t, err, "invalid pension scheme")
+}
+
+func TestThresholdsBetween(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		region string
+		from   float64
+		to     float64
+		want   []string
+	}{
+		{"within a band", "uk", 30000, 35000, []string{}},
+		{"into tax", "uk", 12000, 13000, []string{"Personal Allowance", "NI Primary Threshold"}},
+		{"taper", "uk", 98000, 102000, []string{ThresholdTaper}},
+		{"additional rate", "uk", 120000, 130000, []string{"Additional Rate Band"}},
+		{"scottish intermediate", "scotland", 25000, 30000, []string{"Intermediate Rate Band", "Student Loan Threshold"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			before := &types.TaxResponse{GrossPay: tt.from}
+			after := &types.TaxResponse{GrossPay: tt.to}
+			names := []string{}
+			for _, threshold := range rates.ThresholdsBetween(tt.region, "plan2", before, after) {
+				names = append(names, threshold.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestThresholdsBetween_PensionScheme(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	// A net pay pension keeps adjusted income under the taper
+	before := &types.TaxResponse{GrossPay: 95000, PensionScheme: &types.PensionScheme{Scheme: SchemeNetPay, Contribution: 4750}}
+	after := &types.TaxResponse{GrossPay: 104500, PensionScheme: &types.PensionScheme{Scheme: SchemeNetPay, Contribution: 5225}}
+	assert.Empty(t, rates.ThresholdsBetween("uk", "", before, after))
+
+	after.PensionScheme.Scheme = ""
+	assert.Equal(t, []types.Threshold{{Name: ThresholdTaper, Amount: 100000}}, rates.ThresholdsBetween("uk", "", before, after))
+}
+
+func TestSalaryForTaxable(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	assert.InDelta(t, 50270.0, rates.salaryForTaxable(37700), 0.001)
+	assert.InDelta(t, 125140.0, rates.salaryForTaxable(125140), 0.001)
+
+	// Within the taper, a smaller allowance brings the band forward
+	taxable := 105000 - rates.AllowanceFor(105000)
+	assert.InDelta(t, 105000.0, rates.salaryForTaxable(taxable), 0.001)
+}
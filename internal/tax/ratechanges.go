@@ -0,0 +1,70 @@
+package tax
+
+import (
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Kinds of rate change
+const (
+	ChangeThreshold = "threshold"
+	ChangeRate      = "rate"
+)
+
+// RateChanges compares the allowances, thresholds and rates that apply to an
+// employee's salary in one tax year with another: the personal allowance, the
+// income tax bands for the region, National Insurance thresholds and rates,
+// and the student loan threshold for any plan.
+func RateChanges(fromYear, toYear, region, plan string) ([]types.RateChange, error) {
+	from, err := RatesFor(fromYear)
+	if err != nil {
+		return nil, err
+	}
+	to, err := RatesFor(toYear)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []types.RateChange{}
+	threshold := func(name string, from, to float64) {
+		changes = append(changes, types.RateChange{Name: name, Kind: ChangeThreshold, From: from, To: to})
+	}
+	rate := func(name string, from, to float64) {
+		if from != to {
+			changes = append(changes, types.RateChange{Name: name, Kind: ChangeRate, From: from, To: to})
+		}
+	}
+
+	threshold("Personal Allowance", from.PersonalAllowance, to.PersonalAllowance)
+
+	// Bands are matched by name, since Scottish bands have been added over time
+	fromBands := map[string]Band{}
+	for _, band := range from.BandsFor(region) {
+		fromBands[band.Name] = band
+	}
+	for _, band := range to.BandsFor(region) {
+		previous, ok := fromBands[band.Name]
+		if !ok {
+			continue
+		}
+		if band.Upper != 0 && previous.Upper != 0 {
+			threshold(band.Name+" Band Limit", previous.Upper, band.Upper)
+		}
+		rate(band.Name+" Rate", previous.Rate, band.Rate)
+	}
+
+	threshold("NI Primary Threshold", from.PrimaryThreshold, to.PrimaryThreshold)
+	threshold("NI Upper Earnings Limit", from.UpperEarningsLimit, to.UpperEarningsLimit)
+	rate("Employee NI Rate", from.EmployeeMainRate, to.EmployeeMainRate)
+	rate("Employee NI Upper Rate", from.EmployeeUpperRate, to.EmployeeUpperRate)
+	threshold("NI Secondary Threshold", from.SecondaryThreshold, to.SecondaryThreshold)
+	rate("Employer NI Rate", from.EmployerRate, to.EmployerRate)
+
+	fromPlan, fromOK := from.StudentLoan(plan)
+	toPlan, toOK := to.StudentLoan(plan)
+	if fromOK && toOK {
+		threshold("Student Loan Threshold", fromPlan.Threshold, toPlan.Threshold)
+		rate("Student Loan Rate", fromPlan.Rate, toPlan.Rate)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,53 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func TestRateChanges(t *testing.T) {
+	t.Parallel()
+
+	changes, err := RateChanges("2023", "2024", "uk", "plan2")
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.RateChange{
+		{Name: "Personal Allowance", Kind: ChangeThreshold, From: 12570, To: 12570},
+		{Name: "Basic Rate Band Limit", Kind: ChangeThreshold, From: 37700, To: 37700},
+		{Name: "Higher Rate Band Limit", Kind: ChangeThreshold, From: 125140, To: 125140},
+		{Name: "NI Primary Threshold", Kind: ChangeThreshold, From: 12570, To: 12570},
+		{Name: "NI Upper Earnings Limit", Kind: ChangeThreshold, From: 50270, To: 50270},
+		{Name: "Employee NI Rate", Kind: ChangeRate, From: 0.115, To: 0.08},
+		{Name: "NI Secondary Threshold", Kind: ChangeThreshold, From: 9100, To: 9100},
+		{Name: "Student Loan Threshold", Kind: ChangeThreshold, From: 27295, To: 27295},
+	}, changes)
+}
+
+func TestRateChanges_Employer(t *testing.T) {
+	t.Parallel()
+
+	changes, err := RateChanges("2024", "2025", "uk", "")
+	require.NoError(t, err)
+	assert.Contains(t, changes, types.RateChange{Name: "NI Secondary Threshold", Kind: ChangeThreshold, From: 9100, To: 5000})
+	assert.Contains(t, changes, types.RateChange{Name: "Employer NI Rate", Kind: ChangeRate, From: 0.138, To: 0.15})
+}
+
+func TestRateChanges_ScottishBands(t *testing.T) {
+	t.Parallel()
+
+	changes, err := RateChanges("2025", "2026", "scotland", "")
+	require.NoError(t, err)
+	assert.Contains(t, changes, types.RateChange{Name: "Starter Rate Band Limit", Kind: ChangeThreshold, From: 2827, To: 3967})
+	assert.Contains(t, changes, types.RateChange{Name: "Intermediate Band Limit", Kind: ChangeThreshold, From: 31092, To: 31092})
+}
+
+func TestRateChanges_UnsupportedYear(t *testing.T) {
+	t.Parallel()
+
+	_, err := RateChanges("2010", "2011", "uk", "")
+	assert.ErrorContains(t, err, "no local tax rates for 2010")
+}
@@ -0,0 +1,328 @@
+// Package tax provides local UK tax calculations for scenarios the
+// listentotaxman.com API does not cover.
+package tax
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Regions with their own tax code prefix
+const (
+	regionScotland = "scotland"
+	regionWales    = "wales"
+)
+
+// Band is an income tax band, expressed as the upper limit of taxable income
+// (income after the personal allowance). An Upper of 0 means the band is unbounded.
+type Band struct {
+	Name  string  `json:"name"`
+	Rate  float64 `json:"rate"`
+	Upper float64 `json:"upper"`
+}
+
+// StudentLoanPlan holds the repayment threshold and rate for a student loan plan
+type StudentLoanPlan struct {
+	Threshold float64 `json:"threshold"`
+	Rate      float64 `json:"rate"`
+}
+
+// Rates holds the thresholds and rates for a single tax year
+type Rates struct {
+	Year int `json:"year"`
+
+	// Income tax
+	PersonalAllowance float64 `json:"personal_allowance"`
+	TaperThreshold    float64 `json:"taper_threshold"`
+	BlindAllowance    float64 `json:"blind_allowance"`
+	MarriageAllowance float64 `json:"marriage_allowance"`
+	UKBands           []Band  `json:"uk_bands"`
+	ScottishBands     []Band  `json:"scottish_bands"`
+
+	// Class 1 (employee) National Insurance
+	PrimaryThreshold   float64 `json:"primary_threshold"`
+	UpperEarningsLimit float64 `json:"upper_earnings_limit"`
+	EmployeeMainRate   float64 `json:"employee_main_rate"`
+	EmployeeUpperRate  float64 `json:"employee_upper_rate"`
+
+	// Class 1 (employer) National Insurance
+	SecondaryThreshold  float64 `json:"secondary_threshold"`
+	EmployerRate        float64 `json:"employer_rate"`
+	EmploymentAllowance float64 `json:"employment_allowance"`
+
+	// Class 2 and Class 4 (self-employed) National Insurance
+	Class2Weekly    float64 `json:"class2_weekly"`
+	Class2Threshold float64 `json:"class2_threshold"`
+	Class4Lower     float64 `json:"class4_lower"`
+	Class4Upper     float64 `json:"class4_upper"`
+	Class4MainRate  float64 `json:"class4_main_rate"`
+	Class4UpperRate float64 `json:"class4_upper_rate"`
+
+	// Savings and dividends
+	StartingRateForSavings float64    `json:"starting_rate_for_savings"`
+	SavingsAllowanceBasic  float64    `json:"savings_allowance_basic"`
+	SavingsAllowanceHigher float64    `json:"savings_allowance_higher"`
+	DividendAllowance      float64    `json:"dividend_allowance"`
+	DividendRates          [3]float64 `json:"dividend_rates"`
+
+	StudentLoans map[string]StudentLoanPlan `json:"student_loans"`
+}
+
+// ukBands returns the England, Wales and Northern Ireland bands
+func ukBands(basicUpper, higherUpper, additionalRate float64) []Band {
+	return []Band{
+		{Name: "Basic Rate", Rate: 0.20, Upper: basicUpper},
+		{Name: "Higher Rate", Rate: 0.40, Upper: higherUpper},
+		{Name: "Additional", Rate: additionalRate},
+	}
+}
+
+// ratesByYear holds the rates for each supported tax year, keyed by the
+// calendar year in which the tax year starts (2025 is 2025/26).
+var ratesByYear = map[int]*Rates{
+	2022: {
+		Year:              2022,
+		PersonalAllowance: 12570,
+		TaperThreshold:    100000,
+		BlindAllowance:    2600,
+		MarriageAllowance: 1260,
+		UKBands:           ukBands(37700, 150000, 0.45),
+		ScottishBands: []Band{
+			{Name: "Starter Rate", Rate: 0.19, Upper: 2162},
+			{Name: "Basic Rate", Rate: 0.20, Upper: 13118},
+			{Name: "Intermediate", Rate: 0.21, Upper: 31092},
+			{Name: "Higher Rate", Rate: 0.41, Upper: 150000},
+			{Name: "Top Rate", Rate: 0.46},
+		},
+		// Annualised figures reflecting the mid-year changes
+		PrimaryThreshold:       11908,
+		UpperEarningsLimit:     50270,
+		EmployeeMainRate:       0.1273,
+		EmployeeUpperRate:      0.0273,
+		SecondaryThreshold:     9100,
+		EmployerRate:           0.1453,
+		EmploymentAllowance:    5000,
+		Class2Weekly:           3.15,
+		Class2Threshold:        11908,
+		Class4Lower:            11908,
+		Class4Upper:            50270,
+		Class4MainRate:         0.0973,
+		Class4UpperRate:        0.0273,
+		StartingRateForSavings: 5000,
+		SavingsAllowanceBasic:  1000,
+		SavingsAllowanceHigher: 500,
+		DividendAllowance:      2000,
+		DividendRates:          [3]float64{0.0875, 0.3375, 0.3935},
+		StudentLoans: map[string]StudentLoanPlan{
+			"plan1":        {Threshold: 20195, Rate: 0.09},
+			"plan2":        {Threshold: 27295, Rate: 0.09},
+			"plan4":        {Threshold: 25375, Rate: 0.09},
+			"postgraduate": {Threshold: 21000, Rate: 0.06},
+		},
+	},
+	2023: {
+		Year:              2023,
+		PersonalAllowance: 12570,
+		TaperThreshold:    100000,
+		BlindAllowance:    2870,
+		MarriageAllowance: 1260,
+		UKBands:           ukBands(37700, 125140, 0.45),
+		ScottishBands: []Band{
+			{Name: "Starter Rate", Rate: 0.19, Upper: 2162},
+			{Name: "Basic Rate", Rate: 0.20, Upper: 13118},
+			{Name: "Intermediate", Rate: 0.21, Upper: 31092},
+			{Name: "Higher Rate", Rate: 0.42, Upper: 125140},
+			{Name: "Top Rate", Rate: 0.47},
+		},
+		// Annualised figures reflecting the January 2024 rate cut
+		PrimaryThreshold:       12570,
+		UpperEarningsLimit:     50270,
+		EmployeeMainRate:       0.115,
+		EmployeeUpperRate:      0.02,
+		SecondaryThreshold:     9100,
+		EmployerRate:           0.138,
+		EmploymentAllowance:    5000,
+		Class2Weekly:           3.45,
+		Class2Threshold:        12570,
+		Class4Lower:            12570,
+		Class4Upper:            50270,
+		Class4MainRate:         0.09,
+		Class4UpperRate:        0.02,
+		StartingRateForSavings: 5000,
+		SavingsAllowanceBasic:  1000,
+		SavingsAllowanceHigher: 500,
+		DividendAllowance:      1000,
+		DividendRates:          [3]float64{0.0875, 0.3375, 0.3935},
+		StudentLoans: map[string]StudentLoanPlan{
+			"plan1":        {Threshold: 22015, Rate: 0.09},
+			"plan2":        {Threshold: 27295, Rate: 0.09},
+			"plan4":        {Threshold: 27660, Rate: 0.09},
+			"postgraduate": {Threshold: 21000, Rate: 0.06},
+		},
+	},
+	2024: {
+		Year:              2024,
+		PersonalAllowance: 12570,
+		TaperThreshold:    100000,
+		BlindAllowance:    3070,
+		MarriageAllowance: 1260,
+		UKBands:           ukBands(37700, 125140, 0.45),
+		ScottishBands: []Band{
+			{Name: "Starter Rate", Rate: 0.19, Upper: 2306},
+			{Name: "Basic Rate", Rate: 0.20, Upper: 13991},
+			{Name: "Intermediate", Rate: 0.21, Upper: 31092},
+			{Name: "Higher Rate", Rate: 0.42, Upper: 62430},
+			{Name: "Advanced Rate", Rate: 0.45, Upper: 125140},
+			{Name: "Top Rate", Rate: 0.48},
+		},
+		PrimaryThreshold:    12570,
+		UpperEarningsLimit:  50270,
+		EmployeeMainRate:    0.08,
+		EmployeeUpperRate:   0.02,
+		SecondaryThreshold:  9100,
+		EmployerRate:        0.138,
+		EmploymentAllowance: 5000,
+		// Class 2 is treated as paid above the threshold from 2024/25
+		Class2Weekly:           0,
+		Class2Threshold:        12570,
+		Class4Lower:            12570,
+		Class4Upper:            50270,
+		Class4MainRate:         0.06,
+		Class4UpperRate:        0.02,
+		StartingRateForSavings: 5000,
+		SavingsAllowanceBasic:  1000,
+		SavingsAllowanceHigher: 500,
+		DividendAllowance:      500,
+		DividendRates:          [3]float64{0.0875, 0.3375, 0.3935},
+		StudentLoans: map[string]StudentLoanPlan{
+			"plan1":        {Threshold: 24990, Rate: 0.09},
+			"plan2":        {Threshold: 27295, Rate: 0.09},
+			"plan4":        {Threshold: 31395, Rate: 0.09},
+			"postgraduate": {Threshold: 21000, Rate: 0.06},
+		},
+	},
+	2025: {
+		Year:              2025,
+		PersonalAllowance: 12570,
+		TaperThreshold:    100000,
+		BlindAllowance:    3130,
+		MarriageAllowance: 1260,
+		UKBands:           ukBands(37700, 125140, 0.45),
+		ScottishBands: []Band{
+			{Name: "Starter Rate", Rate: 0.19, Upper: 2827},
+			{Name: "Basic Rate", Rate: 0.20, Upper: 14921},
+			{Name: "Intermediate", Rate: 0.21, Upper: 31092},
+			{Name: "Higher Rate", Rate: 0.42, Upper: 62430},
+			{Name: "Advanced Rate", Rate: 0.45, Upper: 125140},
+			{Name: "Top Rate", Rate: 0.48},
+		},
+		PrimaryThreshold:       12570,
+		UpperEarningsLimit:     50270,
+		EmployeeMainRate:       0.08,
+		EmployeeUpperRate:      0.02,
+		SecondaryThreshold:     5000,
+		EmployerRate:           0.15,
+		EmploymentAllowance:    10500,
+		Class2Weekly:           0,
+		Class2Threshold:        12570,
+		Class4Lower:            12570,
+		Class4Upper:            50270,
+		Class4MainRate:         0.06,
+		Class4UpperRate:        0.02,
+		StartingRateForSavings: 5000,
+		SavingsAllowanceBasic:  1000,
+		SavingsAllowanceHigher: 500,
+		DividendAllowance:      500,
+		DividendRates:          [3]float64{0.0875, 0.3375, 0.3935},
+		StudentLoans: map[string]StudentLoanPlan{
+			"plan1":        {Threshold: 26065, Rate: 0.09},
+			"plan2":        {Threshold: 28470, Rate: 0.09},
+			"plan4":        {Threshold: 32745, Rate: 0.09},
+			"postgraduate": {Threshold: 21000, Rate: 0.06},
+		},
+	},
+	2026: {
+		Year:              2026,
+		PersonalAllowance: 12570,
+		TaperThreshold:    100000,
+		BlindAllowance:    3250,
+		MarriageAllowance: 1260,
+		UKBands:           ukBands(37700, 125140, 0.45),
+		ScottishBands: []Band{
+			{Name: "Starter Rate", Rate: 0.19, Upper: 3967},
+			{Name: "Basic Rate", Rate: 0.20, Upper: 16956},
+			{Name: "Intermediate", Rate: 0.21, Upper: 31092},
+			{Name: "Higher Rate", Rate: 0.42, Upper: 62430},
+			{Name: "Advanced Rate", Rate: 0.45, Upper: 125140},
+			{Name: "Top Rate", Rate: 0.48},
+		},
+		PrimaryThreshold:       12570,
+		UpperEarningsLimit:     50270,
+		EmployeeMainRate:       0.08,
+		EmployeeUpperRate:      0.02,
+		SecondaryThreshold:     5000,
+		EmployerRate:           0.15,
+		EmploymentAllowance:    10500,
+		Class2Weekly:           0,
+		Class2Threshold:        12570,
+		Class4Lower:            12570,
+		Class4Upper:            50270,
+		Class4MainRate:         0.06,
+		Class4UpperRate:        0.02,
+		StartingRateForSavings: 5000,
+		SavingsAllowanceBasic:  1000,
+		SavingsAllowanceHigher: 500,
+		DividendAllowance:      500,
+		DividendRates:          [3]float64{0.1075, 0.3575, 0.3935},
+		StudentLoans: map[string]StudentLoanPlan{
+			"plan1":        {Threshold: 26900, Rate: 0.09},
+			"plan2":        {Threshold: 29385, Rate: 0.09},
+			"plan4":        {Threshold: 33795, Rate: 0.09},
+			"postgraduate": {Threshold: 21000, Rate: 0.06},
+		},
+	},
+}
+
+// SupportedYears returns the tax years with local rate tables, in ascending order
+func SupportedYears() []int {
+	years := make([]int, 0, len(ratesByYear))
+	for year := range ratesByYear {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// RatesFor returns the rates for the given tax year (e.g. "2025" for 2025/26)
+func RatesFor(year string) (*Rates, error) {
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return nil, fmt.Errorf("year must be a valid number: %s", year)
+	}
+
+	rates, ok := ratesByYear[y]
+	if !ok {
+		years := SupportedYears()
+		return nil, fmt.Errorf("no local tax rates for %d (supported: %d-%d)", y, years[0], years[len(years)-1])
+	}
+
+	return rates, nil
+}
+
+// BandsFor returns the income tax bands for a region
+func (r *Rates) BandsFor(region string) []Band {
+	if region == regionScotland {
+		return r.ScottishBands
+	}
+	return r.UKBands
+}
+
+// StudentLoan returns the repayment plan, treating "scottish" as plan 4
+func (r *Rates) StudentLoan(plan string) (StudentLoanPlan, bool) {
+	if plan == "scottish" {
+		plan = "plan4"
+	}
+	p, ok := r.StudentLoans[plan]
+	return p, ok
+}
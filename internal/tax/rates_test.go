@@ -0,0 +1,75 @@
+package tax
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatesFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		year    string
+		wantErr string
+	}{
+		{"2022", "2022", ""},
+		{"2025", "2025", ""},
+		{"2026", "2026", ""},
+		{"unsupported", "2010", "no local tax rates for 2010"},
+		{"not a number", "abcd", "year must be a valid number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rates, err := RatesFor(tt.year)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.year, strconv.Itoa(rates.Year))
+		})
+	}
+}
+
+func TestSupportedYears(t *testing.T) {
+	t.Parallel()
+
+	years := SupportedYears()
+	require.NotEmpty(t, years)
+	assert.Equal(t, 2022, years[0])
+	assert.IsIncreasing(t, years)
+}
+
+func TestBandsFor(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	assert.Len(t, rates.BandsFor("uk"), 3)
+	assert.Len(t, rates.BandsFor("wales"), 3)
+	assert.Len(t, rates.BandsFor("scotland"), 6)
+}
+
+func TestStudentLoan(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	plan4, ok := rates.StudentLoan("plan4")
+	require.True(t, ok)
+	scottish, ok := rates.StudentLoan("scottish")
+	require.True(t, ok)
+	assert.Equal(t, plan4, scottish)
+
+	_, ok = rates.StudentLoan("plan9")
+	assert.False(t, ok)
+}
@@ -0,0 +1,384 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/mheap/listentotaxman-cli/internal/payperiod"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// Deductions compared when reconciling payslips
+const (
+	FieldTax         = "Tax"
+	FieldNI          = "NI"
+	FieldStudentLoan = "Student Loan"
+	FieldPension     = "Pension"
+)
+
+// impliedAllowanceRange bounds the search for the allowance implied by tax paid
+const impliedAllowanceRange = 100000.0
+
+// PeriodForDate returns the tax year and pay period a payment date falls in.
+// The tax year starts on 6 April, so month 1 runs from 6 April to 5 May, and
+// a weekly, fortnightly or four-weekly pay day on 4 or 5 April falls in the
+// extra period (e.g. week 53).
+func PeriodForDate(date time.Time, frequency string) (year, period int, err error) {
+	periods, err := PeriodsPerYear(frequency)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Date(date.Year(), time.April, 6, 0, 0, 0, 0, time.UTC)
+	if date.Before(start) {
+		start = start.AddDate(-1, 0, 0)
+	}
+
+	if frequency == FrequencyMonthly {
+		months := (date.Year()-start.Year())*12 + int(date.Month()) - int(time.April)
+		if date.Day() < 6 {
+			months--
+		}
+		return start.Year(), months + 1, nil
+	}
+
+	days := int(date.Sub(start).Hours() / 24)
+	period = days/(364/periods) + 1
+	if period > payperiod.PayDays(start.Year(), payperiod.PayDayInterval(frequency), date) {
+		return 0, 0, fmt.Errorf("%s falls in %s period %d, which is not supported", date.Format("2006-01-02"), frequency, period)
+	}
+	return start.Year(), period, nil
+}
+
+// Reconcile compares payslips from payroll with the deductions expected for the
+// same gross pay under the request's tax code, student loan plan and NI status,
+// and suggests likely causes for any differences above the tolerance. Actual
+// payslips must have their period set. When the request has no pension, the
+// pension on each payslip is used for the expected figures. Deductions in
+// missing, such as FieldNI, are not on the payslips so are not compared.
+func Reconcile(req *types.TaxRequest, frequency string, actual []types.Payslip, tolerance float64, missing []string) (*types.Reconciliation, error) {
+	rates, err := RatesFor(req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := PeriodsPerYear(frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := ParseTaxCode(rates.taxCodeOrDefault(req.TaxCode))
+	if err != nil {
+		return nil, err
+	}
+
+	actual = append([]types.Payslip(nil), actual...)
+	sort.SliceStable(actual, func(i, j int) bool { return actual[i].Period < actual[j].Period })
+
+	payDays := payDaysFor(rates.Year, frequency, periods, actual)
+	pay := make([]PeriodPay, len(actual))
+	for i, slip := range actual {
+		if slip.Period < 1 || slip.Period > payDays {
+			return nil, fmt.Errorf("payslip period %d is outside 1-%d for %s pay", slip.Period, payDays, frequency)
+		}
+		if i > 0 && slip.Period == actual[i-1].Period {
+			return nil, fmt.Errorf("more than one payslip in period %d", slip.Period)
+		}
+
+		pension := slip.Pension
+		if req.Pension != "" {
+			if pension, err = periodPension(req.Pension, slip.GrossPay, periods); err != nil {
+				return nil, err
+			}
+		}
+		pay[i] = PeriodPay{Period: slip.Period, Date: slip.Date, Gross: slip.GrossPay, Pension: pension, TaxCode: code}
+	}
+
+	expected, err := rates.SimulatePayslips(req, periods, pay)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &types.Reconciliation{
+		TaxYear:   rates.Year,
+		Frequency: frequency,
+		TaxCode:   code.String(),
+		Tolerance: tolerance,
+		Payslips:  make([]types.ReconciledPayslip, len(actual)),
+		Missing:   missing,
+	}
+	for i := range actual {
+		rec.Payslips[i] = types.ReconciledPayslip{
+			Actual:      actual[i],
+			Expected:    expected[i],
+			Differences: compareDeductions(actual[i], expected[i], tolerance, req.Pension != "", missing),
+		}
+	}
+
+	check := &reconcileCheck{rates: rates, req: req, periods: periods, actual: actual, pay: pay, tolerance: tolerance}
+	rec.Findings = append(check.diagnose(differenceCounts(rec.Payslips), code), uncheckedFindings(req, missing)...)
+
+	return rec, nil
+}
+
+// payDaysFor returns the pay periods payslips can fall in: the usual number,
+// or one more when a dated payslip's pay day cycle has an extra pay day in the
+// tax year
+func payDaysFor(year int, frequency string, periods int, actual []types.Payslip) int {
+	interval := payperiod.PayDayInterval(frequency)
+	if interval == 0 {
+		return periods
+	}
+	for _, slip := range actual {
+		if date, err := time.Parse("2006-01-02", slip.Date); err == nil {
+			return max(periods, payperiod.PayDays(year, interval, date))
+		}
+	}
+	return periods
+}
+
+// compareDeductions returns each deduction that differs by more than the
+// tolerance, leaving out those missing from the payslips
+func compareDeductions(actual, expected types.Payslip, tolerance float64, comparePension bool, missing []string) []types.PayslipDifference {
+	fields := []types.PayslipDifference{
+		{Field: FieldTax, Actual: actual.TaxPaid, Expected: expected.TaxPaid},
+		{Field: FieldNI, Actual: actual.NationalInsurance, Expected: expected.NationalInsurance},
+		{Field: FieldStudentLoan, Actual: actual.StudentLoanRepayment, Expected: expected.StudentLoanRepayment},
+	}
+	if comparePension {
+		fields = append(fields, types.PayslipDifference{Field: FieldPension, Actual: actual.Pension, Expected: expected.Pension})
+	}
+
+	var differences []types.PayslipDifference
+	for _, field := range fields {
+		if slices.Contains(missing, field.Field) {
+			continue
+		}
+		if math.Abs(field.Actual-field.Expected) > tolerance {
+			differences = append(differences, field)
+		}
+	}
+	return differences
+}
+
+// uncheckedFindings returns a finding for each deduction that would be
+// checked but is missing from the payslips
+func uncheckedFindings(req *types.TaxRequest, missing []string) []string {
+	findings := []string{}
+	for _, field := range missing {
+		switch {
+		case field == FieldStudentLoan && req.Plan == "":
+		case field == FieldPension && req.Pension == "":
+		default:
+			findings = append(findings, fmt.Sprintf("%s was not checked, as the payslips have no column for it", field))
+		}
+	}
+	return findings
+}
+
+// differenceCounts returns the number of payslips where each deduction differs
+func differenceCounts(payslips []types.ReconciledPayslip) map[string]int {
+	counts := make(map[string]int)
+	for _, payslip := range payslips {
+		for _, difference := range payslip.Differences {
+			counts[difference.Field]++
+		}
+	}
+	return counts
+}
+
+// reconcileCheck re-runs the payslips under alternative settings to find the
+// most likely cause of a difference
+type reconcileCheck struct {
+	rates     *Rates
+	req       *types.TaxRequest
+	periods   int
+	actual    []types.Payslip
+	pay       []PeriodPay
+	tolerance float64
+}
+
+// diagnose returns a finding for each deduction that differs
+func (c *reconcileCheck) diagnose(counts map[string]int, code *TaxCode) []string {
+	findings := []string{}
+	if counts[FieldTax] > 0 {
+		findings = append(findings, c.diagnoseTax(code, counts[FieldTax]))
+	}
+	if counts[FieldNI] > 0 {
+		findings = append(findings, c.diagnoseNI(counts[FieldNI]))
+	}
+	if counts[FieldStudentLoan] > 0 {
+		findings = append(findings, c.diagnoseStudentLoan(counts[FieldStudentLoan]))
+	}
+	if counts[FieldPension] > 0 {
+		findings = append(findings, fmt.Sprintf("Pension differs in %d payslips: check the contribution rate and whether it is worked out on qualifying earnings only", counts[FieldPension]))
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, fmt.Sprintf("All %d payslips match the expected deductions within £%.2f", len(c.actual), c.tolerance))
+	}
+	return findings
+}
+
+// diagnoseTax looks for an emergency basis or a different tax code that explains the tax deducted
+func (c *reconcileCheck) diagnoseTax(code *TaxCode, count int) string {
+	// An emergency code that was never reset to cumulative, or the reverse
+	alternative := *code
+	if code.Cumulative() {
+		alternative.Basis = BasisWeek1
+		if c.periods == 12 {
+			alternative.Basis = BasisMonth1
+		}
+		if c.taxMatches(c.req, &alternative) {
+			return fmt.Sprintf("Tax matches %s in every payslip: the emergency %s basis was never reset to cumulative, so allowance unused earlier in the year has not been given. Ask your employer or HMRC to remove it",
+				alternative.String(), alternative.Basis)
+		}
+	} else {
+		alternative.Basis = ""
+		if c.taxMatches(c.req, &alternative) {
+			return fmt.Sprintf("Tax was worked out cumulatively on %s, not on the %s basis", alternative.Code, code.Basis)
+		}
+	}
+
+	// A different tax code
+	for _, candidate := range c.candidateCodes(code) {
+		parsed, err := ParseTaxCode(candidate)
+		if err == nil && parsed.Code != code.Code && c.taxMatches(c.req, parsed) {
+			return fmt.Sprintf("Tax matches tax code %s rather than %s: check the code on your payslips against your HMRC personal tax account", parsed.String(), code.String())
+		}
+	}
+
+	return fmt.Sprintf("Tax differs in %d payslips and no single tax code explains it: check for taxable benefits, bonuses or a code change during the year", count)
+}
+
+// diagnoseNI explains National Insurance differences
+func (c *reconcileCheck) diagnoseNI(count int) string {
+	for _, slip := range c.actual {
+		if slip.NationalInsurance != 0 {
+			return fmt.Sprintf("National Insurance differs in %d payslips: check your NI category letter and whether you are paid as a director, whose NI is worked out on an annual basis", count)
+		}
+	}
+	return "No National Insurance was deducted: check your NI category letter (C is used over State Pension age), or use --no-ni if you are exempt"
+}
+
+// diagnoseStudentLoan looks for a student loan plan that explains the deductions
+func (c *reconcileCheck) diagnoseStudentLoan(count int) string {
+	deducted := false
+	for _, slip := range c.actual {
+		deducted = deducted || slip.StudentLoanRepayment != 0
+	}
+	if !deducted {
+		return fmt.Sprintf("No student loan was deducted on %s: check your employer has received a start notice for your loan", c.req.Plan)
+	}
+
+	for _, plan := range []string{"plan1", "plan2", "plan4", "postgraduate"} {
+		if plan == c.req.Plan {
+			continue
+		}
+
+		req := *c.req
+		req.Plan = plan
+		if c.studentLoanMatches(&req) {
+			if c.req.Plan == "" {
+				return fmt.Sprintf("Student loan deductions match %s: add --student-loan %s", plan, plan)
+			}
+			return fmt.Sprintf("Student loan deductions match %s rather than %s: your employer may have the wrong plan type", plan, c.req.Plan)
+		}
+	}
+
+	return fmt.Sprintf("Student loan deductions differ in %d payslips: check your plan type and repayment threshold", count)
+}
+
+// taxMatches reports whether tax under a code matches every payslip. A code
+// with no band in the tax year matches nothing
+func (c *reconcileCheck) taxMatches(req *types.TaxRequest, code *TaxCode) bool {
+	slips, err := c.simulate(req, code)
+	if err != nil {
+		return false
+	}
+	for i, slip := range slips {
+		if math.Abs(slip.TaxPaid-c.actual[i].TaxPaid) > c.tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// studentLoanMatches reports whether student loan deductions match every payslip
+func (c *reconcileCheck) studentLoanMatches(req *types.TaxRequest) bool {
+	slips, err := c.simulate(req, c.pay[0].TaxCode)
+	if err != nil {
+		return false
+	}
+	for i, slip := range slips {
+		if math.Abs(slip.StudentLoanRepayment-c.actual[i].StudentLoanRepayment) > c.tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// simulate works out the payslips for the same pay under a different tax code
+func (c *reconcileCheck) simulate(req *types.TaxRequest, code *TaxCode) ([]types.Payslip, error) {
+	pay := make([]PeriodPay, len(c.pay))
+	for i, p := range c.pay {
+		p.TaxCode = code
+		pay[i] = p
+	}
+	return c.rates.SimulatePayslips(req, c.periods, pay)
+}
+
+// candidateCodes returns common wrong tax codes and the code implied by the
+// total tax deducted, keeping the region prefix
+func (c *reconcileCheck) candidateCodes(code *TaxCode) []string {
+	prefix := regionPrefix(code.Region)
+	candidates := []string{}
+	for _, candidate := range []string{"BR", "0T", "D0", "NT"} {
+		candidates = append(candidates, prefix+candidate)
+	}
+	return append(candidates, prefix+c.impliedTaxCode(code))
+}
+
+// impliedTaxCode finds the cumulative code whose tax to date matches the total
+// tax actually deducted
+func (c *reconcileCheck) impliedTaxCode(code *TaxCode) string {
+	target := 0.0
+	for _, slip := range c.actual {
+		target += slip.TaxPaid
+	}
+
+	low, high := -impliedAllowanceRange, impliedAllowanceRange
+	for i := 0; i < 60; i++ {
+		mid := (low + high) / 2
+		slips, err := c.simulate(c.req, &TaxCode{Code: code.Code, Region: code.Region, Allowance: mid})
+		if err != nil {
+			break
+		}
+		if slips[len(slips)-1].TaxPaidToDate > target {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	allowance := int(math.Round(high / 10))
+	if allowance < 0 {
+		return fmt.Sprintf("K%d", -allowance)
+	}
+	return fmt.Sprintf("%dL", allowance)
+}
+
+// regionPrefix returns the tax code prefix for a region
+func regionPrefix(region string) string {
+	switch region {
+	case regionScotland:
+		return "S"
+	case regionWales:
+		return "C"
+	default:
+		return ""
+	}
+}
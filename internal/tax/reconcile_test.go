@@ -0,0 +1,267 @@
+package tax
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// payrollSlips simulates the payslips payroll would produce for a request
+func payrollSlips(t *testing.T, req *types.TaxRequest, changes ...types.SalaryChange) []types.Payslip {
+	t.Helper()
+
+	slips, err := Payslips(req, FrequencyMonthly, changes)
+	require.NoError(t, err)
+	return slips
+}
+
+func TestPeriodForDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		date      string
+		frequency string
+		year      int
+		period    int
+	}{
+		{"2025-04-25", FrequencyMonthly, 2025, 1},
+		{"2025-05-05", FrequencyMonthly, 2025, 1},
+		{"2025-05-06", FrequencyMonthly, 2025, 2},
+		{"2026-03-31", FrequencyMonthly, 2025, 12},
+		{"2025-04-05", FrequencyMonthly, 2024, 12},
+		{"2025-04-06", FrequencyWeekly, 2025, 1},
+		{"2025-04-12", FrequencyWeekly, 2025, 1},
+		{"2025-04-13", FrequencyWeekly, 2025, 2},
+		{"2025-05-03", FrequencyFourWeekly, 2025, 1},
+		{"2025-05-04", FrequencyFourWeekly, 2025, 2},
+	}
+
+	for _, tt := range tests {
+		date, err := time.Parse("2006-01-02", tt.date)
+		require.NoError(t, err)
+
+		year, period, err := PeriodForDate(date, tt.frequency)
+		require.NoError(t, err)
+		assert.Equal(t, tt.year, year, tt.date)
+		assert.Equal(t, tt.period, period, tt.date)
+	}
+}
+
+func TestPeriodForDate_ExtraPayDay(t *testing.T) {
+	t.Parallel()
+
+	// A pay day on 4 or 5 April falls in week 53, fortnight 27 or period 14
+	date := time.Date(2026, time.April, 5, 0, 0, 0, 0, time.UTC)
+	for frequency, want := range map[string]int{FrequencyWeekly: 53, FrequencyFortnightly: 27, FrequencyFourWeekly: 14} {
+		year, period, err := PeriodForDate(date, frequency)
+		require.NoError(t, err)
+		assert.Equal(t, 2025, year)
+		assert.Equal(t, want, period, frequency)
+	}
+}
+
+func TestPeriodForDate_Errors(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2026, time.April, 5, 0, 0, 0, 0, time.UTC)
+	_, _, err := PeriodForDate(date, "daily")
+	assert.ErrorContains(t, err, "invalid frequency: daily")
+}
+
+func TestReconcile_AllMatch(t *testing.T) {
+	t.Parallel()
+
+	req := payslipsRequest()
+	actual := payrollSlips(t, req)
+
+	rec, err := Reconcile(req, FrequencyMonthly, actual, 1, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2025, rec.TaxYear)
+	assert.Equal(t, "1257L", rec.TaxCode)
+	require.Len(t, rec.Payslips, 12)
+	for _, payslip := range rec.Payslips {
+		assert.Empty(t, payslip.Differences)
+	}
+	assert.Equal(t, []string{"All 12 payslips match the expected deductions within £1.00"}, rec.Findings)
+}
+
+func TestReconcile_Week53(t *testing.T) {
+	t.Parallel()
+
+	// Paid every Sunday from 6 April 2025, so the 53rd pay day is 5 April 2026
+	req := payslipsRequest(func(r *types.TaxRequest) { r.WorkPattern.PayDays = 53 })
+	actual, err := Payslips(req, FrequencyWeekly, nil)
+	require.NoError(t, err)
+	require.Len(t, actual, 53)
+	start := time.Date(2025, time.April, 6, 0, 0, 0, 0, time.UTC)
+	for i := range actual {
+		actual[i].Date = start.AddDate(0, 0, 7*i).Format("2006-01-02")
+	}
+	assert.Equal(t, "2026-04-05", actual[52].Date)
+
+	rec, err := Reconcile(payslipsRequest(), FrequencyWeekly, actual, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, rec.Payslips, 53)
+	assert.Equal(t, []string{"All 53 payslips match the expected deductions within £1.00"}, rec.Findings)
+}
+
+func TestReconcile_EmergencyBasisNeverReset(t *testing.T) {
+	t.Parallel()
+
+	// Low pay then a rise means M1 pays more tax than cumulative
+	low := func(r *types.TaxRequest) { r.GrossWage = 6000 }
+	actual := payrollSlips(t, payslipsRequest(low, func(r *types.TaxRequest) { r.TaxCode = "1257L M1" }),
+		types.SalaryChange{Period: 7, Income: 60000})
+
+	rec, err := Reconcile(payslipsRequest(low), FrequencyMonthly, actual, 1, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, rec.Payslips[0].Differences)
+	require.NotEmpty(t, rec.Payslips[6].Differences)
+	assert.Equal(t, FieldTax, rec.Payslips[6].Differences[0].Field)
+	require.Len(t, rec.Findings, 1)
+	assert.Contains(t, rec.Findings[0], "Tax matches 1257L M1 in every payslip")
+	assert.Contains(t, rec.Findings[0], "emergency M1 basis was never reset")
+}
+
+func TestReconcile_WrongTaxCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		code    string
+		finding string
+	}{
+		{"basic rate", "BR", "Tax matches tax code BR rather than 1257L"},
+		{"implied allowance", "1100L", "Tax matches tax code 1100L rather than 1257L"},
+		{"K code", "K100", "Tax matches tax code K100 rather than 1257L"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := payrollSlips(t, payslipsRequest(func(r *types.TaxRequest) { r.TaxCode = tt.code }))
+
+			rec, err := Reconcile(payslipsRequest(), FrequencyMonthly, actual, 1, nil)
+			require.NoError(t, err)
+			require.Len(t, rec.Findings, 1)
+			assert.Contains(t, rec.Findings[0], tt.finding)
+		})
+	}
+}
+
+func TestReconcile_ScottishCodeKeepsPrefix(t *testing.T) {
+	t.Parallel()
+
+	scottish := func(r *types.TaxRequest) {
+		r.TaxRegion = "scotland"
+		r.TaxCode = "S1257L"
+	}
+	actual := payrollSlips(t, payslipsRequest(func(r *types.TaxRequest) {
+		r.TaxRegion = "scotland"
+		r.TaxCode = "SBR"
+	}))
+
+	rec, err := Reconcile(payslipsRequest(scottish), FrequencyMonthly, actual, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, rec.Findings, 1)
+	assert.Contains(t, rec.Findings[0], "Tax matches tax code SBR rather than S1257L")
+}
+
+func TestReconcile_NoNationalInsurance(t *testing.T) {
+	t.Parallel()
+
+	req := payslipsRequest()
+	actual := payrollSlips(t, req)
+	for i := range actual {
+		actual[i].NationalInsurance = 0
+	}
+
+	rec, err := Reconcile(req, FrequencyMonthly, actual, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, rec.Findings, 1)
+	assert.Contains(t, rec.Findings[0], "No National Insurance was deducted")
+}
+
+func TestReconcile_MissingColumns(t *testing.T) {
+	t.Parallel()
+
+	req := payslipsRequest(func(r *types.TaxRequest) { r.Plan = "plan2" })
+	actual := payrollSlips(t, req)
+	for i := range actual {
+		actual[i].NationalInsurance = 0
+		actual[i].StudentLoanRepayment = 0
+		actual[i].Pension = 0
+	}
+
+	rec, err := Reconcile(req, FrequencyMonthly, actual, 1, []string{FieldNI, FieldStudentLoan, FieldPension})
+	require.NoError(t, err)
+	assert.Equal(t, []string{FieldNI, FieldStudentLoan, FieldPension}, rec.Missing)
+	for _, payslip := range rec.Payslips {
+		assert.Empty(t, payslip.Differences)
+	}
+	// Pension isn't expected, so only the deductions that would be checked are reported
+	assert.Equal(t, []string{
+		"NI was not checked, as the payslips have no column for it",
+		"Student Loan was not checked, as the payslips have no column for it",
+	}, rec.Findings[len(rec.Findings)-2:])
+	assert.NotContains(t, strings.Join(rec.Findings, "\n"), "No National Insurance was deducted")
+}
+
+func TestReconcile_StudentLoanPlan(t *testing.T) {
+	t.Parallel()
+
+	actual := payrollSlips(t, payslipsRequest(func(r *types.TaxRequest) { r.Plan = "plan2" }))
+
+	rec, err := Reconcile(payslipsRequest(), FrequencyMonthly, actual, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, rec.Findings, 1)
+	assert.Equal(t, "Student loan deductions match plan2: add --student-loan plan2", rec.Findings[0])
+
+	rec, err = Reconcile(payslipsRequest(func(r *types.TaxRequest) { r.Plan = "plan1" }), FrequencyMonthly, actual, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, rec.Findings, 1)
+	assert.Contains(t, rec.Findings[0], "match plan2 rather than plan1")
+}
+
+func TestReconcile_UsesActualPension(t *testing.T) {
+	t.Parallel()
+
+	actual := payrollSlips(t, payslipsRequest(func(r *types.TaxRequest) { r.Pension = "5%" }))
+
+	rec, err := Reconcile(payslipsRequest(), FrequencyMonthly, actual, 1, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 250, rec.Payslips[0].Expected.Pension, 0.01)
+	assert.Empty(t, rec.Payslips[0].Differences)
+
+	// An expected pension is compared with the pension on each payslip
+	rec, err = Reconcile(payslipsRequest(func(r *types.TaxRequest) { r.Pension = "3%" }), FrequencyMonthly, actual, 1, nil)
+	require.NoError(t, err)
+	assert.Contains(t, rec.Payslips[0].Differences, types.PayslipDifference{Field: FieldPension, Actual: 250, Expected: 150})
+}
+
+func TestReconcile_Errors(t *testing.T) {
+	t.Parallel()
+
+	req := payslipsRequest()
+
+	_, err := Reconcile(req, FrequencyMonthly, []types.Payslip{{Period: 13}}, 1, nil)
+	assert.ErrorContains(t, err, "payslip period 13 is outside 1-12 for monthly pay")
+
+	// Without a pay date there is no week 53
+	_, err = Reconcile(req, FrequencyWeekly, []types.Payslip{{Period: 53}}, 1, nil)
+	assert.ErrorContains(t, err, "payslip period 53 is outside 1-52 for weekly pay")
+
+	_, err = Reconcile(req, FrequencyMonthly, []types.Payslip{{Period: 2}, {Period: 2}}, 1, nil)
+	assert.ErrorContains(t, err, "more than one payslip in period 2")
+
+	_, err = Reconcile(req, "daily", nil, 1, nil)
+	assert.ErrorContains(t, err, "invalid frequency: daily")
+}
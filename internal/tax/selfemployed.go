@@ -0,0 +1,128 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+// paymentsOnAccountThreshold is the self assessment liability above which
+// HMRC asks for payments on account towards the next tax year
+const paymentsOnAccountThreshold = 1000.0
+
+// basicRateRelief is the tax relief added at source to personal pension contributions
+const basicRateRelief = 0.20
+
+// SelfEmployed calculates income tax, Class 2 and Class 4 National Insurance
+// and payments on account for a sole trader with the given trading profit.
+// Pension contributions are treated as relief at source, extending the bands.
+func SelfEmployed(req *types.TaxRequest) (*types.TaxResponse, error) {
+	rates, err := RatesFor(req.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	profit := float64(req.Profit)
+	if profit <= 0 {
+		return nil, fmt.Errorf("profit must be greater than 0")
+	}
+
+	pension, err := ParsePension(req.Pension, profit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Personal allowance, tapered on profit less gross pension contributions
+	allowance := rates.AllowanceFor(profit - pension)
+	if req.Blind == "y" {
+		allowance += rates.BlindAllowance
+	}
+	taxable := math.Max(0, profit-allowance)
+
+	bands := rates.BandsFor(req.TaxRegion)
+	incomeTax, taxDue := IncomeTax(taxable, bands, pension)
+	taxWithoutRelief, _ := IncomeTax(taxable, bands, 0)
+	higherRateRelief := taxWithoutRelief - incomeTax
+
+	marriageAllowance := rates.marriageAllowanceFor(req, taxDue)
+	incomeTax = math.Max(0, incomeTax-marriageAllowance*basicRateRelief)
+
+	class2, class4 := 0.0, 0.0
+	if req.ExNI != "y" {
+		class2, class4 = rates.SelfEmployedNI(profit)
+	}
+
+	studentLoan := rates.StudentLoanRepayment(req.Plan, profit)
+	pensionYou := pension * (1 - basicRateRelief)
+
+	liability := incomeTax + class2 + class4 + studentLoan
+
+	return &types.TaxResponse{
+		TaxYear:                  rates.Year,
+		TaxablePay:               taxable,
+		GrossPay:                 profit,
+		TaxFreeAllowance:         allowance,
+		TaxPaid:                  incomeTax,
+		TaxDue:                   taxDue,
+		NationalInsurance:        class2 + class4,
+		NetPay:                   profit - liability - pensionYou,
+		StudentLoanRepayment:     studentLoan,
+		PensionHMRC:              pension - pensionYou,
+		PensionYou:               pensionYou,
+		PensionClaimback:         higherRateRelief,
+		TaxRegion:                req.TaxRegion,
+		TaxFreeMarriageAllowance: marriageAllowance,
+		SelfEmployment: &types.SelfEmployment{
+			Profit:           profit,
+			Class2NI:         class2,
+			Class4NI:         class4,
+			TotalLiability:   liability,
+			PaymentOnAccount: paymentOnAccount(incomeTax + class4),
+		},
+	}, nil
+}
+
+// paymentOnAccount returns each of the two payments on account due towards the
+// next tax year, based on this year's income tax and Class 4 National Insurance
+func paymentOnAccount(liability float64) float64 {
+	if liability <= paymentsOnAccountThreshold {
+		return 0
+	}
+	return liability / 2
+}
+
+// SelfEmployedNI returns the Class 2 and Class 4 National Insurance due on profits
+func (r *Rates) SelfEmployedNI(profit float64) (class2, class4 float64) {
+	if profit > r.Class2Threshold {
+		class2 = r.Class2Weekly * 52
+	}
+
+	if profit > r.Class4Lower {
+		class4 = (math.Min(profit, r.Class4Upper) - r.Class4Lower) * r.Class4MainRate
+	}
+	if profit > r.Class4Upper {
+		class4 += (profit - r.Class4Upper) * r.Class4UpperRate
+	}
+
+	return class2, class4
+}
+
+// marriageAllowanceFor returns the marriage allowance transferred from a
+// partner with unused allowance, or 0 if the couple is not eligible
+func (r *Rates) marriageAllowanceFor(req *types.TaxRequest, taxDue map[string]types.TaxBracket) float64 {
+	if req.Married == "y" && float64(req.PartnerGrossWage) <= r.PersonalAllowance && isBasicRateTaxpayer(taxDue) {
+		return r.MarriageAllowance
+	}
+	return 0
+}
+
+// isBasicRateTaxpayer reports whether no tax is due above the basic rate
+func isBasicRateTaxpayer(taxDue map[string]types.TaxBracket) bool {
+	for _, bracket := range taxDue {
+		if bracket.Rate > 0.21 && bracket.Amount > 0 {
+			return false
+		}
+	}
+	return true
+}
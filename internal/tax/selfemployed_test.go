@@ -0,0 +1,140 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mheap/listentotaxman-cli/internal/testutil"
+	"github.com/mheap/listentotaxman-cli/internal/types"
+)
+
+func selfEmployedRequest(overrides ...func(*types.TaxRequest)) *types.TaxRequest {
+	return testutil.CreateSampleTaxRequest(append([]func(*types.TaxRequest){
+		func(r *types.TaxRequest) {
+			r.Year = "2025"
+			r.GrossWage = 0
+			r.SelfEmployed = true
+			r.Profit = 60000
+		},
+	}, overrides...)...)
+}
+
+func TestSelfEmployed_Basic(t *testing.T) {
+	t.Parallel()
+
+	resp, err := SelfEmployed(selfEmployedRequest())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2025, resp.TaxYear)
+	assert.InDelta(t, 60000, resp.GrossPay, 0.01)
+	assert.InDelta(t, 47430, resp.TaxablePay, 0.01)
+	assert.InDelta(t, 11432, resp.TaxPaid, 0.01)
+	assert.InDelta(t, 2456.60, resp.NationalInsurance, 0.01)
+	assert.InDelta(t, 46111.40, resp.NetPay, 0.01)
+
+	// Employer fields are zeroed
+	assert.Zero(t, resp.EmployersNI)
+
+	require.NotNil(t, resp.SelfEmployment)
+	assert.Zero(t, resp.SelfEmployment.Class2NI)
+	assert.InDelta(t, 2456.60, resp.SelfEmployment.Class4NI, 0.01)
+	assert.InDelta(t, 13888.60, resp.SelfEmployment.TotalLiability, 0.01)
+	assert.InDelta(t, 6944.30, resp.SelfEmployment.PaymentOnAccount, 0.01)
+}
+
+func TestSelfEmployed_Class2Before2024(t *testing.T) {
+	t.Parallel()
+
+	resp, err := SelfEmployed(selfEmployedRequest(func(r *types.TaxRequest) {
+		r.Year = "2023"
+		r.Profit = 30000
+	}))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 3.45*52, resp.SelfEmployment.Class2NI, 0.01)
+	assert.InDelta(t, (30000-12570)*0.09, resp.SelfEmployment.Class4NI, 0.01)
+}
+
+func TestSelfEmployed_NoPaymentsOnAccountBelowThreshold(t *testing.T) {
+	t.Parallel()
+
+	resp, err := SelfEmployed(selfEmployedRequest(func(r *types.TaxRequest) {
+		r.Profit = 15000
+	}))
+	require.NoError(t, err)
+
+	assert.Zero(t, resp.SelfEmployment.PaymentOnAccount)
+	assert.Positive(t, resp.SelfEmployment.TotalLiability)
+}
+
+func TestSelfEmployed_PensionReliefAtSource(t *testing.T) {
+	t.Parallel()
+
+	resp, err := SelfEmployed(selfEmployedRequest(func(r *types.TaxRequest) {
+		r.Pension = "10000"
+	}))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 8000, resp.PensionYou, 0.01)
+	assert.InDelta(t, 2000, resp.PensionHMRC, 0.01)
+	// Basic rate band extended by £10,000 moves the £9,730 higher rate slice to 20%
+	assert.InDelta(t, 1946, resp.PensionClaimback, 0.01)
+	assert.InDelta(t, 9486, resp.TaxPaid, 0.01)
+}
+
+func TestSelfEmployed_Allowances(t *testing.T) {
+	t.Parallel()
+
+	blind, err := SelfEmployed(selfEmployedRequest(func(r *types.TaxRequest) {
+		r.Blind = "y"
+	}))
+	require.NoError(t, err)
+	assert.InDelta(t, 12570+3130, blind.TaxFreeAllowance, 0.01)
+
+	married, err := SelfEmployed(selfEmployedRequest(func(r *types.TaxRequest) {
+		r.Profit = 30000
+		r.Married = "y"
+		r.PartnerGrossWage = 5000
+	}))
+	require.NoError(t, err)
+	assert.InDelta(t, 1260, married.TaxFreeMarriageAllowance, 0.01)
+	assert.InDelta(t, (30000-12570)*0.20-252, married.TaxPaid, 0.01)
+}
+
+func TestSelfEmployed_NIExemptAndStudentLoan(t *testing.T) {
+	t.Parallel()
+
+	resp, err := SelfEmployed(selfEmployedRequest(func(r *types.TaxRequest) {
+		r.ExNI = "y"
+		r.Plan = "plan2"
+	}))
+	require.NoError(t, err)
+
+	assert.Zero(t, resp.NationalInsurance)
+	assert.InDelta(t, (60000-28470)*0.09, resp.StudentLoanRepayment, 0.01)
+}
+
+func TestSelfEmployed_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		override func(*types.TaxRequest)
+		wantErr  string
+	}{
+		{"unsupported year", func(r *types.TaxRequest) { r.Year = "2015" }, "no local tax rates"},
+		{"zero profit", func(r *types.TaxRequest) { r.Profit = 0 }, "profit must be greater than 0"},
+		{"invalid pension", func(r *types.TaxRequest) { r.Pension = "x%" }, "invalid pension percentage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := SelfEmployed(selfEmployedRequest(tt.override))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package tax
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// kCodeCap is the maximum share of pay that can be deducted as tax under a K code
+const kCodeCap = 0.5
+
+// Emergency bases, where each pay period is taxed on its own
+const (
+	BasisWeek1       = "W1"
+	BasisMonth1      = "M1"
+	BasisWeek1Month1 = "W1/M1"
+	BasisX           = "X"
+)
+
+// taxCodePattern matches a tax code with an optional region prefix and
+// emergency basis. D2 and D3 are only valid with the Scottish prefix, which
+// ParseTaxCode checks
+var taxCodePattern = regexp.MustCompile(`^([SC]?)((?:(\d+)([LMNT])|K(\d+)|BR|D[0-3]|NT))(?: ?(W1/M1|W1|M1|X))?$`)
+
+// TaxCode is a parsed PAYE tax code
+type TaxCode struct {
+	// Code is the normalised code without the emergency basis, e.g. S1257L
+	Code string `json:"code"`
+	// Region is "scotland" or "wales" when the code has an S or C prefix
+	Region string `json:"region,omitempty"`
+	// Suffix is the letter after the allowance (L, M, N or T)
+	Suffix string `json:"suffix,omitempty"`
+	// Allowance is the tax-free pay for the year, negative for K codes
+	Allowance float64 `json:"allowance"`
+	// FlatRate is the code (BR, D0-D3) when all pay is taxed at a single rate
+	FlatRate string `json:"flat_rate,omitempty"`
+	// NoTax is set for NT codes
+	NoTax bool `json:"no_tax"`
+	// Basis is the emergency basis (W1, M1, W1/M1 or X), empty when cumulative
+	Basis string `json:"basis,omitempty"`
+}
+
+// ParseTaxCode parses a PAYE tax code such as 1257L, K475, S1257L, BR, NT or
+// 1257L W1/M1
+func ParseTaxCode(code string) (*TaxCode, error) {
+	normalised := strings.Join(strings.Fields(strings.ToUpper(code)), " ")
+	match := taxCodePattern.FindStringSubmatch(normalised)
+	if match == nil {
+		return nil, fmt.Errorf("invalid tax code: %s", code)
+	}
+
+	tc := &TaxCode{Code: match[1] + match[2], Suffix: match[4], Basis: match[6]}
+	switch match[1] {
+	case "S":
+		tc.Region = regionScotland
+	case "C":
+		tc.Region = regionWales
+	}
+
+	body := match[2]
+	if (body == "D2" || body == "D3") && tc.Region != regionScotland {
+		return nil, fmt.Errorf("invalid tax code: %s (%s is only used in Scotland, as S%s)", code, body, body)
+	}
+
+	switch {
+	case match[3] != "":
+		n, _ := strconv.Atoi(match[3])
+		tc.Allowance = float64(n) * 10
+	case match[5] != "":
+		n, _ := strconv.Atoi(match[5])
+		tc.Allowance = -float64(n) * 10
+	case body == "NT":
+		tc.NoTax = true
+	default:
+		tc.FlatRate = body
+	}
+
+	return tc, nil
+}
+
+// String returns the code with its emergency basis, e.g. 1257L W1/M1
+func (c *TaxCode) String() string {
+	if c.Basis == "" {
+		return c.Code
+	}
+	return c.Code + " " + c.Basis
+}
+
+// Cumulative reports whether tax is worked out on pay to date rather than
+// each period on its own
+func (c *TaxCode) Cumulative() bool {
+	return c.Basis == ""
+}
+
+// IsKCode reports whether the code adds to taxable pay rather than giving an allowance
+func (c *TaxCode) IsKCode() bool {
+	return c.Allowance < 0
+}
+
+// PAYETax calculates the tax an employer deducts from pay under a tax code.
+// Each employment uses the bands from the bottom, so tax on a second job is
+// only right if its code reflects the first job's use of the allowance and bands.
+func (r *Rates) PAYETax(pay float64, code *TaxCode, bands []Band) (float64, error) {
+	if code.NoTax || pay <= 0 {
+		return 0, nil
+	}
+
+	if code.FlatRate != "" {
+		band, err := flatRateBand(code, bands)
+		if err != nil {
+			return 0, err
+		}
+		return pay * band.Rate, nil
+	}
+
+	tax := periodTax(pay, code.Allowance, bands, 1)
+
+	// K code deductions are capped at half of pay
+	if code.IsKCode() {
+		tax = math.Min(tax, pay*kCodeCap)
+	}
+
+	return tax, nil
+}
+
+// FlatRateBand returns the band whose rate is charged on all pay under a BR or
+// D code, using the code's own region or the given one if it has no prefix
+func (r *Rates) FlatRateBand(code *TaxCode, region string) (Band, error) {
+	if code.Region != "" {
+		region = code.Region
+	}
+	return flatRateBand(code, r.BandsFor(region))
+}
+
+// flatRateBand returns the band for a BR or D code: BR is the basic rate and
+// each D code is one band above the last. It is an error if there is no such
+// band, e.g. SD3 before the Scottish advanced rate
+func flatRateBand(code *TaxCode, bands []Band) (Band, error) {
+	basic := 0
+	for i, band := range bands {
+		if band.Name == "Basic Rate" {
+			basic = i
+			break
+		}
+	}
+
+	offset := 0
+	if strings.HasPrefix(code.FlatRate, "D") {
+		n, _ := strconv.Atoi(strings.TrimPrefix(code.FlatRate, "D"))
+		offset = n + 1
+	}
+
+	if basic+offset >= len(bands) {
+		return Band{}, fmt.Errorf("tax code %s has no band to charge in this tax year", code.Code)
+	}
+	return bands[basic+offset], nil
+}
@@ -0,0 +1,155 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaxCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code string
+		want TaxCode
+	}{
+		{code: "1257L", want: TaxCode{Code: "1257L", Suffix: "L", Allowance: 12570}},
+		{code: " s1257l ", want: TaxCode{Code: "S1257L", Region: "scotland", Suffix: "L", Allowance: 12570}},
+		{code: "C1257L", want: TaxCode{Code: "C1257L", Region: "wales", Suffix: "L", Allowance: 12570}},
+		{code: "1383M", want: TaxCode{Code: "1383M", Suffix: "M", Allowance: 13830}},
+		{code: "0T", want: TaxCode{Code: "0T", Suffix: "T", Allowance: 0}},
+		{code: "K475", want: TaxCode{Code: "K475", Allowance: -4750}},
+		{code: "BR", want: TaxCode{Code: "BR", FlatRate: "BR"}},
+		{code: "SD2", want: TaxCode{Code: "SD2", Region: "scotland", FlatRate: "D2"}},
+		{code: "NT", want: TaxCode{Code: "NT", NoTax: true}},
+		{code: "1257L W1/M1", want: TaxCode{Code: "1257L", Suffix: "L", Allowance: 12570, Basis: BasisWeek1Month1}},
+		{code: "1257l  w1", want: TaxCode{Code: "1257L", Suffix: "L", Allowance: 12570, Basis: BasisWeek1}},
+		{code: "1257LM1", want: TaxCode{Code: "1257L", Suffix: "L", Allowance: 12570, Basis: BasisMonth1}},
+		{code: "1257L X", want: TaxCode{Code: "1257L", Suffix: "L", Allowance: 12570, Basis: BasisX}},
+		{code: "BR M1", want: TaxCode{Code: "BR", FlatRate: "BR", Basis: BasisMonth1}},
+		{code: "SK475 W1", want: TaxCode{Code: "SK475", Region: "scotland", Allowance: -4750, Basis: BasisWeek1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTaxCode(tt.code)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+}
+
+func TestParseTaxCode_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range []string{"", "L", "1257X", "XBR", "K", "D4", "1257LL", "1257L W2", "1257L W1 M1", "S", "D2", "CD3"} {
+		_, err := ParseTaxCode(code)
+		assert.Error(t, err, code)
+	}
+
+	// D2 and D3 only exist in Scotland
+	_, err := ParseTaxCode("d3")
+	assert.EqualError(t, err, "invalid tax code: d3 (D3 is only used in Scotland, as SD3)")
+}
+
+func TestTaxCode_String(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range []string{"1257L", "S1257L W1/M1", "K475 X", "BR M1"} {
+		parsed, err := ParseTaxCode(code)
+		require.NoError(t, err)
+		assert.Equal(t, code, parsed.String())
+	}
+
+	parsed, err := ParseTaxCode("1257L W1")
+	require.NoError(t, err)
+	assert.False(t, parsed.Cumulative())
+	assert.False(t, parsed.IsKCode())
+}
+
+func TestPAYETax(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		code   string
+		region string
+		pay    float64
+		want   float64
+	}{
+		{name: "standard code", code: "1257L", region: "uk", pay: 60000, want: 11432},
+		{name: "basic rate", code: "BR", region: "uk", pay: 10000, want: 2000},
+		{name: "higher rate", code: "D0", region: "uk", pay: 10000, want: 4000},
+		{name: "additional rate", code: "D1", region: "uk", pay: 10000, want: 4500},
+		{name: "scottish higher rate", code: "SD1", region: "scotland", pay: 10000, want: 4200},
+		{name: "no tax", code: "NT", region: "uk", pay: 50000, want: 0},
+		{name: "k code", code: "K475", region: "uk", pay: 20000, want: 4950},
+		{name: "k code capped at half of pay", code: "K5000", region: "uk", pay: 10000, want: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := ParseTaxCode(tt.code)
+			require.NoError(t, err)
+			got, err := rates.PAYETax(tt.pay, code, rates.BandsFor(tt.region))
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 0.01)
+		})
+	}
+}
+
+func TestFlatRateBand(t *testing.T) {
+	t.Parallel()
+
+	rates, err := RatesFor("2025")
+	require.NoError(t, err)
+
+	tests := []struct {
+		code   string
+		region string
+		want   string
+	}{
+		{code: "BR", region: "uk", want: "Basic Rate"},
+		{code: "D0", region: "uk", want: "Higher Rate"},
+		{code: "D1", region: "uk", want: "Additional"},
+		{code: "SBR", region: "uk", want: "Basic Rate"},
+		{code: "SD0", region: "uk", want: "Intermediate"},
+		{code: "SD3", region: "uk", want: "Top Rate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := ParseTaxCode(tt.code)
+			require.NoError(t, err)
+			band, err := rates.FlatRateBand(code, tt.region)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, band.Name)
+		})
+	}
+}
+
+func TestFlatRateBand_NoBand(t *testing.T) {
+	t.Parallel()
+
+	// There was no Scottish advanced rate before 2024, so no SD3
+	rates, err := RatesFor("2023")
+	require.NoError(t, err)
+
+	code, err := ParseTaxCode("SD3")
+	require.NoError(t, err)
+	_, err = rates.FlatRateBand(code, "uk")
+	assert.EqualError(t, err, "tax code SD3 has no band to charge in this tax year")
+
+	_, err = rates.PAYETax(10000, code, rates.BandsFor("scotland"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,8 @@
+// Package terminal switches a terminal into raw mode and reads its size, for
+// full-screen output.
+package terminal
+
+import "errors"
+
+// ErrUnsupported is returned on platforms without raw mode support
+var ErrUnsupported = errors.New("full-screen mode is not supported on this platform")
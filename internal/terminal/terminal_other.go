@@ -0,0 +1,18 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package terminal
+
+// IsTerminal reports whether the file descriptor is a terminal
+func IsTerminal(_ int) bool {
+	return false
+}
+
+// MakeRaw is not supported on this platform
+func MakeRaw(_ int) (func() error, error) {
+	return nil, ErrUnsupported
+}
+
+// Size is not supported on this platform
+func Size(_ int) (int, int, error) {
+	return 0, 0, ErrUnsupported
+}
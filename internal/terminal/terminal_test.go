@@ -0,0 +1,26 @@
+package terminal
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTerminal_Pipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = r.Close()
+		_ = w.Close()
+	})
+
+	fd := int(r.Fd()) // #nosec G115 -- file descriptors fit in an int
+	assert.False(t, IsTerminal(fd))
+
+	_, err = MakeRaw(fd)
+	assert.Error(t, err)
+	_, _, err = Size(fd)
+	assert.Error(t, err)
+}
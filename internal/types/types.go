@@ -17,10 +17,66 @@ type TaxRequest struct {
 	Blind            string `json:"blind,omitempty"`
 	ExNI             string `json:"exNI,omitempty"`
 	PartnerGrossWage int    `json:"partnerGrossWage,omitempty"`
+
+	// Local calculation fields (not sent to the API)
+	SelfEmployed    bool         `json:"-"`
+	Profit          int          `json:"-"`
+	Dividends       int          `json:"-"`
+	SavingsInterest int          `json:"-"`
+	RentalProfit    int          `json:"-"`
+	Jobs            []Employment `json:"-"`
+	PensionScheme   string       `json:"-"`
+	EmployerPension string       `json:"-"`
+
+	// Display fields (not sent to the API)
+	WorkPattern WorkPattern `json:"-"`
+	Rate        *IncomeRate `json:"-"`
+	ProRata     *ProRata    `json:"-"`
+}
+
+// ProRata is part-time pay worked out from a full-time-equivalent salary, as
+// a fraction of full-time (FTE) or a number of days of a full-time week
+type ProRata struct {
+	FTE             float64     `json:"fte"`
+	Days            float64     `json:"days,omitempty"`
+	FullTimeIncome  int         `json:"full_time_income"`
+	Income          int         `json:"income"`
+	FullTimePattern WorkPattern `json:"-"`
+}
+
+// IncomeRate is an hourly, daily, weekly or monthly rate of pay and the
+// annual salary it converts to under the working pattern
+type IncomeRate struct {
+	Rate         float64 `json:"rate"`
+	Per          string  `json:"per"`
+	UnitsPerYear float64 `json:"units_per_year"`
+	AnnualIncome int     `json:"annual_income"`
+}
+
+// WorkPattern is the working week used for daily and hourly figures, and the
+// number of pay days for weekly, fortnightly and four-weekly figures. Unset
+// values default to 40 hours over 5 days with no holiday, and daily figures
+// count every calendar day unless days per week or holiday is set, or pay is
+// given as a rate. Pay days default to 52 weekly, 26 fortnightly or 13
+// four-weekly.
+type WorkPattern struct {
+	HoursPerWeek float64 `json:"hours_per_week,omitempty"`
+	DaysPerWeek  float64 `json:"days_per_week,omitempty"`
+	HolidayDays  float64 `json:"holiday_days,omitempty"`
+	PayDays      int     `json:"pay_days,omitempty"`
+}
+
+// Employment is one job in a multiple employment calculation
+type Employment struct {
+	Label   string `json:"label"`
+	Income  int    `json:"income"`
+	TaxCode string `json:"tax_code"`
+	Pension string `json:"pension,omitempty"`
 }
 
 // TaxBracket represents tax at a specific rate
 type TaxBracket struct {
+	Name   string  `json:"name,omitempty"`
 	Rate   float64 `json:"rate"`
 	Amount float64 `json:"amount"`
 }
@@ -50,6 +106,242 @@ type TaxResponse struct {
 	Debug                    interface{}           `json:"debug"`
 	ChildcareAmount          float64               `json:"childcare_amount"`
 	Previous                 *TaxResponse          `json:"previous,omitempty"`
+	SelfEmployment           *SelfEmployment       `json:"self_employment,omitempty"`
+	OtherIncome              *OtherIncome          `json:"other_income,omitempty"`
+	Employments              *Employments          `json:"employments,omitempty"`
+	Rate                     *IncomeRate           `json:"rate,omitempty"`
+	ProRata                  *ProRata              `json:"pro_rata,omitempty"`
+	FullTime                 *TaxResponse          `json:"full_time,omitempty"`
+	PensionScheme            *PensionScheme        `json:"pension_scheme,omitempty"`
+	RateChanges              []RateChange          `json:"rate_changes,omitempty"`
+	Real                     *RealTerms            `json:"real,omitempty"`
+	DisplayCurrency          *DisplayCurrency      `json:"display_currency,omitempty"`
+	Explanation              []ExplanationStep     `json:"explanation,omitempty"`
+}
+
+// ExplanationStep is one step in working out a calculation: the amount it
+// produces and, where a rate applies, the amount the rate is applied to and
+// the threshold it starts from
+type ExplanationStep struct {
+	Section   string  `json:"section"`
+	Name      string  `json:"name"`
+	Amount    float64 `json:"amount"`
+	Base      float64 `json:"base,omitempty"`
+	Rate      float64 `json:"rate,omitempty"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Note      string  `json:"note,omitempty"`
+}
+
+// DisplayCurrency is the main figures converted from pounds to the currency
+// amounts are shown in. Amounts are calculated in pounds, which the rest of
+// the response keeps
+type DisplayCurrency struct {
+	Currency             string  `json:"currency"`
+	Rate                 float64 `json:"rate"`
+	GrossPay             float64 `json:"gross_pay"`
+	TaxPaid              float64 `json:"tax_paid"`
+	NationalInsurance    float64 `json:"national_insurance"`
+	StudentLoanRepayment float64 `json:"student_loan_repayment"`
+	PensionYou           float64 `json:"pension_you"`
+	NetPay               float64 `json:"net_pay"`
+}
+
+// RealTerms is gross and net pay in a base year's prices, adjusted by CPI,
+// so pay from different tax years can be compared after inflation
+type RealTerms struct {
+	BaseYear int     `json:"base_year"`
+	Factor   float64 `json:"factor"`
+	GrossPay float64 `json:"gross_pay"`
+	NetPay   float64 `json:"net_pay"`
+	// Note says when a year's CPI value is missing and the latest is used
+	Note string `json:"note,omitempty"`
+}
+
+// RateChange is an allowance, threshold or rate compared with the previous tax
+// year. Thresholds are listed even when frozen, since a frozen threshold drags
+// more of a rising salary into tax; rates are only listed when they change.
+type RateChange struct {
+	Name string  `json:"name"`
+	Kind string  `json:"kind"`
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+}
+
+// PensionScheme holds the pension contributions and the tax and National
+// Insurance saved under a pension scheme, plus the employer's contribution
+type PensionScheme struct {
+	Scheme           string  `json:"scheme,omitempty"`
+	Contribution     float64 `json:"contribution"`
+	BasicRateTopUp   float64 `json:"basic_rate_top_up,omitempty"`
+	HigherRateRelief float64 `json:"higher_rate_relief,omitempty"`
+	EmployeeNISaving float64 `json:"employee_ni_saving,omitempty"`
+	EmployerNISaving float64 `json:"employer_ni_saving,omitempty"`
+	Employer         float64 `json:"employer"`
+	Total            float64 `json:"total"`
+}
+
+// SelfEmployment holds the self assessment figures for a sole trader
+type SelfEmployment struct {
+	Profit           float64 `json:"profit"`
+	Class2NI         float64 `json:"class2_ni"`
+	Class4NI         float64 `json:"class4_ni"`
+	TotalLiability   float64 `json:"total_liability"`
+	PaymentOnAccount float64 `json:"payment_on_account"`
+}
+
+// OtherIncome holds rental, savings and dividend income, which is taxed on top
+// of employment income
+type OtherIncome struct {
+	RentalProfit    float64      `json:"rental_profit"`
+	SavingsInterest float64      `json:"savings_interest"`
+	Dividends       float64      `json:"dividends"`
+	AllowanceLost   float64      `json:"allowance_lost"`
+	Tax             float64      `json:"tax"`
+	Bands           []IncomeBand `json:"bands"`
+}
+
+// Employments holds the PAYE deductions for each job and the expected
+// under or overpayment once the year-end liability is reconciled
+type Employments struct {
+	Jobs         []EmploymentResult `json:"jobs"`
+	PAYETax      float64            `json:"paye_tax"`
+	Liability    float64            `json:"liability"`
+	Underpayment float64            `json:"underpayment"`
+}
+
+// EmploymentResult is the PAYE calculation for a single job
+type EmploymentResult struct {
+	Label                string  `json:"label"`
+	TaxCode              string  `json:"tax_code"`
+	GrossPay             float64 `json:"gross_pay"`
+	Pension              float64 `json:"pension"`
+	TaxPaid              float64 `json:"tax_paid"`
+	NationalInsurance    float64 `json:"national_insurance"`
+	StudentLoanRepayment float64 `json:"student_loan_repayment"`
+	EmployersNI          float64 `json:"employers_ni"`
+	NetPay               float64 `json:"net_pay"`
+}
+
+// IncomeBand is the portion of one type of income that falls in a single band
+type IncomeBand struct {
+	Income string  `json:"income"`
+	Band   string  `json:"band"`
+	Rate   float64 `json:"rate"`
+	Amount float64 `json:"amount"`
+	Tax    float64 `json:"tax"`
+}
+
+// SalaryChange changes the annual salary or tax code from a pay period onwards
+type SalaryChange struct {
+	Period  int    `json:"period"`
+	Income  int    `json:"income,omitempty"`
+	TaxCode string `json:"tax_code,omitempty"`
+}
+
+// Payslip is the pay and deductions for a single pay period, with year-to-date totals
+type Payslip struct {
+	Period                  int     `json:"period"`
+	Date                    string  `json:"date,omitempty"`
+	TaxCode                 string  `json:"tax_code"`
+	GrossPay                float64 `json:"gross_pay"`
+	TaxPaid                 float64 `json:"tax_paid"`
+	NationalInsurance       float64 `json:"national_insurance"`
+	StudentLoanRepayment    float64 `json:"student_loan_repayment"`
+	Pension                 float64 `json:"pension"`
+	NetPay                  float64 `json:"net_pay"`
+	GrossPayToDate          float64 `json:"gross_pay_to_date"`
+	TaxPaidToDate           float64 `json:"tax_paid_to_date"`
+	NationalInsuranceToDate float64 `json:"national_insurance_to_date"`
+	StudentLoanToDate       float64 `json:"student_loan_to_date"`
+	PensionToDate           float64 `json:"pension_to_date"`
+	NetPayToDate            float64 `json:"net_pay_to_date"`
+}
+
+// PayslipDifference is a deduction on a real payslip that differs from the expected amount
+type PayslipDifference struct {
+	Field    string  `json:"field"`
+	Actual   float64 `json:"actual"`
+	Expected float64 `json:"expected"`
+}
+
+// ReconciledPayslip pairs a real payslip with the deductions expected for the same pay
+type ReconciledPayslip struct {
+	Actual      Payslip             `json:"actual"`
+	Expected    Payslip             `json:"expected"`
+	Differences []PayslipDifference `json:"differences,omitempty"`
+}
+
+// Reconciliation compares real payslips with the expected deductions and
+// suggests likely causes of any differences
+type Reconciliation struct {
+	TaxYear   int                 `json:"tax_year"`
+	Frequency string              `json:"frequency"`
+	TaxCode   string              `json:"tax_code"`
+	Tolerance float64             `json:"tolerance"`
+	Payslips  []ReconciledPayslip `json:"payslips"`
+	Missing   []string            `json:"missing,omitempty"`
+	Findings  []string            `json:"findings"`
+}
+
+// EmployerCostRequest is a salary, or a total budget to solve backwards, for
+// one or more hires on the same salary
+type EmployerCostRequest struct {
+	Year                string
+	Salary              int
+	Budget              int
+	Hires               int
+	EmployerPension     string
+	EmploymentAllowance bool
+	PayBill             int
+}
+
+// EmployerCost is the cost to the business of one or more hires. Salary is
+// per hire; the other figures are totals across all hires.
+type EmployerCost struct {
+	TaxYear             int     `json:"tax_year"`
+	Hires               int     `json:"hires"`
+	Budget              float64 `json:"budget,omitempty"`
+	Salary              float64 `json:"salary"`
+	GrossPay            float64 `json:"gross_pay"`
+	EmployersNI         float64 `json:"employers_ni"`
+	EmploymentAllowance float64 `json:"employment_allowance"`
+	ApprenticeshipLevy  float64 `json:"apprenticeship_levy"`
+	EmployerPension     float64 `json:"employer_pension"`
+	TotalCost           float64 `json:"total_cost"`
+}
+
+// PayRise analyses a raise: how much reaches net pay, the marginal rate across
+// it, the extra pension contribution that would keep take-home pay unchanged,
+// and the thresholds the raise crosses
+type PayRise struct {
+	TaxYear       int          `json:"tax_year"`
+	From          float64      `json:"from"`
+	To            float64      `json:"to"`
+	Raise         float64      `json:"raise"`
+	NetIncrease   float64      `json:"net_increase"`
+	Kept          float64      `json:"kept"`
+	MarginalRate  float64      `json:"marginal_rate"`
+	PensionScheme string       `json:"pension_scheme"`
+	ExtraPension  float64      `json:"extra_pension"`
+	Thresholds    []Threshold  `json:"thresholds,omitempty"`
+	Before        *TaxResponse `json:"before"`
+	After         *TaxResponse `json:"after"`
+}
+
+// Threshold is a point on the salary scale where tax, NI or student loan
+// deductions change
+type Threshold struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+// BatchResult is the calculation for one row of a batch input, or the error
+// that stopped it
+type BatchResult struct {
+	Row    int          `json:"row"`
+	Label  string       `json:"label"`
+	Result *TaxResponse `json:"result,omitempty"`
+	Error  string       `json:"error,omitempty"`
 }
 
 // ComparisonResult represents one option's calculation result with its label